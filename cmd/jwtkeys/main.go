@@ -0,0 +1,286 @@
+// Command jwtkeys manages the JWT keyring directory read by the server when
+// JWT_KEYRING_DIR is set.
+//
+// A rotation is three steps, each followed by a rolling restart:
+//
+//	jwtkeys -dir /etc/strive/jwt generate -alg EdDSA   # new key, verification only
+//	jwtkeys -dir /etc/strive/jwt promote <kid>         # sign new tokens with it
+//	jwtkeys -dir /etc/strive/jwt retire <old kid>      # once the old tokens expired
+//
+// Adding the key before promoting it lets every instance (and every consumer
+// of /.well-known/jwks.json) accept it before the first token signed with it
+// appears. Between the last two steps, demote replaces the old key's private
+// key file with its public key, so nothing can sign with it any more.
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/config"
+)
+
+func main() {
+	dir := flag.String("dir", os.Getenv("JWT_KEYRING_DIR"), "keyring directory (defaults to $JWT_KEYRING_DIR)")
+	flag.Usage = usage
+	flag.Parse()
+
+	if *dir == "" || flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	if err := run(*dir, flag.Arg(0), flag.Args()[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "jwtkeys: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `Usage: jwtkeys -dir <keyring dir> <command> [arguments]
+
+Commands:
+  list                         show the keys and which one signs
+  generate [-alg A] [-id kid]  add a verification-only key (HS256, RS256 or EdDSA; default EdDSA)
+  promote <kid>                sign new tokens with the key
+  demote <kid>                 keep only the public half of a key that no longer signs
+  retire <kid>                 remove a key that no longer signs
+`)
+}
+
+func run(dir, command string, args []string) error {
+	switch command {
+	case "list":
+		return list(dir)
+	case "generate":
+		return generate(dir, args)
+	case "promote":
+		if len(args) != 1 {
+			return errors.New("promote takes exactly one key ID")
+		}
+		return promote(dir, args[0])
+	case "demote":
+		if len(args) != 1 {
+			return errors.New("demote takes exactly one key ID")
+		}
+		return demote(dir, args[0])
+	case "retire":
+		if len(args) != 1 {
+			return errors.New("retire takes exactly one key ID")
+		}
+		return retire(dir, args[0])
+	default:
+		return fmt.Errorf("unknown command %q", command)
+	}
+}
+
+func list(dir string) error {
+	manifest, err := config.ReadKeyringManifest(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range manifest.Keys {
+		role := "verify"
+		if entry.ID == manifest.SigningKey {
+			role = "sign"
+		}
+		fmt.Printf("%-24s %-6s %-6s %s\n", entry.ID, entry.Algorithm, role, entry.File)
+	}
+	return nil
+}
+
+func generate(dir string, args []string) error {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	algorithm := flags.String("alg", config.JWTAlgorithmEdDSA, "HS256, RS256 or EdDSA")
+	id := flags.String("id", time.Now().UTC().Format("20060102T150405Z"), "key ID (kid)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := validateKeyID(*id); err != nil {
+		return err
+	}
+
+	manifest, err := config.ReadKeyringManifest(dir)
+	if err != nil {
+		return err
+	}
+	if manifest.Find(*id) != nil {
+		return fmt.Errorf("key %s already exists", *id)
+	}
+
+	data, err := newKeyFile(*algorithm)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create keyring directory: %w", err)
+	}
+	file := *id + ".key"
+	if err := os.WriteFile(filepath.Join(dir, file), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write key file: %w", err)
+	}
+
+	manifest.Keys = append(manifest.Keys, config.KeyringEntry{ID: *id, Algorithm: *algorithm, File: file})
+	// The first key of a new keyring has nothing to wait for.
+	if manifest.SigningKey == "" {
+		manifest.SigningKey = *id
+	}
+	if err := config.WriteKeyringManifest(dir, manifest); err != nil {
+		return err
+	}
+
+	fmt.Println(*id)
+	return nil
+}
+
+// validateKeyID rejects IDs that are not safe to name the key's file after.
+func validateKeyID(id string) error {
+	if id == "" || id == "." || strings.ContainsAny(id, `/\`) || strings.Contains(id, "..") {
+		return fmt.Errorf("invalid key ID %q: it names the key file, so it cannot contain path separators or \"..\"", id)
+	}
+	return nil
+}
+
+func newKeyFile(algorithm string) ([]byte, error) {
+	switch algorithm {
+	case config.JWTAlgorithmHS256:
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate secret: %w", err)
+		}
+		return []byte(hex.EncodeToString(secret) + "\n"), nil
+	case config.JWTAlgorithmRS256:
+		key, err := rsa.GenerateKey(rand.Reader, 3072)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate RSA key: %w", err)
+		}
+		return encodePKCS8(key)
+	case config.JWTAlgorithmEdDSA:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate Ed25519 key: %w", err)
+		}
+		return encodePKCS8(key)
+	default:
+		return nil, fmt.Errorf("invalid JWT algorithm: %s", algorithm)
+	}
+}
+
+func encodePKCS8(key any) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+func promote(dir, id string) error {
+	manifest, err := config.ReadKeyringManifest(dir)
+	if err != nil {
+		return err
+	}
+	if manifest.Find(id) == nil {
+		return fmt.Errorf("key %s is not in the keyring", id)
+	}
+
+	manifest.SigningKey = id
+	return config.WriteKeyringManifest(dir, manifest)
+}
+
+func demote(dir, id string) error {
+	manifest, err := config.ReadKeyringManifest(dir)
+	if err != nil {
+		return err
+	}
+	if id == manifest.SigningKey {
+		return fmt.Errorf("key %s still signs tokens; promote another key first", id)
+	}
+	entry := manifest.Find(id)
+	if entry == nil {
+		return fmt.Errorf("key %s is not in the keyring", id)
+	}
+	if entry.Algorithm == config.JWTAlgorithmHS256 {
+		return fmt.Errorf("key %s is an HS256 secret, which has no public half", id)
+	}
+	if err := validateKeyID(id); err != nil {
+		return err
+	}
+
+	private, _, err := config.LoadKeyringKey(filepath.Join(dir, entry.File))
+	if err != nil {
+		return fmt.Errorf("failed to load key %s: %w", id, err)
+	}
+	if private == nil {
+		return fmt.Errorf("key %s already only verifies tokens", id)
+	}
+	data, err := config.EncodePublicKey(private)
+	if err != nil {
+		return err
+	}
+
+	oldFile := entry.File
+	entry.File = id + ".pub"
+	if err := os.WriteFile(filepath.Join(dir, entry.File), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write public key file: %w", err)
+	}
+	if err := config.WriteKeyringManifest(dir, manifest); err != nil {
+		return err
+	}
+
+	removeKeyFile(dir, id, oldFile)
+	return nil
+}
+
+// removeKeyFile deletes a key file the manifest no longer references, so a
+// failed removal is harmless. Paths leaving dir are never followed.
+func removeKeyFile(dir, id, file string) {
+	if !filepath.IsLocal(file) {
+		fmt.Fprintf(os.Stderr, "jwtkeys: key %s file %q is outside the keyring and was not removed\n", id, file)
+		return
+	}
+	if err := os.Remove(filepath.Join(dir, file)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(os.Stderr, "jwtkeys: key %s file %s was not removed: %v\n", id, file, err)
+	}
+}
+
+func retire(dir, id string) error {
+	manifest, err := config.ReadKeyringManifest(dir)
+	if err != nil {
+		return err
+	}
+	if id == manifest.SigningKey {
+		return fmt.Errorf("key %s still signs tokens; promote another key first", id)
+	}
+	entry := manifest.Find(id)
+	if entry == nil {
+		return fmt.Errorf("key %s is not in the keyring", id)
+	}
+	file := entry.File
+
+	kept := manifest.Keys[:0]
+	for _, key := range manifest.Keys {
+		if key.ID != id {
+			kept = append(kept, key)
+		}
+	}
+	manifest.Keys = kept
+	if err := config.WriteKeyringManifest(dir, manifest); err != nil {
+		return err
+	}
+
+	removeKeyFile(dir, id, file)
+	return nil
+}
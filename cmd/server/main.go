@@ -1,15 +1,19 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
+	"time"
 
 	_ "github.com/aleksandr/strive-api/docs"
 	"github.com/aleksandr/strive-api/internal/config"
 	"github.com/aleksandr/strive-api/internal/database"
 	httphandler "github.com/aleksandr/strive-api/internal/http"
 	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/mail"
 	"github.com/aleksandr/strive-api/internal/migrate"
+	"github.com/aleksandr/strive-api/internal/models"
 	"github.com/aleksandr/strive-api/internal/repositories"
 	"github.com/aleksandr/strive-api/internal/services"
 	httpSwagger "github.com/swaggo/http-swagger"
@@ -45,11 +49,12 @@ func main() {
 	runMigrations(cfg, logger)
 
 	// Initialize services and handlers
-	authService := setupServices(db, cfg)
-	handlers := setupHandlers(authService, logger, db, cfg)
+	svcs := setupServices(db, cfg, setupMailer(cfg, logger))
+	handlers := setupHandlers(svcs, logger, db, cfg)
+	go pruneRevokedTokens(svcs.Revocation, logger)
 
 	// Setup routes and middleware
-	handler := setupRoutes(handlers, logger, authService, cfg)
+	handler := setupRoutes(handlers, logger, svcs, cfg)
 
 	// Start server
 	server := httphandler.NewServer(cfg, handler, logger)
@@ -85,36 +90,162 @@ func runMigrations(cfg *config.Config, logger *logger.Logger) {
 	}
 }
 
-func setupServices(db *database.Database, cfg *config.Config) services.AuthService {
+func setupMailer(cfg *config.Config, logger *logger.Logger) mail.Sender {
+	mailer, err := mail.NewSender(&cfg.Mail, logger)
+	if err != nil {
+		log.Fatalf("Failed to set up mail sender: %v", err)
+	}
+	return mailer
+}
+
+// pruneRevokedTokens periodically deletes revocations of expired access
+// tokens, keeping that work off the request path.
+func pruneRevokedTokens(revocations services.TokenRevocationService, logger *logger.Logger) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := revocations.PruneExpired(context.Background()); err != nil {
+			logger.Warn("Failed to prune revoked access tokens", "error", err)
+		}
+	}
+}
+
+type Services struct {
+	Auth            services.AuthService
+	User            services.UserService
+	PasswordReset   services.PasswordResetService
+	Verification    services.EmailVerificationService
+	EmailChange     services.EmailChangeService
+	Session         services.SessionService
+	MFA             services.MFAService
+	Admin           services.AdminService
+	Audit           services.AuditService
+	Revocation      services.TokenRevocationService
+	Workout         services.WorkoutService
+	WorkoutExercise services.WorkoutExerciseService
+	Exercise        services.ExerciseService
+	Template        services.TemplateService
+	Program         services.ProgramService
+	Record          services.RecordService
+	Stats           services.StatsService
+	Measurement     services.MeasurementService
+}
+
+func setupServices(db *database.Database, cfg *config.Config, mailer mail.Sender) *Services {
 	userRepo := repositories.NewUserRepository(db.Pool())
 	refreshTokenRepo := repositories.NewRefreshTokenRepository(db.Pool())
-	authService := services.NewAuthService(userRepo, refreshTokenRepo, &cfg.JWT)
-	return authService
+	workoutRepo := repositories.NewWorkoutRepository(db.Pool())
+	workoutExerciseRepo := repositories.NewWorkoutExerciseRepository(db.Pool())
+	exerciseSetRepo := repositories.NewExerciseSetRepository(db.Pool())
+	exerciseRepo := repositories.NewExerciseRepository(db.Pool())
+	templateRepo := repositories.NewTemplateRepository(db.Pool())
+	programRepo := repositories.NewProgramRepository(db.Pool())
+	enrollmentRepo := repositories.NewEnrollmentRepository(db.Pool())
+	recordRepo := repositories.NewRecordRepository(db.Pool())
+	passwordResetRepo := repositories.NewPasswordResetRepository(db.Pool())
+	verificationRepo := repositories.NewEmailVerificationRepository(db.Pool())
+	emailChangeRepo := repositories.NewEmailChangeRepository(db.Pool())
+	revocationRepo := repositories.NewTokenRevocationRepository(db.Pool())
+	mfaRepo := repositories.NewMFARepository(db.Pool())
+	loginThrottle := services.NewLoginThrottleService(
+		repositories.NewLoginFailureRepository(db.Pool()), userRepo, mailer, &cfg.Auth,
+	)
+
+	auditService := services.NewAuditService(repositories.NewAuditRepository(db.Pool()))
+	revocationService := services.NewTokenRevocationService(revocationRepo, cfg.Auth.RevocationSyncInterval)
+	authService := services.NewAuthService(
+		userRepo, refreshTokenRepo, mfaRepo, loginThrottle, auditService, revocationService, &cfg.JWT, &cfg.Auth,
+	)
+	sessionService := services.NewSessionService(refreshTokenRepo)
+	passwordResetService := services.NewPasswordResetService(
+		userRepo, refreshTokenRepo, passwordResetRepo, mailer, auditService, revocationService, &cfg.Auth,
+	)
+	adminService := services.NewAdminService(
+		userRepo, refreshTokenRepo, auditService, revocationService, sessionService, passwordResetService,
+	)
+
+	recordService := services.NewRecordService(recordRepo, workoutExerciseRepo, exerciseSetRepo, exerciseRepo)
+	workoutExerciseService := services.NewWorkoutExerciseService(
+		workoutRepo, workoutExerciseRepo, exerciseSetRepo, exerciseRepo, recordService,
+	)
+
+	return &Services{
+		Auth:            authService,
+		User:            services.NewUserService(userRepo),
+		PasswordReset:   passwordResetService,
+		Verification:    services.NewEmailVerificationService(userRepo, verificationRepo, mailer, &cfg.Auth),
+		EmailChange:     services.NewEmailChangeService(userRepo, emailChangeRepo, mailer, auditService, &cfg.Auth),
+		Session:         sessionService,
+		MFA:             services.NewMFAService(userRepo, mfaRepo, loginThrottle, auditService, &cfg.Auth),
+		Admin:           adminService,
+		Audit:           auditService,
+		Revocation:      revocationService,
+		Workout:         services.NewWorkoutService(workoutRepo),
+		WorkoutExercise: workoutExerciseService,
+		Exercise:        services.NewExerciseService(exerciseRepo),
+		Template:        services.NewTemplateService(templateRepo, workoutRepo, exerciseRepo),
+		Program:         services.NewProgramService(programRepo, enrollmentRepo, templateRepo, workoutRepo, exerciseRepo),
+		Record:          recordService,
+		Stats:           services.NewStatsService(repositories.NewStatsRepository(db.Pool())),
+		Measurement:     services.NewMeasurementService(repositories.NewMeasurementRepository(db.Pool())),
+	}
 }
 
 type Handlers struct {
-	Auth   *httphandler.AuthHandlers
-	Health *httphandler.DetailedHealthHandler
+	Auth            *httphandler.AuthHandlers
+	User            *httphandler.UserHandlers
+	PasswordReset   *httphandler.PasswordResetHandlers
+	Verification    *httphandler.EmailVerificationHandlers
+	EmailChange     *httphandler.EmailChangeHandlers
+	Session         *httphandler.SessionHandlers
+	MFA             *httphandler.MFAHandlers
+	Admin           *httphandler.AdminHandlers
+	Audit           *httphandler.AuditHandlers
+	Health          *httphandler.DetailedHealthHandler
+	Workout         *httphandler.WorkoutHandlers
+	WorkoutExercise *httphandler.WorkoutExerciseHandlers
+	Exercise        *httphandler.ExerciseHandlers
+	Template        *httphandler.TemplateHandlers
+	Program         *httphandler.ProgramHandlers
+	Record          *httphandler.RecordHandlers
+	Stats           *httphandler.StatsHandlers
+	Measurement     *httphandler.MeasurementHandlers
 }
 
-func setupHandlers(authService services.AuthService, logger *logger.Logger, db *database.Database, cfg *config.Config) *Handlers {
+func setupHandlers(svcs *Services, logger *logger.Logger, db *database.Database, cfg *config.Config) *Handlers {
 	return &Handlers{
-		Auth:   httphandler.NewAuthHandlers(authService, logger, cfg),
-		Health: httphandler.NewDetailedHealthHandler(logger, db.Pool()),
+		Auth:            httphandler.NewAuthHandlers(svcs.Auth, svcs.Verification, svcs.Revocation, logger, cfg),
+		User:            httphandler.NewUserHandlers(svcs.User, logger),
+		PasswordReset:   httphandler.NewPasswordResetHandlers(svcs.PasswordReset, logger),
+		Verification:    httphandler.NewEmailVerificationHandlers(svcs.Verification, logger),
+		EmailChange:     httphandler.NewEmailChangeHandlers(svcs.EmailChange, svcs.Auth, logger),
+		Session:         httphandler.NewSessionHandlers(svcs.Session, logger),
+		MFA:             httphandler.NewMFAHandlers(svcs.MFA, logger),
+		Admin:           httphandler.NewAdminHandlers(svcs.Admin, logger),
+		Audit:           httphandler.NewAuditHandlers(svcs.Audit, logger),
+		Health:          httphandler.NewDetailedHealthHandler(logger, db.Pool()),
+		Workout:         httphandler.NewWorkoutHandlers(svcs.Workout, logger),
+		WorkoutExercise: httphandler.NewWorkoutExerciseHandlers(svcs.WorkoutExercise, logger),
+		Exercise:        httphandler.NewExerciseHandlers(svcs.Exercise, logger),
+		Template:        httphandler.NewTemplateHandlers(svcs.Template, logger),
+		Program:         httphandler.NewProgramHandlers(svcs.Program, logger),
+		Record:          httphandler.NewRecordHandlers(svcs.Record, logger),
+		Stats:           httphandler.NewStatsHandlers(svcs.Stats, logger),
+		Measurement:     httphandler.NewMeasurementHandlers(svcs.Measurement, logger),
 	}
 }
 
-func setupRoutes(handlers *Handlers, logger *logger.Logger, authService services.AuthService, cfg *config.Config) http.Handler {
+func setupRoutes(handlers *Handlers, logger *logger.Logger, svcs *Services, cfg *config.Config) http.Handler {
 	mux := http.NewServeMux()
 
 	// Setup public routes
 	setupPublicRoutes(mux, handlers)
 
 	// Setup protected routes
-	setupProtectedRoutes(mux, authService, logger, handlers)
+	setupProtectedRoutes(mux, svcs, logger, handlers, cfg)
 
 	// Apply middleware
-	return applyMiddleware(mux, logger, cfg)
+	return applyMiddleware(mux, logger, svcs, cfg)
 }
 
 func setupPublicRoutes(mux *http.ServeMux, handlers *Handlers) {
@@ -126,33 +257,153 @@ func setupPublicRoutes(mux *http.ServeMux, handlers *Handlers) {
 	// Auth endpoints
 	mux.HandleFunc("/api/v1/auth/register", handlers.Auth.Register)
 	mux.HandleFunc("/api/v1/auth/login", handlers.Auth.Login)
+	mux.HandleFunc("POST /api/v1/auth/login/mfa", handlers.Auth.LoginMFA)
 	mux.HandleFunc("/api/v1/auth/refresh", handlers.Auth.Refresh)
 	mux.HandleFunc("/api/v1/auth/logout", handlers.Auth.Logout)
+	mux.HandleFunc("POST /api/v1/auth/forgot-password", handlers.PasswordReset.ForgotPassword)
+	mux.HandleFunc("POST /api/v1/auth/reset-password", handlers.PasswordReset.ResetPassword)
+	mux.HandleFunc("POST /api/v1/auth/verify-email", handlers.Verification.VerifyEmail)
+	mux.HandleFunc("POST /api/v1/auth/resend-verification", handlers.Verification.ResendVerification)
+	mux.HandleFunc("GET /.well-known/jwks.json", handlers.Auth.JWKS)
 
 	// Documentation
 	mux.HandleFunc("/swagger/", httpSwagger.WrapHandler)
 }
 
-func setupProtectedRoutes(mux *http.ServeMux, authService services.AuthService, logger *logger.Logger, handlers *Handlers) {
+func setupProtectedRoutes(mux *http.ServeMux, svcs *Services, logger *logger.Logger, handlers *Handlers, cfg *config.Config) {
+	authMiddleware := httphandler.AuthMiddleware(svcs.Auth, svcs.Revocation, logger)
+	currentUserMiddleware := httphandler.CurrentUserMiddleware(svcs.User, logger)
+	verifiedEmailMiddleware := httphandler.RequireVerifiedEmail(cfg.Auth.EmailVerificationPolicy)
+
 	protectedMux := http.NewServeMux()
 
-	protectedMux.HandleFunc("/me", handlers.Auth.Me)
+	protectedMux.HandleFunc("GET /me", handlers.User.Me)
+	protectedMux.HandleFunc("POST /change-password", handlers.Auth.ChangePassword)
+	protectedMux.HandleFunc("POST /change-email", handlers.EmailChange.RequestEmailChange)
+	protectedMux.HandleFunc("POST /change-email/confirm", handlers.EmailChange.ConfirmEmailChange)
+	protectedMux.HandleFunc("GET /sessions", handlers.Session.List)
+	protectedMux.HandleFunc("DELETE /sessions", handlers.Session.RevokeOthers)
+	protectedMux.HandleFunc("DELETE /sessions/{id}", handlers.Session.Revoke)
+	protectedMux.HandleFunc("POST /2fa/totp", handlers.MFA.EnrollTOTP)
+	protectedMux.HandleFunc("POST /2fa/totp/confirm", handlers.MFA.ConfirmTOTP)
+	protectedMux.HandleFunc("POST /2fa/totp/disable", handlers.MFA.DisableTOTP)
+	protectedMux.HandleFunc("GET /activity", handlers.Audit.AccountActivity)
+
+	mux.Handle("/api/v1/auth/", http.StripPrefix("/api/v1/auth", authMiddleware(protectedMux)))
+
+	mux.Handle("/api/v1/admin/", authMiddleware(setupAdminRoutes(handlers, logger)))
 
-	protectedHandler := httphandler.AuthMiddleware(authService, logger)(protectedMux)
+	mux.Handle("/api/v1/", authMiddleware(currentUserMiddleware(verifiedEmailMiddleware(setupAPIRoutes(handlers)))))
+}
+
+func setupAdminRoutes(handlers *Handlers, logger *logger.Logger) *http.ServeMux {
+	adminMux := http.NewServeMux()
+	canRead := httphandler.RequirePermission(logger, models.PermissionUsersRead)
+	canManage := httphandler.RequirePermission(logger, models.PermissionUsersManage)
+	canAudit := httphandler.RequirePermission(logger, models.PermissionAuditRead)
+
+	adminMux.Handle("GET /api/v1/admin/users", canRead(http.HandlerFunc(handlers.Admin.ListUsers)))
+	adminMux.Handle("GET /api/v1/admin/users/{id}", canRead(http.HandlerFunc(handlers.Admin.GetUser)))
+	adminMux.Handle("GET /api/v1/admin/users/{id}/sessions", canRead(http.HandlerFunc(handlers.Admin.ListSessions)))
+	adminMux.Handle("POST /api/v1/admin/users/{id}/logout", canManage(http.HandlerFunc(handlers.Admin.ForceLogout)))
+	adminMux.Handle("POST /api/v1/admin/users/{id}/disable", canManage(http.HandlerFunc(handlers.Admin.DisableUser)))
+	adminMux.Handle("POST /api/v1/admin/users/{id}/enable", canManage(http.HandlerFunc(handlers.Admin.EnableUser)))
+	adminMux.Handle("POST /api/v1/admin/users/{id}/password-reset", canManage(http.HandlerFunc(handlers.Admin.SendPasswordReset)))
+	adminMux.Handle("DELETE /api/v1/admin/users/{id}", canManage(http.HandlerFunc(handlers.Admin.DeleteUser)))
+	adminMux.Handle("GET /api/v1/admin/audit-events", canAudit(http.HandlerFunc(handlers.Audit.SearchEvents)))
+
+	return adminMux
+}
 
-	mux.Handle("/api/v1/auth/", http.StripPrefix("/api/v1/auth", protectedHandler))
+func setupAPIRoutes(handlers *Handlers) *http.ServeMux {
+	apiMux := http.NewServeMux()
+
+	// User profile endpoints
+	apiMux.HandleFunc("PATCH /api/v1/users/me", handlers.User.UpdateMe)
+
+	// Workout endpoints
+	apiMux.HandleFunc("POST /api/v1/workouts", handlers.Workout.Create)
+	apiMux.HandleFunc("GET /api/v1/workouts", handlers.Workout.List)
+	apiMux.HandleFunc("GET /api/v1/workouts/{id}", handlers.Workout.Get)
+	apiMux.HandleFunc("PUT /api/v1/workouts/{id}", handlers.Workout.Update)
+	apiMux.HandleFunc("DELETE /api/v1/workouts/{id}", handlers.Workout.Delete)
+
+	// Workout exercise and set endpoints
+	apiMux.HandleFunc("POST /api/v1/workouts/{id}/exercises", handlers.WorkoutExercise.AddExercise)
+	apiMux.HandleFunc("GET /api/v1/workouts/{id}/exercises", handlers.WorkoutExercise.ListExercises)
+	apiMux.HandleFunc("PUT /api/v1/workouts/{id}/exercises/{exerciseId}", handlers.WorkoutExercise.UpdateExercise)
+	apiMux.HandleFunc("DELETE /api/v1/workouts/{id}/exercises/{exerciseId}", handlers.WorkoutExercise.RemoveExercise)
+	apiMux.HandleFunc("POST /api/v1/workouts/{id}/exercises/{exerciseId}/sets", handlers.WorkoutExercise.AddSet)
+	apiMux.HandleFunc("GET /api/v1/workouts/{id}/exercises/{exerciseId}/sets", handlers.WorkoutExercise.ListSets)
+	apiMux.HandleFunc("PUT /api/v1/workouts/{id}/exercises/{exerciseId}/sets/{setId}", handlers.WorkoutExercise.UpdateSet)
+	apiMux.HandleFunc("DELETE /api/v1/workouts/{id}/exercises/{exerciseId}/sets/{setId}", handlers.WorkoutExercise.RemoveSet)
+
+	// Exercise catalog endpoints
+	apiMux.HandleFunc("GET /api/v1/exercises", handlers.Exercise.Search)
+	apiMux.HandleFunc("GET /api/v1/exercises/filters", handlers.Exercise.Filters)
+	apiMux.HandleFunc("POST /api/v1/exercises", handlers.Exercise.Create)
+	apiMux.HandleFunc("GET /api/v1/exercises/{id}", handlers.Exercise.Get)
+	apiMux.HandleFunc("PUT /api/v1/exercises/{id}", handlers.Exercise.Update)
+	apiMux.HandleFunc("DELETE /api/v1/exercises/{id}", handlers.Exercise.Delete)
+	apiMux.HandleFunc("GET /api/v1/exercises/{id}/records", handlers.Record.ListForExercise)
+
+	// Workout template endpoints
+	apiMux.HandleFunc("POST /api/v1/templates", handlers.Template.Create)
+	apiMux.HandleFunc("GET /api/v1/templates", handlers.Template.List)
+	apiMux.HandleFunc("GET /api/v1/templates/{id}", handlers.Template.Get)
+	apiMux.HandleFunc("PUT /api/v1/templates/{id}", handlers.Template.Update)
+	apiMux.HandleFunc("DELETE /api/v1/templates/{id}", handlers.Template.Delete)
+	apiMux.HandleFunc("POST /api/v1/templates/{id}/start", handlers.Template.Start)
+
+	// Training program endpoints
+	apiMux.HandleFunc("POST /api/v1/programs", handlers.Program.Create)
+	apiMux.HandleFunc("GET /api/v1/programs", handlers.Program.List)
+	apiMux.HandleFunc("GET /api/v1/programs/{id}", handlers.Program.Get)
+	apiMux.HandleFunc("PUT /api/v1/programs/{id}", handlers.Program.Update)
+	apiMux.HandleFunc("DELETE /api/v1/programs/{id}", handlers.Program.Delete)
+	apiMux.HandleFunc("POST /api/v1/programs/{id}/enroll", handlers.Program.Enroll)
+	apiMux.HandleFunc("GET /api/v1/enrollment", handlers.Program.CurrentEnrollment)
+	apiMux.HandleFunc("DELETE /api/v1/enrollment", handlers.Program.Unenroll)
+	apiMux.HandleFunc("GET /api/v1/enrollment/today", handlers.Program.Today)
+	apiMux.HandleFunc("POST /api/v1/enrollment/today/start", handlers.Program.StartToday)
+
+	// Personal record endpoints
+	apiMux.HandleFunc("GET /api/v1/records", handlers.Record.List)
+
+	// Statistics endpoints
+	apiMux.HandleFunc("GET /api/v1/stats/volume", handlers.Stats.Volume)
+	apiMux.HandleFunc("GET /api/v1/stats/sets", handlers.Stats.Sets)
+	apiMux.HandleFunc("GET /api/v1/stats/frequency", handlers.Stats.Frequency)
+	apiMux.HandleFunc("GET /api/v1/stats/duration", handlers.Stats.Duration)
+	apiMux.HandleFunc("GET /api/v1/stats/streaks", handlers.Stats.Streaks)
+
+	// Body measurement endpoints
+	apiMux.HandleFunc("POST /api/v1/measurements", handlers.Measurement.Create)
+	apiMux.HandleFunc("GET /api/v1/measurements", handlers.Measurement.List)
+	apiMux.HandleFunc("GET /api/v1/measurements/trend", handlers.Measurement.Trend)
+	apiMux.HandleFunc("GET /api/v1/measurements/{id}", handlers.Measurement.Get)
+	apiMux.HandleFunc("PUT /api/v1/measurements/{id}", handlers.Measurement.Update)
+	apiMux.HandleFunc("DELETE /api/v1/measurements/{id}", handlers.Measurement.Delete)
+
+	return apiMux
 }
 
-func applyMiddleware(mux *http.ServeMux, logger *logger.Logger, cfg *config.Config) http.Handler {
+func applyMiddleware(mux *http.ServeMux, logger *logger.Logger, svcs *Services, cfg *config.Config) http.Handler {
 	corsMiddleware := httphandler.NewCORSMiddleware(&cfg.CORS)
-	rateLimiter := httphandler.NewRateLimiter(&cfg.RateLimit, logger)
+	rateLimiter := httphandler.NewRateLimiter(&cfg.RateLimit, logger, svcs.Audit)
 	securityHeadersMiddleware := httphandler.NewSecurityHeadersMiddleware(&cfg.SecurityHeaders)
 
+	// The client IP, request ID and audit request are set up before the rate
+	// limiter, so rejected requests are recorded with them too.
 	return corsMiddleware(
-		rateLimiter.RateLimitMiddleware()(
-			securityHeadersMiddleware(
-				httphandler.LoggingMiddleware(logger)(
-					httphandler.RequestIDMiddleware()(mux),
+		httphandler.ClientIPMiddleware(cfg.Server.TrustedProxies)(
+			httphandler.RequestIDMiddleware()(
+				httphandler.AuditRequestMiddleware()(
+					rateLimiter.RateLimitMiddleware()(
+						securityHeadersMiddleware(
+							httphandler.LoggingMiddleware(logger)(mux),
+						),
+					),
 				),
 			),
 		),
@@ -1,7 +1,11 @@
 package config
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
 	"fmt"
+	"net/netip"
 	"os"
 	"strconv"
 	"strings"
@@ -17,6 +21,8 @@ type Config struct {
 	Log             LogConfig
 	DB              DatabaseConfig
 	JWT             JWTConfig
+	Auth            AuthConfig
+	Mail            MailConfig
 	RateLimit       RateLimitConfig
 	CORS            CORSConfig
 	SecurityHeaders SecurityHeadersConfig
@@ -27,6 +33,10 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	IdleTimeout  time.Duration
+	// TrustedProxies are the reverse proxies whose X-Forwarded-For and
+	// X-Real-IP headers are believed. Requests from anywhere else are
+	// attributed to the address they arrived from.
+	TrustedProxies []netip.Prefix
 }
 
 type LogConfig struct {
@@ -45,11 +55,105 @@ type DatabaseConfig struct {
 	MinConns int32
 }
 
+// JWT signing algorithms.
+const (
+	JWTAlgorithmHS256 = "HS256"
+	JWTAlgorithmRS256 = "RS256"
+	JWTAlgorithmEdDSA = "EdDSA"
+)
+
+// JWTKey is one key of the keyring. HS256 keys carry Secret; RS256 and EdDSA
+// keys carry PrivateKey, or only PublicKey when they just verify tokens.
+type JWTKey struct {
+	ID         string
+	Algorithm  string
+	Secret     string           `json:"-"`
+	PrivateKey crypto.Signer    `json:"-"`
+	PublicKey  crypto.PublicKey `json:"-"`
+}
+
+// Public returns the key's public half, or nil for HS256.
+func (k JWTKey) Public() crypto.PublicKey {
+	if k.PublicKey != nil {
+		return k.PublicKey
+	}
+	if k.PrivateKey != nil {
+		return k.PrivateKey.Public()
+	}
+	return nil
+}
+
+// CanSign reports whether tokens can be signed with the key.
+func (k JWTKey) CanSign() bool {
+	if k.Algorithm == JWTAlgorithmHS256 {
+		return k.Secret != ""
+	}
+	return k.PrivateKey != nil
+}
+
 type JWTConfig struct {
-	Secret    string
-	Issuer    string
-	Audience  string
-	ClockSkew time.Duration
+	// Algorithm is HS256, signed with Secret, or RS256 / EdDSA, signed with
+	// PrivateKey, whose public half is published as a JWKS.
+	Algorithm string
+	Secret    string `json:"-"`
+	// PrivateKeyFile is a PEM file (PKCS#8, or PKCS#1 for RSA) that Load parses
+	// into PrivateKey.
+	PrivateKeyFile string
+	PrivateKey     crypto.Signer `json:"-"`
+	// KeyID is the kid header of issued tokens; derived from the key when empty.
+	KeyID string
+	// KeyringDir, when set, replaces the single key above with the keyring
+	// described by its manifest: Keys are all accepted for verification, and
+	// SigningKeyID names the one new tokens are signed with.
+	KeyringDir   string
+	Keys         []JWTKey
+	SigningKeyID string
+	Issuer       string
+	Audience     string
+	ClockSkew    time.Duration
+}
+
+// Email verification policies decide what an account can do before its
+// address is confirmed.
+const (
+	// EmailVerificationPolicyNone lets unverified accounts use everything.
+	EmailVerificationPolicyNone = "none"
+	// EmailVerificationPolicyLimit allows sign-in and reads, but rejects writes
+	// outside the account's own profile.
+	EmailVerificationPolicyLimit = "limit"
+	// EmailVerificationPolicyBlock refuses to sign unverified accounts in.
+	EmailVerificationPolicyBlock = "block"
+)
+
+type AuthConfig struct {
+	// AppBaseURL is the frontend origin used to build links in emails.
+	AppBaseURL              string
+	PasswordResetTTL        time.Duration
+	EmailVerificationTTL    time.Duration
+	EmailVerificationPolicy string
+	// RevocationSyncInterval is how often each instance reloads revoked access
+	// tokens, bounding how long a revocation elsewhere takes to apply.
+	RevocationSyncInterval time.Duration
+	// TOTPIssuer names the account in authenticator apps.
+	TOTPIssuer string
+	// After a failed sign-in the account and the IP address must wait before
+	// the next attempt: LoginBackoffBase, doubling with each failure. Reaching
+	// LoginMaxFailures (LoginIPMaxFailures for an IP address) locks it for
+	// LoginLockoutDuration. Failures are forgotten LoginFailureWindow after the
+	// last one.
+	LoginBackoffBase     time.Duration
+	LoginMaxFailures     int
+	LoginIPMaxFailures   int
+	LoginLockoutDuration time.Duration
+	LoginFailureWindow   time.Duration
+}
+
+type MailConfig struct {
+	// Driver selects the sender: "log" writes messages to the application log,
+	// "file" writes one .eml file per message to OutputDir.
+	Driver    string
+	From      string
+	OutputDir string
 }
 
 type RateLimitConfig struct {
@@ -101,10 +205,32 @@ func Load() (*Config, error) {
 			MinConns: int32(getEnvInt("DB_MIN_CONNS", 5)),
 		},
 		JWT: JWTConfig{
-			Secret:    getEnv("JWT_SECRET", ""),
-			Issuer:    getEnv("JWT_ISSUER", "strive-api"),
-			Audience:  getEnv("JWT_AUDIENCE", "strive-app"),
-			ClockSkew: getEnvDuration("JWT_CLOCK_SKEW", 2*time.Minute),
+			Algorithm:      getEnv("JWT_ALGORITHM", JWTAlgorithmHS256),
+			Secret:         getEnv("JWT_SECRET", ""),
+			PrivateKeyFile: getEnv("JWT_PRIVATE_KEY_FILE", ""),
+			KeyID:          getEnv("JWT_KEY_ID", ""),
+			KeyringDir:     getEnv("JWT_KEYRING_DIR", ""),
+			Issuer:         getEnv("JWT_ISSUER", "strive-api"),
+			Audience:       getEnv("JWT_AUDIENCE", "strive-app"),
+			ClockSkew:      getEnvDuration("JWT_CLOCK_SKEW", 2*time.Minute),
+		},
+		Auth: AuthConfig{
+			AppBaseURL:              strings.TrimRight(getEnv("APP_BASE_URL", "http://localhost:4200"), "/"),
+			PasswordResetTTL:        getEnvDuration("AUTH_PASSWORD_RESET_TTL", time.Hour),
+			EmailVerificationTTL:    getEnvDuration("AUTH_EMAIL_VERIFICATION_TTL", 48*time.Hour),
+			EmailVerificationPolicy: getEnv("AUTH_EMAIL_VERIFICATION_POLICY", EmailVerificationPolicyLimit),
+			RevocationSyncInterval:  getEnvDuration("AUTH_REVOCATION_SYNC_INTERVAL", 30*time.Second),
+			TOTPIssuer:              getEnv("AUTH_TOTP_ISSUER", "Strive"),
+			LoginBackoffBase:        getEnvDuration("AUTH_LOGIN_BACKOFF_BASE", time.Second),
+			LoginMaxFailures:        getEnvInt("AUTH_LOGIN_MAX_FAILURES", 5),
+			LoginIPMaxFailures:      getEnvInt("AUTH_LOGIN_IP_MAX_FAILURES", 20),
+			LoginLockoutDuration:    getEnvDuration("AUTH_LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+			LoginFailureWindow:      getEnvDuration("AUTH_LOGIN_FAILURE_WINDOW", time.Hour),
+		},
+		Mail: MailConfig{
+			Driver:    getEnv("MAIL_DRIVER", "log"),
+			From:      getEnv("MAIL_FROM", "Strive <no-reply@strive.local>"),
+			OutputDir: getEnv("MAIL_OUTPUT_DIR", "tmp/mail"),
 		},
 		RateLimit: RateLimitConfig{
 			AuthRequestsPerMinute:    getEnvInt("RATE_LIMIT_AUTH_PER_MINUTE", 5),
@@ -117,7 +243,7 @@ func Load() (*Config, error) {
 				"http://localhost:4200", "http://127.0.0.1:4200",
 				"http://192.168.1.186:4200", "https://satanlittlehelper.github.io",
 			}),
-			AllowedMethods:   getEnvSlice("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}),
+			AllowedMethods:   getEnvSlice("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}),
 			AllowedHeaders:   getEnvSlice("CORS_ALLOWED_HEADERS", []string{"Accept", "Authorization", "Content-Type", "X-Request-ID"}),
 			ExposedHeaders:   getEnvSlice("CORS_EXPOSED_HEADERS", []string{"X-Request-ID"}),
 			AllowCredentials: getEnv("CORS_ALLOW_CREDENTIALS", trueStr) == trueStr,
@@ -138,6 +264,27 @@ func Load() (*Config, error) {
 		},
 	}
 
+	trustedProxies, err := parseTrustedProxies(getEnvSlice("TRUSTED_PROXIES", nil))
+	if err != nil {
+		return nil, fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
+	config.Server.TrustedProxies = trustedProxies
+
+	if config.JWT.KeyringDir != "" {
+		keys, signingKeyID, err := LoadKeyring(config.JWT.KeyringDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load JWT keyring: %w", err)
+		}
+		config.JWT.Keys = keys
+		config.JWT.SigningKeyID = signingKeyID
+	} else if config.JWT.PrivateKeyFile != "" {
+		key, err := loadPrivateKey(config.JWT.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load JWT private key: %w", err)
+		}
+		config.JWT.PrivateKey = key
+	}
+
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("config validation failed: %w", err)
 	}
@@ -180,17 +327,150 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid min connections: %d", c.DB.MinConns)
 	}
 
-	if c.JWT.Secret == "" {
-		return fmt.Errorf("JWT_SECRET is required")
+	if err := c.JWT.validate(); err != nil {
+		return err
+	}
+
+	if c.Auth.PasswordResetTTL <= 0 {
+		return fmt.Errorf("invalid password reset TTL: %s", c.Auth.PasswordResetTTL)
+	}
+
+	if c.Auth.EmailVerificationTTL <= 0 {
+		return fmt.Errorf("invalid email verification TTL: %s", c.Auth.EmailVerificationTTL)
+	}
+
+	if c.Auth.RevocationSyncInterval <= 0 {
+		return fmt.Errorf("invalid revocation sync interval: %s", c.Auth.RevocationSyncInterval)
+	}
+
+	if c.Auth.LoginBackoffBase <= 0 || c.Auth.LoginLockoutDuration < c.Auth.LoginBackoffBase {
+		return fmt.Errorf("invalid login backoff: base %s, lockout %s", c.Auth.LoginBackoffBase, c.Auth.LoginLockoutDuration)
+	}
+
+	if c.Auth.LoginMaxFailures <= 0 || c.Auth.LoginIPMaxFailures <= 0 {
+		return fmt.Errorf("invalid login failure limits: %d per account, %d per IP",
+			c.Auth.LoginMaxFailures, c.Auth.LoginIPMaxFailures)
 	}
 
-	if len(c.JWT.Secret) < 32 {
-		return fmt.Errorf("JWT_SECRET must be at least 32 characters long")
+	// A shorter window would forget failures while the lockout they caused is still running.
+	if c.Auth.LoginFailureWindow < c.Auth.LoginLockoutDuration {
+		return fmt.Errorf("login failure window %s is shorter than the lockout duration %s",
+			c.Auth.LoginFailureWindow, c.Auth.LoginLockoutDuration)
+	}
+
+	// The issuer prefixes the otpauth label, where a colon would split it.
+	if c.Auth.TOTPIssuer == "" || strings.Contains(c.Auth.TOTPIssuer, ":") {
+		return fmt.Errorf("invalid TOTP issuer: %q", c.Auth.TOTPIssuer)
+	}
+
+	validVerificationPolicies := map[string]bool{
+		EmailVerificationPolicyNone:  true,
+		EmailVerificationPolicyLimit: true,
+		EmailVerificationPolicyBlock: true,
+	}
+	if !validVerificationPolicies[c.Auth.EmailVerificationPolicy] {
+		return fmt.Errorf("invalid email verification policy: %s", c.Auth.EmailVerificationPolicy)
+	}
+
+	validMailDrivers := map[string]bool{
+		"log":  true,
+		"file": true,
+	}
+	if !validMailDrivers[c.Mail.Driver] {
+		return fmt.Errorf("invalid mail driver: %s", c.Mail.Driver)
 	}
 
 	return nil
 }
 
+// SingleKey returns the key configured directly, without a keyring.
+func (c *JWTConfig) SingleKey() JWTKey {
+	return JWTKey{ID: c.KeyID, Algorithm: c.Algorithm, Secret: c.Secret, PrivateKey: c.PrivateKey}
+}
+
+func (c *JWTConfig) validate() error {
+	if c.KeyringDir == "" {
+		return validateJWTKey(c.SingleKey())
+	}
+
+	signingFound := false
+	seen := make(map[string]bool, len(c.Keys))
+	for _, key := range c.Keys {
+		if key.ID == "" || seen[key.ID] {
+			return fmt.Errorf("JWT keyring key IDs must be unique and non-empty: %q", key.ID)
+		}
+		seen[key.ID] = true
+		if err := validateJWTKey(key); err != nil {
+			return fmt.Errorf("JWT key %s: %w", key.ID, err)
+		}
+		if key.ID == c.SigningKeyID {
+			if !key.CanSign() {
+				return fmt.Errorf("JWT signing key %q only holds a public key", key.ID)
+			}
+			signingFound = true
+		}
+	}
+	if !signingFound {
+		return fmt.Errorf("JWT signing key %q is not in the keyring", c.SigningKeyID)
+	}
+
+	return nil
+}
+
+func validateJWTKey(key JWTKey) error {
+	switch key.Algorithm {
+	case JWTAlgorithmHS256:
+		if key.Secret == "" {
+			return fmt.Errorf("JWT_SECRET is required")
+		}
+		if len(key.Secret) < 32 {
+			return fmt.Errorf("JWT_SECRET must be at least 32 characters long")
+		}
+	case JWTAlgorithmRS256:
+		public, ok := key.Public().(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("JWT_PRIVATE_KEY_FILE must hold an RSA key for %s", key.Algorithm)
+		}
+		if public.N.BitLen() < 2048 {
+			return fmt.Errorf("JWT RSA key must be at least 2048 bits")
+		}
+	case JWTAlgorithmEdDSA:
+		if _, ok := key.Public().(ed25519.PublicKey); !ok {
+			return fmt.Errorf("JWT_PRIVATE_KEY_FILE must hold an Ed25519 key for %s", key.Algorithm)
+		}
+	default:
+		return fmt.Errorf("invalid JWT algorithm: %s", key.Algorithm)
+	}
+
+	return nil
+}
+
+// parseTrustedProxies parses addresses and CIDR ranges; a bare address stands
+// for itself alone.
+func parseTrustedProxies(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if strings.Contains(value, "/") {
+			prefix, err := netip.ParsePrefix(value)
+			if err != nil {
+				return nil, err
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
 func (c *Config) DatabaseURL() string {
 	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
 		c.DB.User, c.DB.Password, c.DB.Host, c.DB.Port, c.DB.DBName, c.DB.SSLMode)
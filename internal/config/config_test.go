@@ -1,7 +1,12 @@
 package config
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -84,3 +89,168 @@ func TestConfigValidation(t *testing.T) {
 		t.Errorf("Config validation failed: %v", err)
 	}
 }
+
+func TestJWTPrivateKeyLoading(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(edKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "jwt.pem")
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		algorithm   string
+		keyFile     string
+		expectError string
+	}{
+		{name: "EdDSA key loads", algorithm: JWTAlgorithmEdDSA, keyFile: keyFile},
+		{name: "algorithm must match key", algorithm: JWTAlgorithmRS256, keyFile: keyFile, expectError: "must hold an RSA key"},
+		{name: "missing key file", algorithm: JWTAlgorithmEdDSA, keyFile: filepath.Join(t.TempDir(), "missing.pem"), expectError: "failed to load JWT private key"},
+		{name: "unknown algorithm", algorithm: "none", keyFile: keyFile, expectError: "invalid JWT algorithm"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("JWT_ALGORITHM", tt.algorithm)
+			t.Setenv("JWT_PRIVATE_KEY_FILE", tt.keyFile)
+
+			config, err := Load()
+
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Errorf("Expected error containing '%s', got %v", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if !edKey.Equal(config.JWT.PrivateKey) {
+				t.Error("Expected the key from the PEM file to be loaded")
+			}
+		})
+	}
+}
+
+func TestJWTKeyringLoading(t *testing.T) {
+	dir := t.TempDir()
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(edKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "new.key"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "old.key"), []byte("this-is-a-very-long-and-secure-secret-key-for-jwt-tokens\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+	t.Setenv("JWT_KEYRING_DIR", dir)
+
+	writeManifest := func(signingKey string) {
+		t.Helper()
+		err := WriteKeyringManifest(dir, &KeyringManifest{
+			SigningKey: signingKey,
+			Keys: []KeyringEntry{
+				{ID: "new", Algorithm: JWTAlgorithmEdDSA, File: "new.key"},
+				{ID: "old", Algorithm: JWTAlgorithmHS256, File: "old.key"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Failed to write manifest: %v", err)
+		}
+	}
+
+	t.Run("loads every key", func(t *testing.T) {
+		writeManifest("new")
+
+		config, err := Load()
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if config.JWT.SigningKeyID != "new" || len(config.JWT.Keys) != 2 {
+			t.Fatalf("Expected two keys signed by 'new', got %d signed by %q", len(config.JWT.Keys), config.JWT.SigningKeyID)
+		}
+		if config.JWT.Keys[1].Secret != "this-is-a-very-long-and-secure-secret-key-for-jwt-tokens" {
+			t.Error("Expected the HS256 secret to be read without its trailing newline")
+		}
+	})
+
+	t.Run("verification-only keys hold a public key", func(t *testing.T) {
+		public, err := EncodePublicKey(edKey)
+		if err != nil {
+			t.Fatalf("Failed to encode public key: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "new.pub"), public, 0o600); err != nil {
+			t.Fatalf("Failed to write key: %v", err)
+		}
+		err = WriteKeyringManifest(dir, &KeyringManifest{
+			SigningKey: "old",
+			Keys: []KeyringEntry{
+				{ID: "new", Algorithm: JWTAlgorithmEdDSA, File: "new.pub"},
+				{ID: "old", Algorithm: JWTAlgorithmHS256, File: "old.key"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Failed to write manifest: %v", err)
+		}
+
+		config, err := Load()
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if config.JWT.Keys[0].PrivateKey != nil || config.JWT.Keys[0].PublicKey == nil {
+			t.Error("Expected the public key file to load as a verification-only key")
+		}
+
+		if err := WriteKeyringManifest(dir, &KeyringManifest{
+			SigningKey: "new",
+			Keys:       []KeyringEntry{{ID: "new", Algorithm: JWTAlgorithmEdDSA, File: "new.pub"}},
+		}); err != nil {
+			t.Fatalf("Failed to write manifest: %v", err)
+		}
+		if _, err := Load(); err == nil || !strings.Contains(err.Error(), "only holds a public key") {
+			t.Errorf("Expected a public key to be refused for signing, got %v", err)
+		}
+	})
+
+	t.Run("signing key must be listed", func(t *testing.T) {
+		writeManifest("missing")
+
+		if _, err := Load(); err == nil || !strings.Contains(err.Error(), "is not in the keyring") {
+			t.Errorf("Expected unknown signing key error, got %v", err)
+		}
+	})
+}
+
+func TestTrustedProxiesLoading(t *testing.T) {
+	t.Setenv("JWT_SECRET", strings.Repeat("s", 32))
+	t.Setenv("TRUSTED_PROXIES", "10.0.0.0/8, 192.0.2.7,2001:db8::1/64")
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	var proxies []string
+	for _, prefix := range config.Server.TrustedProxies {
+		proxies = append(proxies, prefix.String())
+	}
+	if strings.Join(proxies, " ") != "10.0.0.0/8 192.0.2.7/32 2001:db8::/64" {
+		t.Errorf("Unexpected trusted proxies: %v", proxies)
+	}
+
+	t.Setenv("TRUSTED_PROXIES", "proxy.internal")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "invalid TRUSTED_PROXIES") {
+		t.Errorf("Expected an invalid TRUSTED_PROXIES error, got %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// KeyringManifestFile is the file in a keyring directory that lists its keys.
+const KeyringManifestFile = "keyring.json"
+
+// KeyringManifest lists the keys of a JWT keyring. Every key verifies tokens;
+// only SigningKey signs new ones, so a retired signing key can stay listed
+// until the tokens it signed have expired.
+type KeyringManifest struct {
+	SigningKey string         `json:"signing_key"`
+	Keys       []KeyringEntry `json:"keys"`
+}
+
+// KeyringEntry points at one key file, relative to the keyring directory.
+// HS256 files hold the secret itself; RS256 and EdDSA files hold a PEM private
+// key, or only the public key once the key no longer signs.
+type KeyringEntry struct {
+	ID        string `json:"id"`
+	Algorithm string `json:"algorithm"`
+	File      string `json:"file"`
+}
+
+// Find returns the entry with the given key ID, or nil.
+func (m *KeyringManifest) Find(id string) *KeyringEntry {
+	for i := range m.Keys {
+		if m.Keys[i].ID == id {
+			return &m.Keys[i]
+		}
+	}
+	return nil
+}
+
+// ReadKeyringManifest reads dir's manifest. It returns an empty manifest when
+// the directory has none yet.
+func ReadKeyringManifest(dir string) (*KeyringManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, KeyringManifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return &KeyringManifest{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keyring manifest: %w", err)
+	}
+
+	var manifest KeyringManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse keyring manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+// WriteKeyringManifest replaces dir's manifest atomically, so a server
+// starting concurrently never reads a half-written file.
+func WriteKeyringManifest(dir string, manifest *KeyringManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode keyring manifest: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, KeyringManifestFile+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write keyring manifest: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write keyring manifest: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write keyring manifest: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, KeyringManifestFile)); err != nil {
+		return fmt.Errorf("failed to replace keyring manifest: %w", err)
+	}
+	return nil
+}
+
+// LoadKeyring reads every key listed in dir's manifest and returns them with
+// the ID of the signing key.
+func LoadKeyring(dir string) ([]JWTKey, string, error) {
+	manifest, err := ReadKeyringManifest(dir)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(manifest.Keys) == 0 {
+		return nil, "", fmt.Errorf("keyring in %s has no keys", dir)
+	}
+
+	keys := make([]JWTKey, 0, len(manifest.Keys))
+	for _, entry := range manifest.Keys {
+		key := JWTKey{ID: entry.ID, Algorithm: entry.Algorithm}
+		path := filepath.Join(dir, entry.File)
+
+		if entry.Algorithm == JWTAlgorithmHS256 {
+			secret, err := os.ReadFile(path)
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to read key %s: %w", entry.ID, err)
+			}
+			key.Secret = strings.TrimSpace(string(secret))
+		} else {
+			key.PrivateKey, key.PublicKey, err = LoadKeyringKey(path)
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to load key %s: %w", entry.ID, err)
+			}
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, manifest.SigningKey, nil
+}
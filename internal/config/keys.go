@@ -0,0 +1,89 @@
+package config
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+)
+
+// publicKeyPEMType is the PEM block type of a PKIX public key.
+const publicKeyPEMType = "PUBLIC KEY"
+
+// readPEMBlock returns the first PEM block of path.
+func readPEMBlock(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in %s", path)
+	}
+	return block, nil
+}
+
+// loadPrivateKey reads the first PEM block of path as a PKCS#8 key, or as a
+// PKCS#1 RSA key for files written by older tooling.
+func loadPrivateKey(path string) (crypto.Signer, error) {
+	block, err := readPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+	return parsePrivateKey(block)
+}
+
+func parsePrivateKey(block *pem.Block) (crypto.Signer, error) {
+	if block.Type == "RSA PRIVATE KEY" {
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse PKCS#1 key: %w", err)
+		}
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse PKCS#8 key: %w", err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return signer, nil
+}
+
+// LoadKeyringKey reads a keyring key file. A file holding only a public key
+// yields a key that verifies tokens but cannot sign them.
+func LoadKeyringKey(path string) (crypto.Signer, crypto.PublicKey, error) {
+	block, err := readPEMBlock(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if block.Type == publicKeyPEMType {
+		public, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse public key: %w", err)
+		}
+		return nil, public, nil
+	}
+
+	private, err := parsePrivateKey(block)
+	if err != nil {
+		return nil, nil, err
+	}
+	return private, private.Public(), nil
+}
+
+// EncodePublicKey returns key's public half as a PKIX PEM block, the form a
+// keyring file takes once its key only verifies.
+func EncodePublicKey(key crypto.Signer) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode public key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: publicKeyPEMType, Bytes: der}), nil
+}
@@ -0,0 +1,267 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/aleksandr/strive-api/internal/validation"
+	"github.com/google/uuid"
+)
+
+type AdminHandlers struct {
+	adminService services.AdminService
+	logger       *logger.Logger
+}
+
+func NewAdminHandlers(adminService services.AdminService, logger *logger.Logger) *AdminHandlers {
+	return &AdminHandlers{
+		adminService: adminService,
+		logger:       logger,
+	}
+}
+
+type AdminUserListResponse struct {
+	Users []*models.User `json:"users"`
+	models.Pagination
+}
+
+// ListUsers godoc
+// @Summary List users
+// @Description Search users by email or display name, newest first. Requires the users:read permission.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param q query string false "Text search on email and display name"
+// @Param role query string false "Role (user, coach, admin)"
+// @Param disabled query bool false "Only disabled (true) or enabled (false) accounts"
+// @Param limit query int false "Page size (max 100)"
+// @Param offset query int false "Page offset"
+// @Success 200 {object} AdminUserListResponse "Users"
+// @Failure 400 {object} ErrorResponse "Invalid filter"
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Router /api/v1/admin/users [get]
+func (h *AdminHandlers) ListUsers(w http.ResponseWriter, r *http.Request) {
+	actor, ok := requestAuditActor(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	filter := models.UserFilter{
+		Query:      strings.TrimSpace(query.Get("q")),
+		Role:       models.Role(query.Get("role")),
+		Pagination: queryPagination(r),
+	}
+
+	var validationErrors validation.ValidationErrors
+	if len(filter.Query) > maxSearchQueryLength {
+		validationErrors.Add("q", fmt.Errorf("q too long (max %d characters)", maxSearchQueryLength))
+	}
+	if filter.Role != "" {
+		validationErrors.Add("role", validation.ValidateOneOf(string(filter.Role), "role", models.Roles...))
+	}
+	if value := query.Get("disabled"); value != "" {
+		disabled, err := strconv.ParseBool(value)
+		if err != nil {
+			validationErrors.Add("disabled", fmt.Errorf("disabled must be true or false"))
+		}
+		filter.Disabled = &disabled
+	}
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	users, err := h.adminService.ListUsers(r.Context(), actor, filter)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to list users")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, AdminUserListResponse{
+		Users:      users,
+		Pagination: filter.Pagination,
+	})
+}
+
+// GetUser godoc
+// @Summary Get user
+// @Description Get any user's account. Requires the users:read permission.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} models.User "User"
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "User not found"
+// @Router /api/v1/admin/users/{id} [get]
+func (h *AdminHandlers) GetUser(w http.ResponseWriter, r *http.Request) {
+	actor, userID, ok := adminTarget(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.adminService.GetUser(r.Context(), actor, userID)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to get user")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, user)
+}
+
+// ListSessions godoc
+// @Summary List a user's sessions
+// @Description List the devices signed in to a user's account. Requires the users:read permission.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} SessionListResponse
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "User not found"
+// @Router /api/v1/admin/users/{id}/sessions [get]
+func (h *AdminHandlers) ListSessions(w http.ResponseWriter, r *http.Request) {
+	actor, userID, ok := adminTarget(w, r)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.adminService.ListSessions(r.Context(), actor, userID)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to list user sessions")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, SessionListResponse{Sessions: sessions})
+}
+
+// ForceLogout godoc
+// @Summary Sign a user out everywhere
+// @Description Revoke every session and access token of a user. Requires the users:manage permission.
+// @Tags admin
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 204 "User signed out"
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "User not found"
+// @Router /api/v1/admin/users/{id}/logout [post]
+func (h *AdminHandlers) ForceLogout(w http.ResponseWriter, r *http.Request) {
+	h.runAction(w, r, "User signed out by admin", h.adminService.ForceLogout)
+}
+
+// DisableUser godoc
+// @Summary Disable a user
+// @Description Block sign-in and sign the user out everywhere. Requires the users:manage permission.
+// @Tags admin
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 204 "User disabled"
+// @Failure 400 {object} ErrorResponse "Admins cannot disable themselves"
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "User not found"
+// @Failure 409 {object} ErrorResponse "Already disabled"
+// @Router /api/v1/admin/users/{id}/disable [post]
+func (h *AdminHandlers) DisableUser(w http.ResponseWriter, r *http.Request) {
+	h.runAction(w, r, "User disabled by admin", h.adminService.DisableUser)
+}
+
+// EnableUser godoc
+// @Summary Enable a user
+// @Description Allow a disabled user to sign in again. Requires the users:manage permission.
+// @Tags admin
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 204 "User enabled"
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "User not found"
+// @Failure 409 {object} ErrorResponse "Not disabled"
+// @Router /api/v1/admin/users/{id}/enable [post]
+func (h *AdminHandlers) EnableUser(w http.ResponseWriter, r *http.Request) {
+	h.runAction(w, r, "User enabled by admin", h.adminService.EnableUser)
+}
+
+// SendPasswordReset godoc
+// @Summary Send a password reset
+// @Description Email the user a password reset link. Requires the users:manage permission.
+// @Tags admin
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 204 "Reset email sent"
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "User not found"
+// @Router /api/v1/admin/users/{id}/password-reset [post]
+func (h *AdminHandlers) SendPasswordReset(w http.ResponseWriter, r *http.Request) {
+	h.runAction(w, r, "Password reset sent by admin", h.adminService.SendPasswordReset)
+}
+
+// DeleteUser godoc
+// @Summary Delete a user
+// @Description Delete a user's account and all of its data. Requires the users:manage permission.
+// @Tags admin
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 204 "User deleted"
+// @Failure 400 {object} ErrorResponse "Admins cannot delete themselves"
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "User not found"
+// @Router /api/v1/admin/users/{id} [delete]
+func (h *AdminHandlers) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	h.runAction(w, r, "User deleted by admin", h.adminService.DeleteUser)
+}
+
+// runAction applies action to the user in the path and answers 204.
+func (h *AdminHandlers) runAction(
+	w http.ResponseWriter,
+	r *http.Request,
+	message string,
+	action func(ctx context.Context, actor models.AuditActor, userID uuid.UUID) error,
+) {
+	actor, userID, ok := adminTarget(w, r)
+	if !ok {
+		return
+	}
+
+	if err := action(r.Context(), actor, userID); err != nil {
+		writeServiceError(w, h.logger, err, "Failed to perform admin action")
+		return
+	}
+
+	h.logger.Info(message, "admin_id", actor.UserID, "user_id", userID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func adminTarget(w http.ResponseWriter, r *http.Request) (models.AuditActor, uuid.UUID, bool) {
+	actor, ok := requestAuditActor(w, r)
+	if !ok {
+		return models.AuditActor{}, uuid.Nil, false
+	}
+	userID, ok := pathUUID(w, r, "id")
+	if !ok {
+		return models.AuditActor{}, uuid.Nil, false
+	}
+	return actor, userID, true
+}
+
+// requestAuditActor identifies the authenticated caller for the audit trail.
+func requestAuditActor(w http.ResponseWriter, r *http.Request) (models.AuditActor, bool) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return models.AuditActor{}, false
+	}
+	return models.AuditActor{UserID: userID, AuditRequest: requestAuditRequest(r)}, true
+}
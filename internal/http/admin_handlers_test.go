@@ -0,0 +1,121 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminHandlers_ListUsers(t *testing.T) {
+	logger := logger.New("INFO", "json")
+	adminID := uuid.New()
+
+	t.Run("Filters", func(t *testing.T) {
+		mockService := new(MockAdminService)
+		users := []*models.User{{ID: uuid.New(), Email: "coach@example.com", Role: models.RoleCoach}}
+		mockService.On("ListUsers", mock.Anything, mock.MatchedBy(func(actor models.AuditActor) bool {
+			return actor.UserID == adminID && actor.IPAddress == "203.0.113.7" && actor.UserAgent == "curl/8.0"
+		}), mock.MatchedBy(func(filter models.UserFilter) bool {
+			return filter.Query == "coach" && filter.Role == models.RoleCoach && filter.Disabled != nil && !*filter.Disabled &&
+				filter.Limit == 10 && filter.Offset == 20
+		})).Return(users, nil)
+		handlers := NewAdminHandlers(mockService, logger)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users?q=+coach+&role=coach&disabled=false&limit=10&offset=20", http.NoBody)
+		req.RemoteAddr = "203.0.113.7:41234"
+		req.Header.Set("User-Agent", "curl/8.0")
+		rr := httptest.NewRecorder()
+		handlers.ListUsers(rr, withUserID(req, adminID))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response AdminUserListResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		require.Len(t, response.Users, 1)
+		assert.Equal(t, models.RoleCoach, response.Users[0].Role)
+		assert.Equal(t, 10, response.Limit)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("InvalidFilter", func(t *testing.T) {
+		mockService := new(MockAdminService)
+		handlers := NewAdminHandlers(mockService, logger)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users?role=owner&disabled=maybe", http.NoBody)
+		rr := httptest.NewRecorder()
+		handlers.ListUsers(rr, withUserID(req, adminID))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockService.AssertNotCalled(t, "ListUsers", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAdminHandlers_Actions(t *testing.T) {
+	logger := logger.New("INFO", "json")
+	adminID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		method         string
+		serviceErr     error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"DisableUser", "DisableUser", nil, http.StatusNoContent, ""},
+		{"DisableUser already disabled", "DisableUser", services.ErrAccountAlreadyDisabled, http.StatusConflict, "ACCOUNT_ALREADY_DISABLED"},
+		{"DisableUser self", "DisableUser", services.ErrCannotModifySelf, http.StatusBadRequest, "CANNOT_MODIFY_SELF"},
+		{"EnableUser not disabled", "EnableUser", services.ErrAccountNotDisabled, http.StatusConflict, "ACCOUNT_NOT_DISABLED"},
+		{"ForceLogout", "ForceLogout", nil, http.StatusNoContent, ""},
+		{"SendPasswordReset", "SendPasswordReset", nil, http.StatusNoContent, ""},
+		{"DeleteUser unknown user", "DeleteUser", services.ErrUserNotFound, http.StatusNotFound, "USER_NOT_FOUND"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAdminService)
+			mockService.On(tt.method, mock.Anything, mock.AnythingOfType("models.AuditActor"), userID).Return(tt.serviceErr)
+			handlers := NewAdminHandlers(mockService, logger)
+			actions := map[string]http.HandlerFunc{
+				"DisableUser":       handlers.DisableUser,
+				"EnableUser":        handlers.EnableUser,
+				"ForceLogout":       handlers.ForceLogout,
+				"SendPasswordReset": handlers.SendPasswordReset,
+				"DeleteUser":        handlers.DeleteUser,
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/"+userID.String(), http.NoBody)
+			req.SetPathValue("id", userID.String())
+			rr := httptest.NewRecorder()
+			actions[tt.method](rr, withUserID(req, adminID))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedCode != "" {
+				var response ErrorResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedCode, response.Error.Code)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+
+	t.Run("InvalidID", func(t *testing.T) {
+		mockService := new(MockAdminService)
+		handlers := NewAdminHandlers(mockService, logger)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users/not-a-uuid", http.NoBody)
+		req.SetPathValue("id", "not-a-uuid")
+		rr := httptest.NewRecorder()
+		handlers.GetUser(rr, withUserID(req, adminID))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,127 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/aleksandr/strive-api/internal/validation"
+	"github.com/google/uuid"
+)
+
+type AuditHandlers struct {
+	auditService services.AuditService
+	logger       *logger.Logger
+}
+
+func NewAuditHandlers(auditService services.AuditService, logger *logger.Logger) *AuditHandlers {
+	return &AuditHandlers{
+		auditService: auditService,
+		logger:       logger,
+	}
+}
+
+type AuditEventListResponse struct {
+	Events []*models.AuditEvent `json:"events"`
+	models.Pagination
+}
+
+// AccountActivity godoc
+// @Summary Recent account activity
+// @Description List security events on the account, newest first: sign-ins, failed sign-ins, sign-outs, password
+// @Description changes and resets, email changes, two-factor authentication being turned on or off, recovery codes
+// @Description used, reused refresh tokens and actions taken by support staff.
+// @Tags authentication
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Page size (max 100)"
+// @Param offset query int false "Page offset"
+// @Success 200 {object} AuditEventListResponse
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Router /api/v1/auth/activity [get]
+func (h *AuditHandlers) AccountActivity(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	page := queryPagination(r)
+	events, err := h.auditService.ListForUser(r.Context(), userID, page)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to list account activity")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, AuditEventListResponse{Events: events, Pagination: page})
+}
+
+// SearchEvents godoc
+// @Summary Search the audit log
+// @Description Search security and admin events, newest first. Requires the audit:read permission; searches are
+// @Description themselves recorded.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param user_id query string false "Events concerning this user"
+// @Param actor_id query string false "Events performed by this user"
+// @Param type query []string false "Event types, e.g. auth.login_failed" collectionFormat(multi)
+// @Param from query string false "Earliest time (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "Latest time, exclusive (RFC 3339 or YYYY-MM-DD)"
+// @Param limit query int false "Page size (max 100)"
+// @Param offset query int false "Page offset"
+// @Success 200 {object} AuditEventListResponse
+// @Failure 400 {object} ErrorResponse "Invalid filter"
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Router /api/v1/admin/audit-events [get]
+func (h *AuditHandlers) SearchEvents(w http.ResponseWriter, r *http.Request) {
+	actor, ok := requestAuditActor(w, r)
+	if !ok {
+		return
+	}
+
+	var validationErrors validation.ValidationErrors
+	filter := models.AuditFilter{
+		UserID:     queryUUID(r, "user_id", &validationErrors),
+		ActorID:    queryUUID(r, "actor_id", &validationErrors),
+		Types:      r.URL.Query()["type"],
+		From:       queryTime(r, "from", &validationErrors),
+		To:         queryTime(r, "to", &validationErrors),
+		Pagination: queryPagination(r),
+	}
+	for _, eventType := range filter.Types {
+		validationErrors.Add("type", validation.ValidateOneOf(eventType, "type", models.AuditEventTypes...))
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		validationErrors = append(validationErrors, validation.ValidationError{Field: "to", Message: "to must be after from"})
+	}
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	events, err := h.auditService.Search(r.Context(), actor, filter)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to search audit events")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, AuditEventListResponse{Events: events, Pagination: filter.Pagination})
+}
+
+func queryUUID(r *http.Request, name string, validationErrors *validation.ValidationErrors) *uuid.UUID {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil
+	}
+	parsed, err := uuid.Parse(value)
+	if err != nil {
+		*validationErrors = append(*validationErrors, validation.ValidationError{
+			Field:   name,
+			Message: name + " must be a valid UUID",
+		})
+		return nil
+	}
+	return &parsed
+}
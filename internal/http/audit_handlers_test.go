@@ -0,0 +1,106 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditHandlers_AccountActivity(t *testing.T) {
+	logger := logger.New("INFO", "json")
+	userID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockAuditService)
+		events := []*models.AuditEvent{{ID: uuid.New(), Type: models.AuditEventLogin, IPAddress: "203.0.113.7"}}
+		mockService.On("ListForUser", mock.Anything, userID, models.Pagination{Limit: 5, Offset: 10}).Return(events, nil)
+		handlers := NewAuditHandlers(mockService, logger)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/activity?limit=5&offset=10", http.NoBody)
+		rr := httptest.NewRecorder()
+		handlers.AccountActivity(rr, withUserID(req, userID))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response AuditEventListResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		require.Len(t, response.Events, 1)
+		assert.Equal(t, models.AuditEventLogin, response.Events[0].Type)
+		assert.Equal(t, 5, response.Limit)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("ServiceError", func(t *testing.T) {
+		mockService := new(MockAuditService)
+		mockService.On("ListForUser", mock.Anything, userID, mock.Anything).Return(nil, errors.New("database is down"))
+		handlers := NewAuditHandlers(mockService, logger)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/activity", http.NoBody)
+		rr := httptest.NewRecorder()
+		handlers.AccountActivity(rr, withUserID(req, userID))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestAuditHandlers_SearchEvents(t *testing.T) {
+	logger := logger.New("INFO", "json")
+	adminID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("Filters", func(t *testing.T) {
+		mockService := new(MockAuditService)
+		from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+		mockService.On("Search", mock.Anything, mock.MatchedBy(func(actor models.AuditActor) bool {
+			return actor.UserID == adminID && actor.IPAddress == "203.0.113.7"
+		}), mock.MatchedBy(func(filter models.AuditFilter) bool {
+			return *filter.UserID == userID && filter.ActorID == nil &&
+				assert.ObjectsAreEqual([]string{models.AuditEventLoginFailed, models.AuditEventRefreshTokenReused}, filter.Types) &&
+				filter.From.Equal(from) && filter.To == nil && filter.Limit == 50
+		})).Return([]*models.AuditEvent{}, nil)
+		handlers := NewAuditHandlers(mockService, logger)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/audit-events?user_id="+userID.String()+
+			"&type=auth.login_failed&type=auth.refresh_token_reused&from=2026-10-01&limit=50", http.NoBody)
+		req.RemoteAddr = "203.0.113.7:41234"
+		rr := httptest.NewRecorder()
+		handlers.SearchEvents(rr, withUserID(req, adminID))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response AuditEventListResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.NotNil(t, response.Events)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("InvalidFilter", func(t *testing.T) {
+		mockService := new(MockAuditService)
+		handlers := NewAuditHandlers(mockService, logger)
+
+		req := httptest.NewRequest(http.MethodGet,
+			"/api/v1/admin/audit-events?actor_id=nope&type=auth.unknown&from=2026-10-02&to=2026-10-01", http.NoBody)
+		rr := httptest.NewRecorder()
+		handlers.SearchEvents(rr, withUserID(req, adminID))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		var response struct {
+			Error struct {
+				Details map[string]string `json:"details"`
+			} `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Contains(t, response.Error.Details, "actor_id")
+		assert.Contains(t, response.Error.Details, "type")
+		assert.Contains(t, response.Error.Details, "to")
+		mockService.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
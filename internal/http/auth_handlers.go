@@ -2,7 +2,11 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/aleksandr/strive-api/internal/config"
 	"github.com/aleksandr/strive-api/internal/logger"
@@ -12,18 +16,28 @@ import (
 )
 
 type AuthHandlers struct {
-	authService    services.AuthService
-	logger         *logger.Logger
-	securityLogger *SecurityLogger
-	config         *config.Config
+	authService         services.AuthService
+	verificationService services.EmailVerificationService
+	revocations         services.TokenRevocationService
+	logger              *logger.Logger
+	securityLogger      *SecurityLogger
+	config              *config.Config
 }
 
-func NewAuthHandlers(authService services.AuthService, logger *logger.Logger, cfg *config.Config) *AuthHandlers {
+func NewAuthHandlers(
+	authService services.AuthService,
+	verificationService services.EmailVerificationService,
+	revocations services.TokenRevocationService,
+	logger *logger.Logger,
+	cfg *config.Config,
+) *AuthHandlers {
 	return &AuthHandlers{
-		authService:    authService,
-		logger:         logger,
-		securityLogger: NewSecurityLogger(logger),
-		config:         cfg,
+		authService:         authService,
+		verificationService: verificationService,
+		revocations:         revocations,
+		logger:              logger,
+		securityLogger:      NewSecurityLogger(logger),
+		config:              cfg,
 	}
 }
 
@@ -55,6 +69,16 @@ type RegisterRequest struct {
 type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email" example:"user@example.com"`
 	Password string `json:"password" validate:"required" example:"password123"`
+	// DeviceName labels the session; it is derived from the user agent when omitted.
+	DeviceName string `json:"device_name,omitempty" validate:"omitempty,max=100" example:"Alex's iPhone"`
+}
+
+type MFALoginRequest struct {
+	// MFAToken is the challenge token returned by login.
+	MFAToken string `json:"mfa_token" validate:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	// Code is a 6-digit TOTP code or a recovery code.
+	Code       string `json:"code" validate:"required" example:"123456"`
+	DeviceName string `json:"device_name,omitempty" validate:"omitempty,max=100" example:"Alex's iPhone"`
 }
 
 type RefreshRequest struct {
@@ -68,6 +92,15 @@ type AuthResponse struct {
 	Message     string `json:"message,omitempty" example:"Login successful"`
 }
 
+// MFAChallengeResponse is returned by login instead of tokens when the account
+// has two-factor authentication on.
+type MFAChallengeResponse struct {
+	MFARequired bool   `json:"mfa_required" example:"true"`
+	MFAToken    string `json:"mfa_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	ExpiresIn   int    `json:"expires_in" example:"300"`
+	Message     string `json:"message" example:"Enter the code from your authenticator app"`
+}
+
 type ErrorResponse struct {
 	Error struct {
 		Code    string `json:"code" example:"VALIDATION_ERROR"`
@@ -77,7 +110,7 @@ type ErrorResponse struct {
 
 // Register godoc
 // @Summary Register a new user
-// @Description Create a new user account with email and password
+// @Description Create a new user account with email and password and email a verification link
 // @Tags authentication
 // @Accept json
 // @Produce json
@@ -136,6 +169,11 @@ func (h *AuthHandlers) Register(w http.ResponseWriter, r *http.Request) {
 
 	h.logger.Info("User registered successfully", "user_id", user.ID, "email", user.Email)
 
+	// The account exists either way; the user can ask for another link if this one fails.
+	if err := h.verificationService.SendVerification(r.Context(), user); err != nil {
+		h.logger.Error("Failed to send verification email", "error", err, "user_id", user.ID)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	_ = json.NewEncoder(w).Encode(map[string]interface{}{
@@ -146,14 +184,18 @@ func (h *AuthHandlers) Register(w http.ResponseWriter, r *http.Request) {
 
 // Login godoc
 // @Summary Login user
-// @Description Authenticate user and return JWT tokens
+// @Description Authenticate user and return JWT tokens. With two-factor authentication on, an MFA challenge token
+// @Description is returned instead; complete the login at /api/v1/auth/login/mfa.
 // @Tags authentication
 // @Accept json
 // @Produce json
 // @Param request body LoginRequest true "User login credentials"
 // @Success 200 {object} AuthResponse "Login successful"
+// @Success 200 {object} MFAChallengeResponse "Two-factor code required"
 // @Failure 400 {object} ErrorResponse "Invalid request data"
 // @Failure 401 {object} ErrorResponse "Invalid credentials"
+// @Failure 403 {object} ErrorResponse "Email not verified (when the block policy is enabled), or account disabled"
+// @Failure 429 {object} ErrorResponse "Too many failed attempts, retry after the Retry-After header"
 // @Router /api/v1/auth/login [post]
 func (h *AuthHandlers) Login(w http.ResponseWriter, r *http.Request) {
 	var req LoginRequest
@@ -177,6 +219,9 @@ func (h *AuthHandlers) Login(w http.ResponseWriter, r *http.Request) {
 			Message: "password is required",
 		})
 	}
+	if req.DeviceName != "" {
+		validationErrors.Add("device_name", validation.ValidateString(req.DeviceName, "device_name", 1, 100))
+	}
 
 	if len(validationErrors) > 0 {
 		h.logger.Warn("Validation failed for login request", "errors", validationErrors)
@@ -191,7 +236,31 @@ func (h *AuthHandlers) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	accessToken, refreshToken, err := h.authService.Login(r.Context(), req.Email, req.Password)
+	accessToken, refreshToken, err := h.authService.Login(r.Context(), req.Email, req.Password, requestClientInfo(r, req.DeviceName))
+	if writeThrottled(w, r, h.securityLogger, err) {
+		return
+	}
+	if errors.Is(err, services.ErrEmailNotVerified) {
+		h.logger.Warn("Login refused for unverified email", "email", req.Email)
+		writeError(w, http.StatusForbidden, "EMAIL_NOT_VERIFIED", "Verify your email address before signing in")
+		return
+	}
+	if errors.Is(err, services.ErrAccountDisabled) {
+		h.securityLogger.LogFailedAuth(r, "account_disabled")
+		writeError(w, http.StatusForbidden, "ACCOUNT_DISABLED", "This account has been disabled")
+		return
+	}
+	var challenge *services.MFAChallengeError
+	if errors.As(err, &challenge) {
+		h.logger.Info("Two-factor code required", "email", req.Email)
+		writeJSON(w, http.StatusOK, MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    challenge.Token,
+			ExpiresIn:   int(challenge.ExpiresIn.Seconds()),
+			Message:     "Enter the code from your authenticator app",
+		})
+		return
+	}
 	if err != nil {
 		h.logger.Error("Failed to login user", "error", err, "email", req.Email)
 		h.securityLogger.LogFailedAuth(r, "invalid_credentials")
@@ -201,6 +270,89 @@ func (h *AuthHandlers) Login(w http.ResponseWriter, r *http.Request) {
 
 	h.logger.Info("User logged in successfully", "email", req.Email)
 
+	h.writeLoginResponse(w, accessToken, refreshToken)
+}
+
+// LoginMFA godoc
+// @Summary Complete login with a two-factor code
+// @Description Exchange the MFA challenge token from login and a TOTP code or unused recovery code for JWT tokens
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Param request body MFALoginRequest true "Challenge token and code"
+// @Success 200 {object} AuthResponse "Login successful"
+// @Failure 400 {object} ErrorResponse "Invalid request data"
+// @Failure 401 {object} ErrorResponse "Invalid or expired challenge token, or invalid code"
+// @Failure 429 {object} ErrorResponse "Too many failed attempts, retry after the Retry-After header"
+// @Router /api/v1/auth/login/mfa [post]
+func (h *AuthHandlers) LoginMFA(w http.ResponseWriter, r *http.Request) {
+	var req MFALoginRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var validationErrors validation.ValidationErrors
+	if req.MFAToken == "" {
+		validationErrors = append(validationErrors, validation.ValidationError{
+			Field:   "mfa_token",
+			Message: "mfa_token is required",
+		})
+	}
+	if req.Code == "" {
+		validationErrors = append(validationErrors, validation.ValidationError{
+			Field:   "code",
+			Message: "code is required",
+		})
+	}
+	if req.DeviceName != "" {
+		validationErrors.Add("device_name", validation.ValidateString(req.DeviceName, "device_name", 1, 100))
+	}
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	client := requestClientInfo(r, req.DeviceName)
+	accessToken, refreshToken, err := h.authService.CompleteMFALogin(r.Context(), req.MFAToken, req.Code, client)
+	if writeThrottled(w, r, h.securityLogger, err) {
+		return
+	}
+	if errors.Is(err, services.ErrInvalidMFAChallenge) {
+		h.securityLogger.LogFailedAuth(r, "invalid_mfa_challenge")
+		writeError(w, http.StatusUnauthorized, "INVALID_MFA_TOKEN", "Two-factor challenge is invalid or expired, sign in again")
+		return
+	}
+	if errors.Is(err, services.ErrInvalidMFACode) {
+		h.securityLogger.LogFailedAuth(r, "invalid_mfa_code")
+		writeError(w, http.StatusUnauthorized, "INVALID_MFA_CODE", "Invalid two-factor code")
+		return
+	}
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to complete two-factor login")
+		return
+	}
+
+	h.logger.Info("User logged in successfully with two-factor authentication")
+
+	h.writeLoginResponse(w, accessToken, refreshToken)
+}
+
+// writeThrottled answers 429 with a Retry-After header when err says sign-in
+// attempts have to wait.
+func writeThrottled(w http.ResponseWriter, r *http.Request, securityLogger *SecurityLogger, err error) bool {
+	var throttled *services.LoginThrottledError
+	if !errors.As(err, &throttled) {
+		return false
+	}
+
+	securityLogger.LogFailedAuth(r, "login_throttled")
+	retryAfter := int(math.Ceil(throttled.RetryAfter.Seconds()))
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	writeError(w, http.StatusTooManyRequests, "TOO_MANY_ATTEMPTS", "Too many failed sign-in attempts, try again later")
+	return true
+}
+
+func (h *AuthHandlers) writeLoginResponse(w http.ResponseWriter, accessToken, refreshToken string) {
 	h.setSecureCookie(w, "refresh-token", refreshToken, 604800)
 
 	response := AuthResponse{
@@ -241,7 +393,12 @@ func (h *AuthHandlers) Refresh(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	accessToken, refreshToken, err := h.authService.RefreshToken(r.Context(), refreshTokenCookie.Value)
+	accessToken, refreshToken, err := h.authService.RefreshToken(r.Context(), refreshTokenCookie.Value, requestClientInfo(r, ""))
+	if errors.Is(err, services.ErrRefreshTokenReused) {
+		// Answered like any invalid token so the replaying client learns nothing.
+		h.securityLogger.LogRefreshTokenReuse(r, err.Error())
+		h.setSecureCookie(w, "refresh-token", "", -1)
+	}
 	if err != nil {
 		h.logger.Error("Failed to refresh token", "error", err)
 		h.securityLogger.LogFailedAuth(r, "invalid_refresh_token")
@@ -265,9 +422,22 @@ func (h *AuthHandlers) Refresh(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(response)
 }
 
+// JWKS godoc
+// @Summary JSON Web Key Set
+// @Description Public keys for verifying access tokens, selected by the token's kid header. Empty when tokens are signed with a shared HS256 secret.
+// @Tags authentication
+// @Produce json
+// @Success 200 {object} models.JWKS "Verification keys"
+// @Router /.well-known/jwks.json [get]
+func (h *AuthHandlers) JWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	writeJSON(w, http.StatusOK, h.authService.JWKS())
+}
+
 // Logout godoc
 // @Summary Logout user
-// @Description Logout user and clear authentication cookies
+// @Description Logout user and clear authentication cookies. An access token sent in the Authorization header is
+// @Description revoked as well.
 // @Tags authentication
 // @Accept json
 // @Produce json
@@ -281,6 +451,8 @@ func (h *AuthHandlers) Logout(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	h.revokeBearerToken(r)
+
 	h.setSecureCookie(w, "refresh-token", "", -1)
 
 	h.logger.Info("User logged out successfully")
@@ -294,36 +466,70 @@ func (h *AuthHandlers) Logout(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(response)
 }
 
-// Me returns information about the current authenticated user
-// @Summary Get current user information
-// @Description Returns information about the currently authenticated user
+// revokeBearerToken revokes the request's access token, if it carries a valid one.
+func (h *AuthHandlers) revokeBearerToken(r *http.Request) {
+	tokenString, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || tokenString == "" {
+		return
+	}
+	claims, err := h.authService.ValidateToken(tokenString)
+	if err != nil {
+		return
+	}
+	if err := h.revocations.Revoke(r.Context(), claims); err != nil {
+		h.logger.Error("Failed to revoke access token", "error", err)
+	}
+}
+
+// ChangePassword godoc
+// @Summary Change password
+// @Description Change the current user's password. Every other session is signed out; the refresh-token cookie
+// @Description sent with this request stays valid.
 // @Tags authentication
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} map[string]interface{} "User information"
+// @Param request body models.ChangePasswordRequest true "Current and new password"
+// @Success 200 {object} map[string]interface{} "Password changed"
+// @Failure 400 {object} ErrorResponse "Invalid request data or incorrect current password"
 // @Failure 401 {object} AuthError "Unauthorized"
-// @Failure 500 {object} AuthError "Internal server error"
-// @Router /api/v1/auth/me [get]
-func (h *AuthHandlers) Me(w http.ResponseWriter, r *http.Request) {
-	userID, ok := GetUserIDFromContext(r.Context())
+// @Router /api/v1/auth/change-password [post]
+func (h *AuthHandlers) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
 	if !ok {
-		h.logger.Error("User ID not found in context")
-		http.Error(w, `{"error":{"code":"INTERNAL_ERROR","message":"User ID not found in context"}}`, http.StatusInternalServerError)
 		return
 	}
 
-	userEmail, _ := GetUserEmailFromContext(r.Context())
+	var req models.ChangePasswordRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
 
-	response := map[string]interface{}{
-		"user_id": userID,
-		"email":   userEmail,
-		"message": "User authenticated successfully",
+	var validationErrors validation.ValidationErrors
+	if req.CurrentPassword == "" {
+		validationErrors = append(validationErrors, validation.ValidationError{
+			Field:   "current_password",
+			Message: "current_password is required",
+		})
+	}
+	validationErrors.Add("new_password", validation.ValidatePassword(req.NewPassword))
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	err := h.authService.ChangePassword(r.Context(), userID, refreshTokenFromCookie(r), &req)
+	if errors.Is(err, services.ErrInvalidCurrentPassword) {
+		h.securityLogger.LogFailedAuth(r, "invalid_current_password")
+	}
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to change password")
+		return
 	}
 
-	h.logger.Info("User profile requested", "user_id", userID, "email", userEmail)
+	h.logger.Info("User changed password", "user_id", userID)
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	_ = json.NewEncoder(w).Encode(response)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Password changed successfully",
+	})
 }
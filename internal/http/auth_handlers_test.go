@@ -2,18 +2,20 @@ package http
 
 import (
 	"bytes"
-	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/aleksandr/strive-api/internal/config"
 	"github.com/aleksandr/strive-api/internal/logger"
 	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAuthHandlers_Register(t *testing.T) {
@@ -23,6 +25,8 @@ func TestAuthHandlers_Register(t *testing.T) {
 		name           string
 		requestBody    interface{}
 		mockSetup      func(*MockAuthService)
+		sendErr        error
+		sendsEmail     bool
 		expectedStatus int
 		expectedError  bool
 	}{
@@ -40,6 +44,26 @@ func TestAuthHandlers_Register(t *testing.T) {
 				m.On("Register", mock.Anything, mock.AnythingOfType("*models.CreateUserRequest")).
 					Return(user, nil)
 			},
+			sendsEmail:     true,
+			expectedStatus: http.StatusCreated,
+			expectedError:  false,
+		},
+		{
+			name: "verification email failure still registers",
+			requestBody: map[string]string{
+				"email":    "test@example.com",
+				"password": "Password123!",
+			},
+			mockSetup: func(m *MockAuthService) {
+				user := &models.User{
+					ID:    uuid.New(),
+					Email: "test@example.com",
+				}
+				m.On("Register", mock.Anything, mock.AnythingOfType("*models.CreateUserRequest")).
+					Return(user, nil)
+			},
+			sendErr:        assert.AnError,
+			sendsEmail:     true,
 			expectedStatus: http.StatusCreated,
 			expectedError:  false,
 		},
@@ -72,8 +96,13 @@ func TestAuthHandlers_Register(t *testing.T) {
 			mockService := new(MockAuthService)
 			tt.mockSetup(mockService)
 
+			mockVerification := new(MockEmailVerificationService)
+			if tt.sendsEmail {
+				mockVerification.On("SendVerification", mock.Anything, mock.AnythingOfType("*models.User")).Return(tt.sendErr)
+			}
+
 			cfg := &config.Config{}
-			handlers := NewAuthHandlers(mockService, logger, cfg)
+			handlers := NewAuthHandlers(mockService, mockVerification, new(MockTokenRevocationService), logger, cfg)
 
 			body, _ := json.Marshal(tt.requestBody)
 			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/register", bytes.NewReader(body))
@@ -98,6 +127,7 @@ func TestAuthHandlers_Register(t *testing.T) {
 			}
 
 			mockService.AssertExpectations(t)
+			mockVerification.AssertExpectations(t)
 		})
 	}
 }
@@ -119,7 +149,7 @@ func TestAuthHandlers_Login(t *testing.T) {
 				"password": "Password123!",
 			},
 			mockSetup: func(m *MockAuthService) {
-				m.On("Login", mock.Anything, "test@example.com", "Password123!").
+				m.On("Login", mock.Anything, "test@example.com", "Password123!", mock.AnythingOfType("models.ClientInfo")).
 					Return("access_token", "refresh_token", nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -132,12 +162,25 @@ func TestAuthHandlers_Login(t *testing.T) {
 				"password": "WrongPassword123!",
 			},
 			mockSetup: func(m *MockAuthService) {
-				m.On("Login", mock.Anything, "test@example.com", "WrongPassword123!").
+				m.On("Login", mock.Anything, "test@example.com", "WrongPassword123!", mock.AnythingOfType("models.ClientInfo")).
 					Return("", "", assert.AnError)
 			},
 			expectedStatus: http.StatusUnauthorized,
 			expectedError:  true,
 		},
+		{
+			name: "email not verified",
+			requestBody: map[string]string{
+				"email":    "test@example.com",
+				"password": "Password123!",
+			},
+			mockSetup: func(m *MockAuthService) {
+				m.On("Login", mock.Anything, "test@example.com", "Password123!", mock.AnythingOfType("models.ClientInfo")).
+					Return("", "", services.ErrEmailNotVerified)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedError:  true,
+		},
 		{
 			name: "invalid request body",
 			requestBody: map[string]string{
@@ -155,7 +198,7 @@ func TestAuthHandlers_Login(t *testing.T) {
 			tt.mockSetup(mockService)
 
 			cfg := &config.Config{}
-			handlers := NewAuthHandlers(mockService, logger, cfg)
+			handlers := NewAuthHandlers(mockService, new(MockEmailVerificationService), new(MockTokenRevocationService), logger, cfg)
 
 			body, _ := json.Marshal(tt.requestBody)
 			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewReader(body))
@@ -196,22 +239,41 @@ func TestAuthHandlers_Login(t *testing.T) {
 	}
 }
 
-func TestAuthHandlers_Me(t *testing.T) {
+func TestAuthHandlers_ChangePassword(t *testing.T) {
 	logger := logger.New("INFO", "json")
+	userID := uuid.New()
 
 	tests := []struct {
 		name           string
-		mockSetup      func(m *MockAuthService)
+		requestBody    interface{}
+		refreshToken   string
+		mockSetup      func(*MockAuthService)
 		expectedStatus int
-		expectedError  bool
 	}{
 		{
-			name: "successful me request",
+			name:         "successful change keeps current session",
+			requestBody:  map[string]string{"current_password": "OldPassword1!", "new_password": "NewPassword2@"},
+			refreshToken: "current-refresh-token",
 			mockSetup: func(m *MockAuthService) {
-				// No mock setup needed as Me doesn't call auth service
+				m.On("ChangePassword", mock.Anything, userID, "current-refresh-token",
+					mock.AnythingOfType("*models.ChangePasswordRequest")).Return(nil)
 			},
 			expectedStatus: http.StatusOK,
-			expectedError:  false,
+		},
+		{
+			name:           "weak new password",
+			requestBody:    map[string]string{"current_password": "OldPassword1!", "new_password": "weak"},
+			mockSetup:      func(m *MockAuthService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "incorrect current password",
+			requestBody: map[string]string{"current_password": "WrongPassword1!", "new_password": "NewPassword2@"},
+			mockSetup: func(m *MockAuthService) {
+				m.On("ChangePassword", mock.Anything, userID, "", mock.AnythingOfType("*models.ChangePasswordRequest")).
+					Return(services.ErrInvalidCurrentPassword)
+			},
+			expectedStatus: http.StatusBadRequest,
 		},
 	}
 
@@ -219,39 +281,146 @@ func TestAuthHandlers_Me(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockService := &MockAuthService{}
 			tt.mockSetup(mockService)
+			handlers := NewAuthHandlers(mockService, new(MockEmailVerificationService), new(MockTokenRevocationService), logger, &config.Config{})
 
-			cfg := &config.Config{}
-			handlers := NewAuthHandlers(mockService, logger, cfg)
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/change-password", bytes.NewReader(body))
+			if tt.refreshToken != "" {
+				req.AddCookie(&http.Cookie{Name: "refresh-token", Value: tt.refreshToken})
+			}
+			req = withUserID(req, userID)
 
-			req := httptest.NewRequest("GET", "/api/v1/auth/me", http.NoBody)
 			rr := httptest.NewRecorder()
+			handlers.ChangePassword(rr, req)
 
-			// Add user context to request (simulating auth middleware)
-			ctx := context.WithValue(req.Context(), UserIDKey, "test-user-id")
-			ctx = context.WithValue(ctx, UserEmailKey, "test@example.com")
-			req = req.WithContext(ctx)
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
 
-			handlers.Me(rr, req)
+func TestAuthHandlers_JWKS(t *testing.T) {
+	mockService := &MockAuthService{}
+	jwks := &models.JWKS{Keys: []models.JWK{{Kty: "OKP", Use: "sig", Alg: "EdDSA", Kid: "key-1", Crv: "Ed25519", X: "abc"}}}
+	mockService.On("JWKS").Return(jwks)
+	handlers := NewAuthHandlers(mockService, new(MockEmailVerificationService), new(MockTokenRevocationService), logger.New("INFO", "json"), &config.Config{})
 
-			assert.Equal(t, tt.expectedStatus, rr.Code)
+	rr := httptest.NewRecorder()
+	handlers.JWKS(rr, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
 
-			if tt.expectedError {
-				var response map[string]interface{}
-				err := json.Unmarshal(rr.Body.Bytes(), &response)
-				assert.NoError(t, err)
-				assert.Contains(t, response, "error")
-			} else {
-				var response map[string]interface{}
-				err := json.Unmarshal(rr.Body.Bytes(), &response)
-				assert.NoError(t, err)
-				assert.Contains(t, response, "user_id")
-				assert.Contains(t, response, "email")
-				assert.Contains(t, response, "message")
-				assert.Equal(t, "test-user-id", response["user_id"])
-				assert.Equal(t, "test@example.com", response["email"])
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "public, max-age=300", rr.Header().Get("Cache-Control"))
+	var got models.JWKS
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&got))
+	assert.Equal(t, *jwks, got)
+}
+
+func TestAuthHandlers_LogoutRevokesAccessToken(t *testing.T) {
+	mockService := &MockAuthService{}
+	revocations := &MockTokenRevocationService{}
+	claims := &services.Claims{UserID: uuid.New(), Email: "test@example.com"}
+	mockService.On("Logout", mock.Anything, "refresh_token").Return(nil)
+	mockService.On("ValidateToken", "access_token").Return(claims, nil)
+	revocations.On("Revoke", mock.Anything, claims).Return(nil)
+	handlers := NewAuthHandlers(mockService, new(MockEmailVerificationService), revocations, logger.New("INFO", "json"), &config.Config{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/logout", http.NoBody)
+	req.Header.Set("Authorization", "Bearer access_token")
+	req.AddCookie(&http.Cookie{Name: "refresh-token", Value: "refresh_token"})
+	rr := httptest.NewRecorder()
+	handlers.Logout(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockService.AssertExpectations(t)
+	revocations.AssertExpectations(t)
+}
+
+func TestAuthHandlers_LoginWithMFA(t *testing.T) {
+	logger := logger.New("INFO", "json")
+
+	t.Run("ReturnsChallenge", func(t *testing.T) {
+		mockService := &MockAuthService{}
+		mockService.On("Login", mock.Anything, "test@example.com", "Password123!", mock.AnythingOfType("models.ClientInfo")).
+			Return("", "", &services.MFAChallengeError{Token: "challenge", ExpiresIn: 5 * time.Minute})
+		handlers := NewAuthHandlers(
+			mockService, new(MockEmailVerificationService), new(MockTokenRevocationService), logger, &config.Config{},
+		)
+
+		body, _ := json.Marshal(map[string]string{"email": "test@example.com", "password": "Password123!"})
+		rr := httptest.NewRecorder()
+		handlers.Login(rr, httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewReader(body)))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response MFAChallengeResponse
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.True(t, response.MFARequired)
+		assert.Equal(t, "challenge", response.MFAToken)
+		assert.Equal(t, 300, response.ExpiresIn)
+		assert.Empty(t, rr.Result().Cookies(), "no session before the second factor")
+	})
+
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"valid code", nil, http.StatusOK, ""},
+		{"invalid code", services.ErrInvalidMFACode, http.StatusUnauthorized, "INVALID_MFA_CODE"},
+		{"expired challenge", services.ErrInvalidMFAChallenge, http.StatusUnauthorized, "INVALID_MFA_TOKEN"},
+		{"throttled", &services.LoginThrottledError{RetryAfter: time.Minute}, http.StatusTooManyRequests, "TOO_MANY_ATTEMPTS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockAuthService{}
+			accessToken, refreshToken := "", ""
+			if tt.serviceErr == nil {
+				accessToken, refreshToken = "access_token", "refresh_token"
 			}
+			mockService.On("CompleteMFALogin", mock.Anything, "challenge", "123456", mock.AnythingOfType("models.ClientInfo")).
+				Return(accessToken, refreshToken, tt.serviceErr)
+			handlers := NewAuthHandlers(
+				mockService, new(MockEmailVerificationService), new(MockTokenRevocationService), logger, &config.Config{},
+			)
+
+			body, _ := json.Marshal(MFALoginRequest{MFAToken: "challenge", Code: "123456"})
+			rr := httptest.NewRecorder()
+			handlers.LoginMFA(rr, httptest.NewRequest(http.MethodPost, "/api/v1/auth/login/mfa", bytes.NewReader(body)))
 
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.serviceErr == nil {
+				var response AuthResponse
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, "access_token", response.AccessToken)
+				require.Len(t, rr.Result().Cookies(), 1)
+				assert.Equal(t, "refresh_token", rr.Result().Cookies()[0].Value)
+			} else {
+				var response ErrorResponse
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedCode, response.Error.Code)
+			}
 			mockService.AssertExpectations(t)
 		})
 	}
 }
+
+func TestAuthHandlers_LoginThrottled(t *testing.T) {
+	mockService := &MockAuthService{}
+	mockService.On("Login", mock.Anything, "test@example.com", "Password123!", mock.AnythingOfType("models.ClientInfo")).
+		Return("", "", &services.LoginThrottledError{RetryAfter: 1500 * time.Millisecond})
+	handlers := NewAuthHandlers(
+		mockService, new(MockEmailVerificationService), new(MockTokenRevocationService), logger.New("INFO", "json"), &config.Config{},
+	)
+
+	body, _ := json.Marshal(map[string]string{"email": "test@example.com", "password": "Password123!"})
+	rr := httptest.NewRecorder()
+	handlers.Login(rr, httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewReader(body)))
+
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "2", rr.Header().Get("Retry-After"), "the wait is rounded up to whole seconds")
+	var response ErrorResponse
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "TOO_MANY_ATTEMPTS", response.Error.Code)
+	mockService.AssertExpectations(t)
+}
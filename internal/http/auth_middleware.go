@@ -8,14 +8,17 @@ import (
 	"strings"
 
 	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
 	"github.com/aleksandr/strive-api/internal/services"
 )
 
 type contextKey string
 
 const (
-	UserIDKey    contextKey = "user_id"
-	UserEmailKey contextKey = "user_email"
+	UserIDKey          contextKey = "user_id"
+	UserEmailKey       contextKey = "user_email"
+	UserRoleKey        contextKey = "user_role"
+	UserPermissionsKey contextKey = "user_permissions"
 )
 
 type AuthError struct {
@@ -53,7 +56,11 @@ func logAuthFailure(log *logger.Logger, r *http.Request, reason string) {
 	)
 }
 
-func AuthMiddleware(authService services.AuthService, log *logger.Logger) func(http.Handler) http.Handler {
+func AuthMiddleware(
+	authService services.AuthService,
+	revocations services.TokenRevocationService,
+	log *logger.Logger,
+) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
@@ -93,12 +100,24 @@ func AuthMiddleware(authService services.AuthService, log *logger.Logger) func(h
 				return
 			}
 
+			if err := revocations.Check(r.Context(), claims); err != nil {
+				if errors.Is(err, services.ErrTokenRevoked) {
+					writeAuthError(w, log, r, "TOKEN_REVOKED", "Token has been revoked", "token_revoked")
+					return
+				}
+				log.Error("Failed to check token revocation", "error", err)
+				writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+				return
+			}
+
 			log.Debug("Authentication successful",
 				"user_id", claims.UserID,
 				"email", claims.Email)
 
 			ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID.String())
 			ctx = context.WithValue(ctx, UserEmailKey, claims.Email)
+			ctx = context.WithValue(ctx, UserRoleKey, claims.Role)
+			ctx = context.WithValue(ctx, UserPermissionsKey, claims.Permissions)
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
@@ -114,3 +133,15 @@ func GetUserEmailFromContext(ctx context.Context) (string, bool) {
 	email, ok := ctx.Value(UserEmailKey).(string)
 	return email, ok
 }
+
+// GetUserRoleFromContext returns the role from the access token; tokens issued
+// before roles existed carry none.
+func GetUserRoleFromContext(ctx context.Context) (models.Role, bool) {
+	role, ok := ctx.Value(UserRoleKey).(models.Role)
+	return role, ok
+}
+
+func GetUserPermissionsFromContext(ctx context.Context) ([]string, bool) {
+	permissions, ok := ctx.Value(UserPermissionsKey).([]string)
+	return permissions, ok
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/aleksandr/strive-api/internal/logger"
 	"github.com/aleksandr/strive-api/internal/models"
@@ -23,11 +24,19 @@ func (m *mockAuthService) Register(ctx context.Context, req *models.CreateUserRe
 	return nil, nil
 }
 
-func (m *mockAuthService) Login(ctx context.Context, email, password string) (string, string, error) {
+func (m *mockAuthService) Login(ctx context.Context, email, password string, client models.ClientInfo) (string, string, error) {
 	return "", "", nil
 }
 
-func (m *mockAuthService) RefreshToken(ctx context.Context, refreshToken string) (string, string, error) {
+func (m *mockAuthService) CompleteMFALogin(
+	ctx context.Context,
+	challengeToken, code string,
+	client models.ClientInfo,
+) (string, string, error) {
+	return "", "", nil
+}
+
+func (m *mockAuthService) RefreshToken(ctx context.Context, refreshToken string, client models.ClientInfo) (string, string, error) {
 	return "", "", nil
 }
 
@@ -50,12 +59,47 @@ func (m *mockAuthService) Logout(ctx context.Context, refreshToken string) error
 	return nil
 }
 
+func (m *mockAuthService) ChangePassword(ctx context.Context, userID uuid.UUID, currentRefreshToken string, req *models.ChangePasswordRequest) error {
+	return nil
+}
+
+func (m *mockAuthService) IssueAccessToken(user *models.User) (string, error) {
+	return "", nil
+}
+
+func (m *mockAuthService) JWKS() *models.JWKS {
+	return &models.JWKS{}
+}
+
+type mockTokenRevocationService struct {
+	checkFunc func(*services.Claims) error
+}
+
+func (m *mockTokenRevocationService) Revoke(ctx context.Context, claims *services.Claims) error {
+	return nil
+}
+
+func (m *mockTokenRevocationService) RevokeUser(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	return nil
+}
+
+func (m *mockTokenRevocationService) Check(ctx context.Context, claims *services.Claims) error {
+	if m.checkFunc != nil {
+		return m.checkFunc(claims)
+	}
+	return nil
+}
+
+func (m *mockTokenRevocationService) PruneExpired(ctx context.Context) error {
+	return nil
+}
+
 func TestAuthMiddleware(t *testing.T) {
 	log := logger.New("INFO", "json")
 
 	t.Run("MissingAuthorizationHeader", func(t *testing.T) {
 		mockAuth := &mockAuthService{}
-		middleware := AuthMiddleware(mockAuth, log)
+		middleware := AuthMiddleware(mockAuth, &mockTokenRevocationService{}, log)
 
 		handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusOK)
@@ -78,7 +122,7 @@ func TestAuthMiddleware(t *testing.T) {
 
 	t.Run("InvalidBearerFormat", func(t *testing.T) {
 		mockAuth := &mockAuthService{}
-		middleware := AuthMiddleware(mockAuth, log)
+		middleware := AuthMiddleware(mockAuth, &mockTokenRevocationService{}, log)
 
 		handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusOK)
@@ -100,7 +144,7 @@ func TestAuthMiddleware(t *testing.T) {
 
 	t.Run("EmptyToken", func(t *testing.T) {
 		mockAuth := &mockAuthService{}
-		middleware := AuthMiddleware(mockAuth, log)
+		middleware := AuthMiddleware(mockAuth, &mockTokenRevocationService{}, log)
 
 		handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusOK)
@@ -126,7 +170,7 @@ func TestAuthMiddleware(t *testing.T) {
 				return nil, services.ErrTokenExpired
 			},
 		}
-		middleware := AuthMiddleware(mockAuth, log)
+		middleware := AuthMiddleware(mockAuth, &mockTokenRevocationService{}, log)
 
 		handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusOK)
@@ -153,7 +197,7 @@ func TestAuthMiddleware(t *testing.T) {
 				return nil, services.ErrInvalidIssuer
 			},
 		}
-		middleware := AuthMiddleware(mockAuth, log)
+		middleware := AuthMiddleware(mockAuth, &mockTokenRevocationService{}, log)
 
 		handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusOK)
@@ -179,7 +223,7 @@ func TestAuthMiddleware(t *testing.T) {
 				return nil, services.ErrInvalidAudience
 			},
 		}
-		middleware := AuthMiddleware(mockAuth, log)
+		middleware := AuthMiddleware(mockAuth, &mockTokenRevocationService{}, log)
 
 		handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusOK)
@@ -211,7 +255,7 @@ func TestAuthMiddleware(t *testing.T) {
 				}, nil
 			},
 		}
-		middleware := AuthMiddleware(mockAuth, log)
+		middleware := AuthMiddleware(mockAuth, &mockTokenRevocationService{}, log)
 
 		handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			contextUserID, ok := GetUserIDFromContext(r.Context())
@@ -248,7 +292,7 @@ func TestAuthMiddleware(t *testing.T) {
 				}, nil
 			},
 		}
-		middleware := AuthMiddleware(mockAuth, log)
+		middleware := AuthMiddleware(mockAuth, &mockTokenRevocationService{}, log)
 
 		handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			contextUserID, ok := GetUserIDFromContext(r.Context())
@@ -271,4 +315,33 @@ func TestAuthMiddleware(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
+
+	t.Run("RevokedToken", func(t *testing.T) {
+		mockAuth := &mockAuthService{
+			validateTokenFunc: func(token string) (*services.Claims, error) {
+				return &services.Claims{UserID: uuid.New(), Email: "test@example.com"}, nil
+			},
+		}
+		revocations := &mockTokenRevocationService{
+			checkFunc: func(*services.Claims) error { return services.ErrTokenRevoked },
+		}
+		middleware := AuthMiddleware(mockAuth, revocations, log)
+
+		handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("handler must not run for a revoked token")
+		}))
+
+		req := httptest.NewRequest("GET", "/test", http.NoBody)
+		req.Header.Set("Authorization", "Bearer revoked-token")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		var response AuthError
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, "TOKEN_REVOKED", response.Error.Code)
+	})
 }
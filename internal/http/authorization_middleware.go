@@ -0,0 +1,62 @@
+package http
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+)
+
+// RequireRole lets a request through only when the access token's role is one
+// of roles. It must run after AuthMiddleware, e.g.
+//
+//	authMiddleware(RequireRole(log, models.RoleAdmin)(adminMux))
+func RequireRole(log *logger.Logger, roles ...models.Role) func(http.Handler) http.Handler {
+	required := make([]string, 0, len(roles))
+	for _, role := range roles {
+		required = append(required, string(role))
+	}
+
+	return authorize(log, "role "+strings.Join(required, "|"), func(r *http.Request) bool {
+		role, _ := GetUserRoleFromContext(r.Context())
+		return slices.Contains(roles, role)
+	})
+}
+
+// RequirePermission lets a request through only when the access token grants
+// every one of permissions. It must run after AuthMiddleware.
+func RequirePermission(log *logger.Logger, permissions ...string) func(http.Handler) http.Handler {
+	return authorize(log, "permission "+strings.Join(permissions, ","), func(r *http.Request) bool {
+		granted, _ := GetUserPermissionsFromContext(r.Context())
+		for _, permission := range permissions {
+			if !slices.Contains(granted, permission) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func authorize(log *logger.Logger, required string, allowed func(r *http.Request) bool) func(http.Handler) http.Handler {
+	securityLogger := NewSecurityLogger(log)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, ok := GetUserIDFromContext(r.Context())
+			if !ok {
+				writeAuthError(w, log, r, "UNAUTHORIZED", "Authentication required", "missing_authentication")
+				return
+			}
+
+			if !allowed(r) {
+				securityLogger.LogAccessDenied(r, userID, required)
+				writeError(w, http.StatusForbidden, "FORBIDDEN", "You do not have permission to perform this action")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorizationMiddleware(t *testing.T) {
+	log := logger.New("INFO", "json")
+
+	// serve runs middleware behind AuthMiddleware with a token carrying claims.
+	serve := func(t *testing.T, middleware func(http.Handler) http.Handler, claims *services.Claims) *httptest.ResponseRecorder {
+		t.Helper()
+		mockAuth := &mockAuthService{
+			validateTokenFunc: func(string) (*services.Claims, error) { return claims, nil },
+		}
+		handler := AuthMiddleware(mockAuth, &mockTokenRevocationService{}, log)(
+			middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})),
+		)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users", http.NoBody)
+		req.Header.Set("Authorization", "Bearer token")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	assertForbidden := func(t *testing.T, w *httptest.ResponseRecorder) {
+		t.Helper()
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		var response ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "FORBIDDEN", response.Error.Code)
+	}
+
+	user := &services.Claims{UserID: uuid.New(), Email: "user@example.com", Role: models.RoleUser}
+	coach := &services.Claims{UserID: uuid.New(), Email: "coach@example.com", Role: models.RoleCoach}
+	admin := &services.Claims{
+		UserID:      uuid.New(),
+		Email:       "admin@example.com",
+		Role:        models.RoleAdmin,
+		Permissions: []string{models.PermissionAuditRead, models.PermissionUsersRead},
+	}
+	legacy := &services.Claims{UserID: uuid.New(), Email: "legacy@example.com"}
+
+	t.Run("RequireRole", func(t *testing.T) {
+		middleware := RequireRole(log, models.RoleCoach, models.RoleAdmin)
+
+		assert.Equal(t, http.StatusOK, serve(t, middleware, coach).Code)
+		assert.Equal(t, http.StatusOK, serve(t, middleware, admin).Code)
+		assertForbidden(t, serve(t, middleware, user))
+		assertForbidden(t, serve(t, middleware, legacy))
+	})
+
+	t.Run("RequirePermission", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(t, RequirePermission(log, models.PermissionUsersRead), admin).Code)
+		assertForbidden(t, serve(t, RequirePermission(log, models.PermissionUsersRead, models.PermissionUsersManage), admin))
+		assertForbidden(t, serve(t, RequirePermission(log, models.PermissionUsersRead), coach))
+	})
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		handler := RequireRole(log, models.RoleUser)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/users", http.NoBody))
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code, "without AuthMiddleware nobody is let through")
+	})
+}
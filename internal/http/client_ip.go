@@ -0,0 +1,91 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+)
+
+type clientIPKeyType string
+
+const clientIPKey clientIPKeyType = "client_ip"
+
+// ClientIPMiddleware works out the address each request came from, believing
+// forwarding headers only from trustedProxies, and stores it for getClientIP.
+func ClientIPMiddleware(trustedProxies []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), clientIPKey, clientIP(r, trustedProxies))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// getClientIP returns the address ClientIPMiddleware found for the request,
+// or its remote address outside the middleware.
+func getClientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(clientIPKey).(string); ok {
+		return ip
+	}
+	return clientIP(r, nil)
+}
+
+// clientIP returns the remote address of r or, when that is a trusted proxy,
+// the nearest forwarded address that is not. Each proxy appends the address it
+// was reached from to X-Forwarded-For, so only the entries right of the first
+// untrusted one can be relied on; anything further left may be forged.
+func clientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	client, err := remoteAddr(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	for _, hop := range forwardedHops(r) {
+		if !isTrustedProxy(client, trustedProxies) {
+			break
+		}
+		addr, err := netip.ParseAddr(hop)
+		if err != nil {
+			break
+		}
+		client = addr.Unmap()
+	}
+	return client.String()
+}
+
+func remoteAddr(value string) (netip.Addr, error) {
+	if addrPort, err := netip.ParseAddrPort(value); err == nil {
+		return addrPort.Addr().Unmap(), nil
+	}
+	addr, err := netip.ParseAddr(value)
+	return addr.Unmap(), err
+}
+
+// forwardedHops returns the forwarded addresses of r, nearest first.
+func forwardedHops(r *http.Request) []string {
+	var hops []string
+	if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+		for _, line := range forwarded {
+			hops = append(hops, strings.Split(line, ",")...)
+		}
+	} else if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
+		hops = []string{realIP}
+	}
+
+	slices.Reverse(hops)
+	for i := range hops {
+		hops[i] = strings.TrimSpace(hops[i])
+	}
+	return hops
+}
+
+func isTrustedProxy(addr netip.Addr, trustedProxies []netip.Prefix) bool {
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIP(t *testing.T) {
+	trustedProxies := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("192.0.2.7/32")}
+
+	tests := []struct {
+		name          string
+		remoteAddr    string
+		forwardedFor  []string
+		realIP        string
+		expectedIP    string
+		expectedNoCfg string
+	}{
+		{
+			name:          "direct request",
+			remoteAddr:    "203.0.113.7:41234",
+			expectedIP:    "203.0.113.7",
+			expectedNoCfg: "203.0.113.7",
+		},
+		{
+			name:          "headers from an untrusted client are ignored",
+			remoteAddr:    "203.0.113.7:41234",
+			forwardedFor:  []string{"198.51.100.1"},
+			realIP:        "198.51.100.2",
+			expectedIP:    "203.0.113.7",
+			expectedNoCfg: "203.0.113.7",
+		},
+		{
+			name:          "through a trusted proxy",
+			remoteAddr:    "10.0.0.2:41234",
+			forwardedFor:  []string{"198.51.100.1, 203.0.113.7"},
+			expectedIP:    "203.0.113.7",
+			expectedNoCfg: "10.0.0.2",
+		},
+		{
+			name:          "through a chain of trusted proxies",
+			remoteAddr:    "10.0.0.2:41234",
+			forwardedFor:  []string{"203.0.113.7, 192.0.2.7", "10.0.0.3"},
+			expectedIP:    "203.0.113.7",
+			expectedNoCfg: "10.0.0.2",
+		},
+		{
+			name:          "X-Real-IP from a trusted proxy",
+			remoteAddr:    "10.0.0.2:41234",
+			realIP:        "203.0.113.7",
+			expectedIP:    "203.0.113.7",
+			expectedNoCfg: "10.0.0.2",
+		},
+		{
+			name:          "malformed forwarded address",
+			remoteAddr:    "10.0.0.2:41234",
+			forwardedFor:  []string{"203.0.113.7, not-an-ip"},
+			expectedIP:    "10.0.0.2",
+			expectedNoCfg: "10.0.0.2",
+		},
+		{
+			name:          "IPv4-mapped IPv6 remote address",
+			remoteAddr:    "[::ffff:203.0.113.7]:41234",
+			expectedIP:    "203.0.113.7",
+			expectedNoCfg: "203.0.113.7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
+			req.RemoteAddr = tt.remoteAddr
+			for _, value := range tt.forwardedFor {
+				req.Header.Add("X-Forwarded-For", value)
+			}
+			if tt.realIP != "" {
+				req.Header.Set("X-Real-IP", tt.realIP)
+			}
+
+			assert.Equal(t, tt.expectedIP, clientIP(req, trustedProxies))
+			assert.Equal(t, tt.expectedNoCfg, clientIP(req, nil), "without trusted proxies headers are never believed")
+		})
+	}
+}
+
+func TestClientIPMiddleware(t *testing.T) {
+	var ip string
+	handler := ClientIPMiddleware([]netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip = getClientIP(r)
+		}),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
+	req.RemoteAddr = "10.0.0.2:41234"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, "203.0.113.7", ip)
+	assert.Equal(t, "10.0.0.2", getClientIP(req), "outside the middleware only the remote address is used")
+}
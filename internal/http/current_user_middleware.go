@@ -0,0 +1,85 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/google/uuid"
+)
+
+const currentUserKey contextKey = "current_user"
+
+// CurrentUserMiddleware makes the authenticated user's record available to
+// handlers and later middleware. It is loaded lazily, at most once per request,
+// so endpoints that never need it do not pay for the lookup.
+func CurrentUserMiddleware(userService services.UserService, log *logger.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rawUserID, ok := GetUserIDFromContext(r.Context())
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			userID, err := uuid.Parse(rawUserID)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := r.Context()
+			load := sync.OnceValue(func() *models.User {
+				user, err := userService.GetProfile(ctx, userID)
+				if err != nil {
+					log.Warn("Failed to load current user", "user_id", userID, "error", err)
+					return nil
+				}
+				return user
+			})
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, currentUserKey, load)))
+		})
+	}
+}
+
+// currentUser returns the authenticated user, or nil when it could not be loaded.
+func currentUser(r *http.Request) *models.User {
+	load, ok := r.Context().Value(currentUserKey).(func() *models.User)
+	if !ok {
+		return nil
+	}
+	return load()
+}
+
+// preferredWeightUnit returns the caller's preferred weight unit, or "" when it
+// is unknown and weights should be reported as stored.
+func preferredWeightUnit(r *http.Request) models.WeightUnit {
+	user := currentUser(r)
+	if user == nil {
+		return ""
+	}
+	return user.WeightUnit
+}
+
+// preferredWeekStart returns the day the caller's weeks start on, or "" when it
+// is unknown and weeks start on Monday.
+func preferredWeekStart(r *http.Request) models.WeekStart {
+	user := currentUser(r)
+	if user == nil {
+		return ""
+	}
+	return user.WeekStart
+}
+
+type weightConverter interface {
+	ConvertWeights(unit models.WeightUnit)
+}
+
+// writeConvertedJSON writes v after converting its weights to the caller's preferred unit.
+func writeConvertedJSON(w http.ResponseWriter, r *http.Request, status int, v weightConverter) {
+	v.ConvertWeights(preferredWeightUnit(r))
+	writeJSON(w, status, v)
+}
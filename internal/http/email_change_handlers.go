@@ -0,0 +1,148 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/aleksandr/strive-api/internal/validation"
+)
+
+type EmailChangeHandlers struct {
+	changeService  services.EmailChangeService
+	authService    services.AuthService
+	logger         *logger.Logger
+	securityLogger *SecurityLogger
+}
+
+func NewEmailChangeHandlers(
+	changeService services.EmailChangeService,
+	authService services.AuthService,
+	logger *logger.Logger,
+) *EmailChangeHandlers {
+	return &EmailChangeHandlers{
+		changeService:  changeService,
+		authService:    authService,
+		logger:         logger,
+		securityLogger: NewSecurityLogger(logger),
+	}
+}
+
+// RequestEmailChange godoc
+// @Summary Request email change
+// @Description Email a confirmation link to the new address. The account keeps its current email until the link
+// @Description is confirmed.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.ChangeEmailRequest true "New email and current password"
+// @Success 202 {object} map[string]interface{} "Confirmation email sent"
+// @Failure 400 {object} ErrorResponse "Invalid request data, incorrect current password or unchanged email"
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Failure 409 {object} ErrorResponse "Email address is already in use"
+// @Router /api/v1/auth/change-email [post]
+func (h *EmailChangeHandlers) RequestEmailChange(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.ChangeEmailRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var validationErrors validation.ValidationErrors
+	validationErrors.Add("new_email", validation.ValidateEmail(req.NewEmail))
+	if req.CurrentPassword == "" {
+		validationErrors = append(validationErrors, validation.ValidationError{
+			Field:   "current_password",
+			Message: "current_password is required",
+		})
+	}
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	err := h.changeService.RequestChange(r.Context(), userID, &req)
+	if errors.Is(err, services.ErrInvalidCurrentPassword) {
+		h.securityLogger.LogFailedAuth(r, "invalid_current_password")
+	}
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to request email change")
+		return
+	}
+
+	h.logger.Info("User requested email change", "user_id", userID)
+
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{
+		"message": "A confirmation link has been sent to the new email address",
+	})
+}
+
+// ConfirmEmailChange godoc
+// @Summary Confirm email change
+// @Description Switch the account to the new email using the token from the confirmation email. The previous
+// @Description address is notified and a new access token carrying the new email is returned.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.ConfirmEmailChangeRequest true "Confirmation token"
+// @Success 200 {object} AuthResponse "Email changed"
+// @Failure 400 {object} ErrorResponse "Invalid request data or invalid/expired token"
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Failure 409 {object} ErrorResponse "Email address is already in use"
+// @Router /api/v1/auth/change-email/confirm [post]
+func (h *EmailChangeHandlers) ConfirmEmailChange(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.ConfirmEmailChangeRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var validationErrors validation.ValidationErrors
+	if req.Token == "" {
+		validationErrors = append(validationErrors, validation.ValidationError{
+			Field:   "token",
+			Message: "token is required",
+		})
+	}
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	user, err := h.changeService.ConfirmChange(r.Context(), userID, req.Token)
+	if errors.Is(err, services.ErrInvalidEmailChangeToken) {
+		h.securityLogger.LogFailedAuth(r, "invalid_email_change_token")
+	}
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to confirm email change")
+		return
+	}
+
+	h.logger.Info("User changed email", "user_id", userID)
+
+	// Access tokens embed the email, so the caller needs one for the new address.
+	accessToken, err := h.authService.IssueAccessToken(user)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to issue access token")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, AuthResponse{
+		AccessToken: accessToken,
+		ExpiresIn:   900,
+		TokenType:   "Bearer",
+		Message:     "Email changed successfully",
+	})
+}
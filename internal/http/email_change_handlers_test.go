@@ -0,0 +1,118 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailChangeHandlers_RequestEmailChange(t *testing.T) {
+	logger := logger.New("INFO", "json")
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockSetup      func(*MockEmailChangeService)
+		expectedStatus int
+	}{
+		{
+			name:        "accepted",
+			requestBody: map[string]string{"new_email": "new@example.com", "current_password": "Password1!"},
+			mockSetup: func(m *MockEmailChangeService) {
+				m.On("RequestChange", mock.Anything, userID, mock.AnythingOfType("*models.ChangeEmailRequest")).Return(nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:        "wrong password",
+			requestBody: map[string]string{"new_email": "new@example.com", "current_password": "wrong"},
+			mockSetup: func(m *MockEmailChangeService) {
+				m.On("RequestChange", mock.Anything, userID, mock.AnythingOfType("*models.ChangeEmailRequest")).
+					Return(services.ErrInvalidCurrentPassword)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "email taken",
+			requestBody: map[string]string{"new_email": "taken@example.com", "current_password": "Password1!"},
+			mockSetup: func(m *MockEmailChangeService) {
+				m.On("RequestChange", mock.Anything, userID, mock.AnythingOfType("*models.ChangeEmailRequest")).
+					Return(services.ErrEmailTaken)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "validation error",
+			requestBody:    map[string]string{"new_email": "not-an-email"},
+			mockSetup:      func(m *MockEmailChangeService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockEmailChangeService)
+			tt.mockSetup(mockService)
+			handlers := NewEmailChangeHandlers(mockService, new(MockAuthService), logger)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := withUserID(httptest.NewRequest(http.MethodPost, "/change-email", bytes.NewReader(body)), userID)
+			rr := httptest.NewRecorder()
+			handlers.RequestEmailChange(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEmailChangeHandlers_ConfirmEmailChange(t *testing.T) {
+	logger := logger.New("INFO", "json")
+	userID := uuid.New()
+
+	t.Run("returns access token for new email", func(t *testing.T) {
+		user := &models.User{ID: userID, Email: "new@example.com"}
+		mockService := new(MockEmailChangeService)
+		mockService.On("ConfirmChange", mock.Anything, userID, "abc").Return(user, nil)
+		mockAuth := new(MockAuthService)
+		mockAuth.On("IssueAccessToken", user).Return("new_access_token", nil)
+		handlers := NewEmailChangeHandlers(mockService, mockAuth, logger)
+
+		body, _ := json.Marshal(map[string]string{"token": "abc"})
+		req := withUserID(httptest.NewRequest(http.MethodPost, "/change-email/confirm", bytes.NewReader(body)), userID)
+		rr := httptest.NewRecorder()
+		handlers.ConfirmEmailChange(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var response AuthResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, "new_access_token", response.AccessToken)
+		mockService.AssertExpectations(t)
+		mockAuth.AssertExpectations(t)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		mockService := new(MockEmailChangeService)
+		mockService.On("ConfirmChange", mock.Anything, userID, "abc").Return(nil, services.ErrInvalidEmailChangeToken)
+		handlers := NewEmailChangeHandlers(mockService, new(MockAuthService), logger)
+
+		body, _ := json.Marshal(map[string]string{"token": "abc"})
+		req := withUserID(httptest.NewRequest(http.MethodPost, "/change-email/confirm", bytes.NewReader(body)), userID)
+		rr := httptest.NewRecorder()
+		handlers.ConfirmEmailChange(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+}
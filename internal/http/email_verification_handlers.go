@@ -0,0 +1,104 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/aleksandr/strive-api/internal/validation"
+)
+
+type EmailVerificationHandlers struct {
+	verificationService services.EmailVerificationService
+	logger              *logger.Logger
+	securityLogger      *SecurityLogger
+}
+
+func NewEmailVerificationHandlers(verificationService services.EmailVerificationService, logger *logger.Logger) *EmailVerificationHandlers {
+	return &EmailVerificationHandlers{
+		verificationService: verificationService,
+		logger:              logger,
+		securityLogger:      NewSecurityLogger(logger),
+	}
+}
+
+// VerifyEmail godoc
+// @Summary Verify email address
+// @Description Confirm the account's email address using the token from a verification email. Access tokens
+// @Description issued before verification should be refreshed.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Param request body models.VerifyEmailRequest true "Verification token"
+// @Success 200 {object} map[string]interface{} "Email verified"
+// @Failure 400 {object} ErrorResponse "Invalid request data or invalid/expired token"
+// @Router /api/v1/auth/verify-email [post]
+func (h *EmailVerificationHandlers) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req models.VerifyEmailRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var validationErrors validation.ValidationErrors
+	if req.Token == "" {
+		validationErrors = append(validationErrors, validation.ValidationError{
+			Field:   "token",
+			Message: "token is required",
+		})
+	}
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	err := h.verificationService.Verify(r.Context(), req.Token)
+	if errors.Is(err, services.ErrInvalidVerificationToken) {
+		h.securityLogger.LogFailedAuth(r, "invalid_email_verification_token")
+	}
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to verify email")
+		return
+	}
+
+	h.logger.Info("Email address verified")
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Email address verified",
+	})
+}
+
+// ResendVerification godoc
+// @Summary Resend verification email
+// @Description Email a new verification link to an unverified account. The response is the same whether or not
+// @Description the address belongs to an unverified account.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Param request body models.ResendVerificationRequest true "Account email"
+// @Success 202 {object} map[string]interface{} "Verification email sent if the account needs one"
+// @Failure 400 {object} ErrorResponse "Invalid request data"
+// @Router /api/v1/auth/resend-verification [post]
+func (h *EmailVerificationHandlers) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	var req models.ResendVerificationRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var validationErrors validation.ValidationErrors
+	validationErrors.Add("email", validation.ValidateEmail(req.Email))
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	// Failures are logged but never surfaced, so the response cannot reveal whether the account exists.
+	if err := h.verificationService.Resend(r.Context(), req.Email); err != nil {
+		h.logger.Error("Failed to resend verification email", "error", err)
+	}
+
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{
+		"message": "If this email belongs to an unverified account, a verification link has been sent",
+	})
+}
@@ -0,0 +1,162 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/config"
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEmailVerificationHandlers_VerifyEmail(t *testing.T) {
+	logger := logger.New("INFO", "json")
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockSetup      func(*MockEmailVerificationService)
+		expectedStatus int
+	}{
+		{
+			name:        "verified",
+			requestBody: map[string]string{"token": "abc"},
+			mockSetup: func(m *MockEmailVerificationService) {
+				m.On("Verify", mock.Anything, "abc").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "invalid token",
+			requestBody: map[string]string{"token": "abc"},
+			mockSetup: func(m *MockEmailVerificationService) {
+				m.On("Verify", mock.Anything, "abc").Return(services.ErrInvalidVerificationToken)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing token",
+			requestBody:    map[string]string{},
+			mockSetup:      func(m *MockEmailVerificationService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockEmailVerificationService)
+			tt.mockSetup(mockService)
+			handlers := NewEmailVerificationHandlers(mockService, logger)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/verify-email", bytes.NewReader(body))
+			rr := httptest.NewRecorder()
+			handlers.VerifyEmail(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEmailVerificationHandlers_ResendVerification(t *testing.T) {
+	logger := logger.New("INFO", "json")
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockSetup      func(*MockEmailVerificationService)
+		expectedStatus int
+	}{
+		{
+			name:        "accepted",
+			requestBody: map[string]string{"email": "user@example.com"},
+			mockSetup: func(m *MockEmailVerificationService) {
+				m.On("Resend", mock.Anything, "user@example.com").Return(nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:        "delivery failure is not revealed",
+			requestBody: map[string]string{"email": "user@example.com"},
+			mockSetup: func(m *MockEmailVerificationService) {
+				m.On("Resend", mock.Anything, "user@example.com").Return(errors.New("smtp down"))
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "invalid email",
+			requestBody:    map[string]string{"email": "not-an-email"},
+			mockSetup:      func(m *MockEmailVerificationService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockEmailVerificationService)
+			tt.mockSetup(mockService)
+			handlers := NewEmailVerificationHandlers(mockService, logger)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/resend-verification", bytes.NewReader(body))
+			rr := httptest.NewRecorder()
+			handlers.ResendVerification(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRequireVerifiedEmail(t *testing.T) {
+	logger := logger.New("INFO", "json")
+	verifiedAt := time.Now()
+
+	tests := []struct {
+		name           string
+		policy         string
+		verified       bool
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{"limit blocks writes", config.EmailVerificationPolicyLimit, false, http.MethodPost, "/api/v1/workouts", http.StatusForbidden},
+		{"limit allows reads", config.EmailVerificationPolicyLimit, false, http.MethodGet, "/api/v1/workouts", http.StatusOK},
+		{"limit allows profile edits", config.EmailVerificationPolicyLimit, false, http.MethodPatch, "/api/v1/users/me", http.StatusOK},
+		{"limit allows verified users", config.EmailVerificationPolicyLimit, true, http.MethodPost, "/api/v1/workouts", http.StatusOK},
+		{"none allows writes", config.EmailVerificationPolicyNone, false, http.MethodPost, "/api/v1/workouts", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := uuid.New()
+			user := &models.User{ID: userID}
+			if tt.verified {
+				user.EmailVerifiedAt = &verifiedAt
+			}
+			mockService := new(MockUserService)
+			mockService.On("GetProfile", mock.Anything, userID).Return(user, nil).Maybe()
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			handler := CurrentUserMiddleware(mockService, logger)(RequireVerifiedEmail(tt.policy)(next))
+
+			req := withUserID(httptest.NewRequest(tt.method, tt.path, http.NoBody), userID)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/aleksandr/strive-api/internal/config"
+)
+
+// unverifiedWritablePaths may be changed before the email is verified, so the
+// user can still fix their profile.
+var unverifiedWritablePaths = map[string]bool{
+	"/api/v1/users/me": true,
+}
+
+// RequireVerifiedEmail enforces config.EmailVerificationPolicyLimit: unverified
+// users can read everything but cannot create, change or delete data. Other
+// policies pass every request through. It must run after CurrentUserMiddleware.
+func RequireVerifiedEmail(policy string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if policy != config.EmailVerificationPolicyLimit {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				next.ServeHTTP(w, r)
+				return
+			}
+			if unverifiedWritablePaths[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if user := currentUser(r); user != nil && !user.IsEmailVerified() {
+				writeError(w, http.StatusForbidden, "EMAIL_NOT_VERIFIED", "Verify your email address to continue")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
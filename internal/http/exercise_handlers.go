@@ -0,0 +1,305 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/aleksandr/strive-api/internal/validation"
+)
+
+const maxSearchQueryLength = 100
+
+type ExerciseHandlers struct {
+	exerciseService services.ExerciseService
+	logger          *logger.Logger
+}
+
+func NewExerciseHandlers(exerciseService services.ExerciseService, logger *logger.Logger) *ExerciseHandlers {
+	return &ExerciseHandlers{
+		exerciseService: exerciseService,
+		logger:          logger,
+	}
+}
+
+type ExerciseListResponse struct {
+	Exercises []*models.Exercise `json:"exercises"`
+	models.Pagination
+}
+
+type ExerciseFiltersResponse struct {
+	MuscleGroups     []string `json:"muscle_groups"`
+	Equipment        []string `json:"equipment"`
+	MovementPatterns []string `json:"movement_patterns"`
+}
+
+func validateMuscles(muscles []string, fieldName string, required bool) error {
+	if required && len(muscles) == 0 {
+		return fmt.Errorf("%s must contain at least one muscle group", fieldName)
+	}
+	for _, muscle := range muscles {
+		if err := validation.ValidateOneOf(muscle, fieldName, models.MuscleGroups...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateExerciseFields(req *models.UpdateExerciseRequest) validation.ValidationErrors {
+	var validationErrors validation.ValidationErrors
+	if req.Name != nil {
+		validationErrors.Add("name", validation.ValidateString(strings.TrimSpace(*req.Name), "name", 1, 255))
+	}
+	if req.PrimaryMuscles != nil {
+		validationErrors.Add("primary_muscles", validateMuscles(*req.PrimaryMuscles, "primary_muscles", true))
+	}
+	if req.SecondaryMuscles != nil {
+		validationErrors.Add("secondary_muscles", validateMuscles(*req.SecondaryMuscles, "secondary_muscles", false))
+	}
+	if req.Equipment != nil {
+		validationErrors.Add("equipment", validation.ValidateOneOf(*req.Equipment, "equipment", models.Equipment...))
+	}
+	if req.MovementPattern != nil {
+		validationErrors.Add("movement_pattern",
+			validation.ValidateOneOf(*req.MovementPattern, "movement_pattern", models.MovementPatterns...))
+	}
+	return validationErrors
+}
+
+// Search godoc
+// @Summary Search exercises
+// @Description Search the global catalog and the current user's custom exercises
+// @Tags exercises
+// @Produce json
+// @Security BearerAuth
+// @Param q query string false "Text search on the exercise name"
+// @Param muscle query string false "Primary or secondary muscle group"
+// @Param equipment query string false "Equipment"
+// @Param movement_pattern query string false "Movement pattern"
+// @Param custom query bool false "Only the current user's custom exercises"
+// @Param limit query int false "Page size (max 100)"
+// @Param offset query int false "Page offset"
+// @Success 200 {object} ExerciseListResponse "Exercises"
+// @Failure 400 {object} ErrorResponse "Invalid filter"
+// @Router /api/v1/exercises [get]
+func (h *ExerciseHandlers) Search(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	filter := models.ExerciseFilter{
+		Query:           strings.TrimSpace(query.Get("q")),
+		Muscle:          query.Get("muscle"),
+		Equipment:       query.Get("equipment"),
+		MovementPattern: query.Get("movement_pattern"),
+		CustomOnly:      query.Get("custom") == "true",
+		Pagination:      queryPagination(r),
+	}
+
+	var validationErrors validation.ValidationErrors
+	if len(filter.Query) > maxSearchQueryLength {
+		validationErrors.Add("q", fmt.Errorf("q too long (max %d characters)", maxSearchQueryLength))
+	}
+	if filter.Muscle != "" {
+		validationErrors.Add("muscle", validation.ValidateOneOf(filter.Muscle, "muscle", models.MuscleGroups...))
+	}
+	if filter.Equipment != "" {
+		validationErrors.Add("equipment", validation.ValidateOneOf(filter.Equipment, "equipment", models.Equipment...))
+	}
+	if filter.MovementPattern != "" {
+		validationErrors.Add("movement_pattern",
+			validation.ValidateOneOf(filter.MovementPattern, "movement_pattern", models.MovementPatterns...))
+	}
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	exercises, err := h.exerciseService.Search(r.Context(), userID, filter)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to search exercises")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ExerciseListResponse{
+		Exercises:  exercises,
+		Pagination: filter.Pagination,
+	})
+}
+
+// Filters godoc
+// @Summary Exercise filter values
+// @Description List the muscle groups, equipment and movement patterns accepted by the exercise search
+// @Tags exercises
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} ExerciseFiltersResponse "Filter values"
+// @Router /api/v1/exercises/filters [get]
+func (h *ExerciseHandlers) Filters(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, ExerciseFiltersResponse{
+		MuscleGroups:     models.MuscleGroups,
+		Equipment:        models.Equipment,
+		MovementPatterns: models.MovementPatterns,
+	})
+}
+
+// Get godoc
+// @Summary Get exercise
+// @Description Get a catalog exercise or one of the current user's custom exercises
+// @Tags exercises
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Exercise ID"
+// @Success 200 {object} models.Exercise "Exercise"
+// @Failure 404 {object} ErrorResponse "Exercise not found"
+// @Router /api/v1/exercises/{id} [get]
+func (h *ExerciseHandlers) Get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	exerciseID, ok := pathUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	exercise, err := h.exerciseService.Get(r.Context(), userID, exerciseID)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to get exercise")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, exercise)
+}
+
+// Create godoc
+// @Summary Create custom exercise
+// @Description Create a private exercise visible only to the current user
+// @Tags exercises
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateExerciseRequest true "Exercise data"
+// @Success 201 {object} models.Exercise "Exercise created"
+// @Failure 400 {object} ErrorResponse "Invalid request data"
+// @Failure 409 {object} ErrorResponse "Exercise name already used"
+// @Router /api/v1/exercises [post]
+func (h *ExerciseHandlers) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.CreateExerciseRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	validationErrors := validateExerciseFields(&models.UpdateExerciseRequest{
+		Name:             &req.Name,
+		PrimaryMuscles:   &req.PrimaryMuscles,
+		SecondaryMuscles: &req.SecondaryMuscles,
+		Equipment:        &req.Equipment,
+		MovementPattern:  &req.MovementPattern,
+	})
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	exercise, err := h.exerciseService.Create(r.Context(), userID, &req)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to create exercise")
+		return
+	}
+
+	h.logger.Info("Custom exercise created", "user_id", userID, "exercise_id", exercise.ID)
+
+	writeJSON(w, http.StatusCreated, exercise)
+}
+
+// Update godoc
+// @Summary Update custom exercise
+// @Description Update one of the current user's custom exercises
+// @Tags exercises
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Exercise ID"
+// @Param request body models.UpdateExerciseRequest true "Fields to update"
+// @Success 200 {object} models.Exercise "Exercise updated"
+// @Failure 400 {object} ErrorResponse "Invalid request data"
+// @Failure 403 {object} ErrorResponse "Catalog exercise"
+// @Failure 404 {object} ErrorResponse "Exercise not found"
+// @Failure 409 {object} ErrorResponse "Exercise name already used"
+// @Router /api/v1/exercises/{id} [put]
+func (h *ExerciseHandlers) Update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	exerciseID, ok := pathUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateExerciseRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.Name != nil {
+		trimmed := strings.TrimSpace(*req.Name)
+		req.Name = &trimmed
+	}
+	validationErrors := validateExerciseFields(&req)
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	exercise, err := h.exerciseService.Update(r.Context(), userID, exerciseID, &req)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to update exercise")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, exercise)
+}
+
+// Delete godoc
+// @Summary Delete custom exercise
+// @Description Delete one of the current user's custom exercises; logged entries keep their name
+// @Tags exercises
+// @Security BearerAuth
+// @Param id path string true "Exercise ID"
+// @Success 204 "Exercise deleted"
+// @Failure 403 {object} ErrorResponse "Catalog exercise"
+// @Failure 404 {object} ErrorResponse "Exercise not found"
+// @Router /api/v1/exercises/{id} [delete]
+func (h *ExerciseHandlers) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	exerciseID, ok := pathUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.exerciseService.Delete(r.Context(), userID, exerciseID); err != nil {
+		writeServiceError(w, h.logger, err, "Failed to delete exercise")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -1,4 +1,147 @@
 package http
 
-// This file is kept for future handlers
-// Health handlers are now in health_handlers.go
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/aleksandr/strive-api/internal/validation"
+	"github.com/google/uuid"
+)
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	errResp := ErrorResponse{}
+	errResp.Error.Code = code
+	errResp.Error.Message = message
+	writeJSON(w, status, errResp)
+}
+
+type serviceErrorMapping struct {
+	err     error
+	status  int
+	code    string
+	message string
+}
+
+var serviceErrorMappings = []serviceErrorMapping{
+	{services.ErrUserNotFound, http.StatusNotFound, "USER_NOT_FOUND", "User not found"},
+	{services.ErrInvalidCurrentPassword, http.StatusBadRequest, "INVALID_CURRENT_PASSWORD", "Current password is incorrect"},
+	{services.ErrInvalidResetToken, http.StatusBadRequest, "INVALID_RESET_TOKEN", "Password reset token is invalid or expired"},
+	{services.ErrPasswordUnchanged, http.StatusBadRequest, "PASSWORD_UNCHANGED", "New password must differ from the current password"},
+	{services.ErrInvalidVerificationToken, http.StatusBadRequest, "INVALID_VERIFICATION_TOKEN", "Email verification token is invalid or expired"},
+	{services.ErrInvalidEmailChangeToken, http.StatusBadRequest, "INVALID_EMAIL_CHANGE_TOKEN", "Email change token is invalid or expired"},
+	{services.ErrEmailUnchanged, http.StatusBadRequest, "EMAIL_UNCHANGED", "New email must differ from the current email"},
+	{services.ErrEmailTaken, http.StatusConflict, "EMAIL_TAKEN", "Email address is already in use"},
+	{services.ErrSessionNotFound, http.StatusNotFound, "SESSION_NOT_FOUND", "Session not found"},
+	{services.ErrInvalidMFACode, http.StatusBadRequest, "INVALID_MFA_CODE", "Invalid two-factor code"},
+	{services.ErrTOTPAlreadyEnabled, http.StatusConflict, "TOTP_ALREADY_ENABLED",
+		"Two-factor authentication is already enabled"},
+	{services.ErrTOTPNotEnrolled, http.StatusBadRequest, "TOTP_NOT_ENROLLED", "Set up two-factor authentication first"},
+	{services.ErrTOTPNotEnabled, http.StatusBadRequest, "TOTP_NOT_ENABLED", "Two-factor authentication is not enabled"},
+	{services.ErrEmailNotVerified, http.StatusForbidden, "EMAIL_NOT_VERIFIED", "Verify your email address to continue"},
+	{services.ErrAccountDisabled, http.StatusForbidden, "ACCOUNT_DISABLED", "This account has been disabled"},
+	{services.ErrAccountAlreadyDisabled, http.StatusConflict, "ACCOUNT_ALREADY_DISABLED", "Account is already disabled"},
+	{services.ErrAccountNotDisabled, http.StatusConflict, "ACCOUNT_NOT_DISABLED", "Account is not disabled"},
+	{services.ErrCannotModifySelf, http.StatusBadRequest, "CANNOT_MODIFY_SELF", "Admins cannot disable or delete their own account"},
+	{services.ErrWorkoutNotFound, http.StatusNotFound, "WORKOUT_NOT_FOUND", "Workout not found"},
+	{services.ErrInvalidWorkoutTimes, http.StatusBadRequest, "INVALID_WORKOUT_TIMES", "Workout cannot finish before it starts"},
+	{services.ErrWorkoutExerciseNotFound, http.StatusNotFound, "WORKOUT_EXERCISE_NOT_FOUND", "Workout exercise not found"},
+	{services.ErrExerciseSetNotFound, http.StatusNotFound, "EXERCISE_SET_NOT_FOUND", "Exercise set not found"},
+	{services.ErrExerciseNotFound, http.StatusNotFound, "EXERCISE_NOT_FOUND", "Exercise not found"},
+	{services.ErrExerciseNameTaken, http.StatusConflict, "EXERCISE_NAME_TAKEN", "Exercise with this name already exists"},
+	{services.ErrExerciseNotEditable, http.StatusForbidden, "EXERCISE_NOT_EDITABLE", "Catalog exercises cannot be modified"},
+	{services.ErrTemplateNotFound, http.StatusNotFound, "TEMPLATE_NOT_FOUND", "Template not found"},
+	{services.ErrTemplateInUse, http.StatusConflict, "TEMPLATE_IN_USE", "Template is used by a program"},
+	{services.ErrProgramNotFound, http.StatusNotFound, "PROGRAM_NOT_FOUND", "Program not found"},
+	{services.ErrProgramNotEditable, http.StatusForbidden, "PROGRAM_NOT_EDITABLE", "Only the program author can modify it"},
+	{services.ErrInvalidProgramSchedule, http.StatusBadRequest, "INVALID_PROGRAM_SCHEDULE",
+		"Program days must fall within the program's weeks and days per week, without duplicates"},
+	{services.ErrEnrollmentNotFound, http.StatusNotFound, "ENROLLMENT_NOT_FOUND", "No active program enrollment"},
+	{services.ErrAlreadyEnrolled, http.StatusConflict, "ALREADY_ENROLLED", "Already enrolled in a program"},
+	{services.ErrEnrollmentChanged, http.StatusConflict, "ENROLLMENT_CHANGED", "Enrollment changed, please retry"},
+	{services.ErrInvalidStatsRange, http.StatusBadRequest, "INVALID_STATS_RANGE",
+		"Stats range must start before it ends and cover at most 400 periods"},
+	{services.ErrInvalidTimezone, http.StatusBadRequest, "INVALID_TIMEZONE", "Unknown timezone"},
+	{services.ErrMeasurementNotFound, http.StatusNotFound, "MEASUREMENT_NOT_FOUND", "Measurement not found"},
+	{services.ErrEmptyMeasurement, http.StatusBadRequest, "EMPTY_MEASUREMENT",
+		"Measurement must include bodyweight, body fat or a circumference"},
+	{services.ErrInvalidMeasurementRange, http.StatusBadRequest, "INVALID_MEASUREMENT_RANGE",
+		"Measurement range must start before it ends and cover at most five years"},
+	{services.ErrUnknownMeasurementMetric, http.StatusBadRequest, "UNKNOWN_MEASUREMENT_METRIC",
+		"Metric must be bodyweight, body_fat or a circumference site"},
+}
+
+func writeServiceError(w http.ResponseWriter, log *logger.Logger, err error, msg string) {
+	for _, mapping := range serviceErrorMappings {
+		if errors.Is(err, mapping.err) {
+			writeError(w, mapping.status, mapping.code, mapping.message)
+			return
+		}
+	}
+
+	log.Error(msg, "error", err)
+	writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+}
+
+func writeValidationErrors(w http.ResponseWriter, validationErrors validation.ValidationErrors) {
+	writeJSON(w, http.StatusBadRequest, validationErrors.ToJSON())
+}
+
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid JSON")
+		return false
+	}
+	return true
+}
+
+func currentUserID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	rawUserID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "User ID not found in context")
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(rawUserID)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Invalid user ID in token")
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
+
+func pathUUID(w http.ResponseWriter, r *http.Request, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(r.PathValue(name))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid "+name)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func queryInt(r *http.Request, name string, defaultValue int) int {
+	if value := r.URL.Query().Get(name); value != "" {
+		if intValue, err := strconv.Atoi(value); err == nil {
+			return intValue
+		}
+	}
+	return defaultValue
+}
+
+func queryPagination(r *http.Request) models.Pagination {
+	return models.Pagination{
+		Limit:  queryInt(r, "limit", models.DefaultPageLimit),
+		Offset: queryInt(r, "offset", 0),
+	}.Normalize()
+}
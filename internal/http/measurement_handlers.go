@@ -0,0 +1,346 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/aleksandr/strive-api/internal/validation"
+)
+
+const (
+	maxBodyweight      = 1000
+	maxCircumference   = 500
+	maxTrendWindowDays = 90
+)
+
+type MeasurementHandlers struct {
+	measurementService services.MeasurementService
+	logger             *logger.Logger
+}
+
+func NewMeasurementHandlers(measurementService services.MeasurementService, logger *logger.Logger) *MeasurementHandlers {
+	return &MeasurementHandlers{
+		measurementService: measurementService,
+		logger:             logger,
+	}
+}
+
+type MeasurementListResponse struct {
+	Measurements []*models.Measurement `json:"measurements"`
+	models.Pagination
+}
+
+func (resp MeasurementListResponse) ConvertWeights(unit models.WeightUnit) {
+	for _, measurement := range resp.Measurements {
+		measurement.ConvertWeights(unit)
+	}
+}
+
+func validateLengthUnit(unit models.LengthUnit) error {
+	return validation.ValidateOneOf(string(unit), "length_unit", string(models.LengthUnitCm), string(models.LengthUnitIn))
+}
+
+func validateMeasurementFields(
+	bodyweight *float64,
+	weightUnit *models.WeightUnit,
+	bodyFatPercent *float64,
+	circumferences map[string]float64,
+	lengthUnit *models.LengthUnit,
+) validation.ValidationErrors {
+	var validationErrors validation.ValidationErrors
+	if bodyweight != nil {
+		validationErrors.Add("bodyweight", validation.ValidateFloatRange(*bodyweight, "bodyweight", 1, maxBodyweight))
+	}
+	if weightUnit != nil && *weightUnit != "" {
+		validationErrors.Add("weight_unit", validateWeightUnit(*weightUnit))
+	}
+	if bodyFatPercent != nil {
+		validationErrors.Add("body_fat_percent", validation.ValidateFloatRange(*bodyFatPercent, "body_fat_percent", 1, 75))
+	}
+	if lengthUnit != nil && *lengthUnit != "" {
+		validationErrors.Add("length_unit", validateLengthUnit(*lengthUnit))
+	}
+
+	sites := make([]string, 0, len(circumferences))
+	for site := range circumferences {
+		sites = append(sites, site)
+	}
+	sort.Strings(sites)
+	for _, site := range sites {
+		field := fmt.Sprintf("circumferences.%s", site)
+		if err := validation.ValidateOneOf(site, field, models.CircumferenceSites...); err != nil {
+			validationErrors.Add(field, err)
+			continue
+		}
+		validationErrors.Add(field, validation.ValidateFloatRange(circumferences[site], field, 1, maxCircumference))
+	}
+	return validationErrors
+}
+
+// queryTime reads an RFC 3339 timestamp or a YYYY-MM-DD date (midnight UTC).
+func queryTime(r *http.Request, name string, validationErrors *validation.ValidationErrors) *time.Time {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil
+	}
+	for _, layout := range []string{time.RFC3339, models.PeriodLayout} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return &parsed
+		}
+	}
+	*validationErrors = append(*validationErrors, validation.ValidationError{
+		Field:   name,
+		Message: name + " must be an RFC 3339 timestamp or a date in YYYY-MM-DD format",
+	})
+	return nil
+}
+
+// Create godoc
+// @Summary Record body measurement
+// @Description Record bodyweight, body-fat percentage and/or circumferences; at least one value is required
+// @Tags measurements
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateMeasurementRequest true "Measurement data"
+// @Success 201 {object} models.Measurement "Measurement recorded"
+// @Failure 400 {object} ErrorResponse "Invalid request data"
+// @Router /api/v1/measurements [post]
+func (h *MeasurementHandlers) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.CreateMeasurementRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	validationErrors := validateMeasurementFields(
+		req.Bodyweight, &req.WeightUnit, req.BodyFatPercent, req.Circumferences, &req.LengthUnit,
+	)
+	validationErrors.Add("notes", validateNotes(req.Notes))
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	measurement, err := h.measurementService.Create(r.Context(), userID, &req)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to create measurement")
+		return
+	}
+
+	h.logger.Info("Measurement recorded", "user_id", userID, "measurement_id", measurement.ID)
+
+	writeConvertedJSON(w, r, http.StatusCreated, measurement)
+}
+
+// List godoc
+// @Summary List body measurements
+// @Description List the current user's measurements, newest first, optionally within a time range
+// @Tags measurements
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Earliest measured_at, inclusive (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "Latest measured_at, exclusive (RFC 3339 or YYYY-MM-DD)"
+// @Param limit query int false "Page size (max 100)"
+// @Param offset query int false "Page offset"
+// @Success 200 {object} MeasurementListResponse "Measurements"
+// @Failure 400 {object} ErrorResponse "Invalid parameters"
+// @Router /api/v1/measurements [get]
+func (h *MeasurementHandlers) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var validationErrors validation.ValidationErrors
+	filter := models.MeasurementFilter{
+		From:       queryTime(r, "from", &validationErrors),
+		To:         queryTime(r, "to", &validationErrors),
+		Pagination: queryPagination(r),
+	}
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	measurements, err := h.measurementService.List(r.Context(), userID, filter)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to list measurements")
+		return
+	}
+
+	writeConvertedJSON(w, r, http.StatusOK, MeasurementListResponse{
+		Measurements: measurements,
+		Pagination:   filter.Pagination,
+	})
+}
+
+// Get godoc
+// @Summary Get body measurement
+// @Description Get a single measurement owned by the current user
+// @Tags measurements
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Measurement ID"
+// @Success 200 {object} models.Measurement "Measurement"
+// @Failure 404 {object} ErrorResponse "Measurement not found"
+// @Router /api/v1/measurements/{id} [get]
+func (h *MeasurementHandlers) Get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	measurementID, ok := pathUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	measurement, err := h.measurementService.Get(r.Context(), userID, measurementID)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to get measurement")
+		return
+	}
+
+	writeConvertedJSON(w, r, http.StatusOK, measurement)
+}
+
+// Update godoc
+// @Summary Update body measurement
+// @Description Update a measurement; when circumferences is present it replaces all sites
+// @Tags measurements
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Measurement ID"
+// @Param request body models.UpdateMeasurementRequest true "Fields to update"
+// @Success 200 {object} models.Measurement "Measurement updated"
+// @Failure 400 {object} ErrorResponse "Invalid request data"
+// @Failure 404 {object} ErrorResponse "Measurement not found"
+// @Router /api/v1/measurements/{id} [put]
+func (h *MeasurementHandlers) Update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	measurementID, ok := pathUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateMeasurementRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var circumferences map[string]float64
+	if req.Circumferences != nil {
+		circumferences = *req.Circumferences
+	}
+	validationErrors := validateMeasurementFields(
+		req.Bodyweight, req.WeightUnit, req.BodyFatPercent, circumferences, req.LengthUnit,
+	)
+	if req.Notes != nil {
+		validationErrors.Add("notes", validateNotes(*req.Notes))
+	}
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	measurement, err := h.measurementService.Update(r.Context(), userID, measurementID, &req)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to update measurement")
+		return
+	}
+
+	writeConvertedJSON(w, r, http.StatusOK, measurement)
+}
+
+// Delete godoc
+// @Summary Delete body measurement
+// @Description Delete a measurement owned by the current user
+// @Tags measurements
+// @Security BearerAuth
+// @Param id path string true "Measurement ID"
+// @Success 204 "Measurement deleted"
+// @Failure 404 {object} ErrorResponse "Measurement not found"
+// @Router /api/v1/measurements/{id} [delete]
+func (h *MeasurementHandlers) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	measurementID, ok := pathUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.measurementService.Delete(r.Context(), userID, measurementID); err != nil {
+		writeServiceError(w, h.logger, err, "Failed to delete measurement")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Trend godoc
+// @Summary Measurement trend
+// @Description One metric over time with a trailing moving average. Weights use the preferred unit and circumferences cm.
+// @Tags measurements
+// @Produce json
+// @Security BearerAuth
+// @Param metric query string false "bodyweight, body_fat or a circumference site" default(bodyweight)
+// @Param from query string false "Start (RFC 3339 or YYYY-MM-DD), defaults to 90 days before to"
+// @Param to query string false "End (RFC 3339 or YYYY-MM-DD), defaults to now"
+// @Param window query int false "Moving average window in days (max 90)" default(7)
+// @Success 200 {object} models.MeasurementTrend "Trend"
+// @Failure 400 {object} ErrorResponse "Invalid parameters"
+// @Router /api/v1/measurements/trend [get]
+func (h *MeasurementHandlers) Trend(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	metric := r.URL.Query().Get("metric")
+	if metric == "" {
+		metric = models.MeasurementMetricBodyweight
+	}
+	window := queryInt(r, "window", 0)
+
+	var validationErrors validation.ValidationErrors
+	var from, to time.Time
+	if parsed := queryTime(r, "from", &validationErrors); parsed != nil {
+		from = *parsed
+	}
+	if parsed := queryTime(r, "to", &validationErrors); parsed != nil {
+		to = *parsed
+	}
+	if window != 0 {
+		validationErrors.Add("window", validation.ValidateIntRange(window, "window", 1, maxTrendWindowDays))
+	}
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	trend, err := h.measurementService.Trend(r.Context(), userID, metric, from, to, window)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to compute measurement trend")
+		return
+	}
+
+	writeConvertedJSON(w, r, http.StatusOK, trend)
+}
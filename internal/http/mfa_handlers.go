@@ -0,0 +1,151 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/aleksandr/strive-api/internal/validation"
+)
+
+type MFAHandlers struct {
+	mfaService     services.MFAService
+	logger         *logger.Logger
+	securityLogger *SecurityLogger
+}
+
+func NewMFAHandlers(mfaService services.MFAService, logger *logger.Logger) *MFAHandlers {
+	return &MFAHandlers{
+		mfaService:     mfaService,
+		logger:         logger,
+		securityLogger: NewSecurityLogger(logger),
+	}
+}
+
+// EnrollTOTP godoc
+// @Summary Set up two-factor authentication
+// @Description Create an authenticator app secret. Two-factor authentication stays off until the secret is confirmed
+// @Description with a first code; setting up again replaces an unconfirmed secret.
+// @Tags authentication
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.TOTPEnrollment "Secret and otpauth URI for the authenticator app"
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Failure 409 {object} ErrorResponse "Two-factor authentication is already enabled"
+// @Router /api/v1/auth/2fa/totp [post]
+func (h *MFAHandlers) EnrollTOTP(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	enrollment, err := h.mfaService.EnrollTOTP(r.Context(), userID)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to enroll TOTP")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, enrollment)
+}
+
+// ConfirmTOTP godoc
+// @Summary Enable two-factor authentication
+// @Description Turn two-factor authentication on with a first code from the authenticator app. The response holds
+// @Description single-use recovery codes, which are not shown again.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.TOTPCodeRequest true "Code from the authenticator app"
+// @Success 200 {object} models.RecoveryCodesResponse "Two-factor authentication enabled"
+// @Failure 400 {object} ErrorResponse "Invalid request data, invalid code or not set up"
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Failure 409 {object} ErrorResponse "Two-factor authentication is already enabled"
+// @Router /api/v1/auth/2fa/totp/confirm [post]
+func (h *MFAHandlers) ConfirmTOTP(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	req, ok := decodeCodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	codes, err := h.mfaService.ConfirmTOTP(r.Context(), userID, req.Code)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to confirm TOTP")
+		return
+	}
+
+	h.logger.Info("User enabled two-factor authentication", "user_id", userID)
+
+	writeJSON(w, http.StatusOK, models.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// DisableTOTP godoc
+// @Summary Disable two-factor authentication
+// @Description Turn two-factor authentication off with a current code or an unused recovery code
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.TOTPCodeRequest true "TOTP code or recovery code"
+// @Success 200 {object} map[string]interface{} "Two-factor authentication disabled"
+// @Failure 400 {object} ErrorResponse "Invalid request data, invalid code or not enabled"
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Failure 429 {object} ErrorResponse "Too many failed attempts, retry after the Retry-After header"
+// @Router /api/v1/auth/2fa/totp/disable [post]
+func (h *MFAHandlers) DisableTOTP(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	req, ok := decodeCodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.mfaService.DisableTOTP(r.Context(), userID, req.Code)
+	if errors.Is(err, services.ErrInvalidMFACode) {
+		h.securityLogger.LogFailedAuth(r, "invalid_mfa_code")
+	}
+	if writeThrottled(w, r, h.securityLogger, err) {
+		return
+	}
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to disable TOTP")
+		return
+	}
+
+	h.logger.Info("User disabled two-factor authentication", "user_id", userID)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Two-factor authentication disabled",
+	})
+}
+
+func decodeCodeRequest(w http.ResponseWriter, r *http.Request) (*models.TOTPCodeRequest, bool) {
+	var req models.TOTPCodeRequest
+	if !decodeJSON(w, r, &req) {
+		return nil, false
+	}
+
+	var validationErrors validation.ValidationErrors
+	if req.Code == "" {
+		validationErrors = append(validationErrors, validation.ValidationError{
+			Field:   "code",
+			Message: "code is required",
+		})
+	}
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return nil, false
+	}
+
+	return &req, true
+}
@@ -0,0 +1,139 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMFAHandlers_EnrollTOTP(t *testing.T) {
+	logger := logger.New("INFO", "json")
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{"enrolled", nil, http.StatusOK},
+		{"already enabled", services.ErrTOTPAlreadyEnabled, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockMFAService)
+			var enrollment *models.TOTPEnrollment
+			if tt.serviceErr == nil {
+				enrollment = &models.TOTPEnrollment{Secret: "JBSWY3DPEHPK3PXP", URI: "otpauth://totp/Strive:user@example.com"}
+			}
+			mockService.On("EnrollTOTP", mock.Anything, userID).Return(enrollment, tt.serviceErr)
+			handlers := NewMFAHandlers(mockService, logger)
+
+			req := withUserID(httptest.NewRequest(http.MethodPost, "/2fa/totp", http.NoBody), userID)
+			rr := httptest.NewRecorder()
+			handlers.EnrollTOTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if enrollment != nil {
+				var response models.TOTPEnrollment
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+				assert.Equal(t, *enrollment, response)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMFAHandlers_ConfirmTOTP(t *testing.T) {
+	logger := logger.New("INFO", "json")
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockSetup      func(*MockMFAService)
+		expectedStatus int
+	}{
+		{
+			name:        "confirmed",
+			requestBody: models.TOTPCodeRequest{Code: "123456"},
+			mockSetup: func(m *MockMFAService) {
+				m.On("ConfirmTOTP", mock.Anything, userID, "123456").Return([]string{"k3m9q-x7t2p"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "invalid code",
+			requestBody: models.TOTPCodeRequest{Code: "000000"},
+			mockSetup: func(m *MockMFAService) {
+				m.On("ConfirmTOTP", mock.Anything, userID, "000000").Return(nil, services.ErrInvalidMFACode)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing code",
+			requestBody:    map[string]string{},
+			mockSetup:      func(m *MockMFAService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockMFAService)
+			tt.mockSetup(mockService)
+			handlers := NewMFAHandlers(mockService, logger)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := withUserID(httptest.NewRequest(http.MethodPost, "/2fa/totp/confirm", bytes.NewReader(body)), userID)
+			rr := httptest.NewRecorder()
+			handlers.ConfirmTOTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMFAHandlers_DisableTOTP(t *testing.T) {
+	logger := logger.New("INFO", "json")
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{"disabled", nil, http.StatusOK},
+		{"invalid code", services.ErrInvalidMFACode, http.StatusBadRequest},
+		{"not enabled", services.ErrTOTPNotEnabled, http.StatusBadRequest},
+		{"throttled", &services.LoginThrottledError{RetryAfter: time.Minute}, http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockMFAService)
+			mockService.On("DisableTOTP", mock.Anything, userID, "k3m9q-x7t2p").Return(tt.serviceErr)
+			handlers := NewMFAHandlers(mockService, logger)
+
+			body, _ := json.Marshal(models.TOTPCodeRequest{Code: "k3m9q-x7t2p"})
+			req := withUserID(httptest.NewRequest(http.MethodPost, "/2fa/totp/disable", bytes.NewReader(body)), userID)
+			rr := httptest.NewRecorder()
+			handlers.DisableTOTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -4,9 +4,13 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
 	"github.com/google/uuid"
 )
 
@@ -14,6 +18,10 @@ type requestIDKeyType string
 
 const requestIDKey requestIDKeyType = "request_id"
 
+// maxAuditFieldLength bounds the client-supplied request ID stored with audit
+// events.
+const maxAuditFieldLength = 64
+
 type loggingResponseWriter struct {
 	http.ResponseWriter
 	statusCode int
@@ -31,7 +39,11 @@ func (lrw *loggingResponseWriter) Write(b []byte) (int, error) {
 func LoggingMiddleware(log *logger.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			requestID := r.Header.Get("X-Request-ID")
+			// Behind RequestIDMiddleware the log lines carry the same ID as the response.
+			requestID := requestIDFromContext(r.Context())
+			if requestID == "" {
+				requestID = r.Header.Get("X-Request-ID")
+			}
 			if requestID == "" {
 				requestID = uuid.New().String()
 			}
@@ -70,3 +82,39 @@ func RequestIDMiddleware() func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// requestIDFromContext returns the ID RequestIDMiddleware assigned to the request.
+func requestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+// AuditRequestMiddleware stores where the request came from, so audit events
+// recorded while handling it say so. It runs inside RequestIDMiddleware.
+func AuditRequestMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := services.WithAuditRequest(r.Context(), requestAuditRequest(r))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func requestAuditRequest(r *http.Request) models.AuditRequest {
+	client := requestClientInfo(r, "")
+	return models.AuditRequest{
+		IPAddress: client.IPAddress,
+		UserAgent: client.UserAgent,
+		RequestID: truncate(requestIDFromContext(r.Context()), maxAuditFieldLength),
+	}
+}
+
+// truncate drops invalid UTF-8 from value and cuts it to at most maxLength
+// characters, the unit of the VARCHAR columns it is stored in.
+func truncate(value string, maxLength int) string {
+	value = strings.ToValidUTF8(value, "")
+	if utf8.RuneCountInString(value) <= maxLength {
+		return value
+	}
+	return string([]rune(value)[:maxLength])
+}
@@ -3,9 +3,13 @@ package http
 import (
 	"net/http"
 	"net/http/httptest"
+	"net/netip"
+	"strings"
 	"testing"
 
 	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -120,3 +124,26 @@ func TestMiddlewareContext(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, rr.Code)
 }
+
+func TestAuditRequestMiddleware(t *testing.T) {
+	var request models.AuditRequest
+	handler := ClientIPMiddleware([]netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")})(RequestIDMiddleware()(AuditRequestMiddleware()(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			request = services.AuditRequestFromContext(r.Context())
+		}),
+	)))
+
+	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
+	req.RemoteAddr = "10.0.0.2:41234"
+	req.Header.Set("X-Request-ID", "req-1")
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+	req.Header.Set("User-Agent", "curl/8.0")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, models.AuditRequest{IPAddress: "203.0.113.7", UserAgent: "curl/8.0", RequestID: "req-1"}, request)
+
+	req.Header.Set("X-Request-ID", strings.Repeat("a", 100))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Len(t, request.RequestID, maxAuditFieldLength, "oversized client values are cut to fit the audit log")
+}
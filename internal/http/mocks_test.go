@@ -2,9 +2,11 @@ package http
 
 import (
 	"context"
+	"time"
 
 	"github.com/aleksandr/strive-api/internal/models"
 	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -20,8 +22,17 @@ func (m *MockAuthService) Register(ctx context.Context, req *models.CreateUserRe
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
-func (m *MockAuthService) Login(ctx context.Context, email, password string) (string, string, error) {
-	args := m.Called(ctx, email, password)
+func (m *MockAuthService) Login(ctx context.Context, email, password string, client models.ClientInfo) (string, string, error) {
+	args := m.Called(ctx, email, password, client)
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+func (m *MockAuthService) CompleteMFALogin(
+	ctx context.Context,
+	challengeToken, code string,
+	client models.ClientInfo,
+) (string, string, error) {
+	args := m.Called(ctx, challengeToken, code, client)
 	return args.String(0), args.String(1), args.Error(2)
 }
 
@@ -43,8 +54,8 @@ func (m *MockAuthService) VerifyPassword(hashedPassword, password string) error
 	return args.Error(0)
 }
 
-func (m *MockAuthService) RefreshToken(ctx context.Context, refreshToken string) (string, string, error) {
-	args := m.Called(ctx, refreshToken)
+func (m *MockAuthService) RefreshToken(ctx context.Context, refreshToken string, client models.ClientInfo) (string, string, error) {
+	args := m.Called(ctx, refreshToken, client)
 	return args.String(0), args.String(1), args.Error(2)
 }
 
@@ -52,3 +63,287 @@ func (m *MockAuthService) Logout(ctx context.Context, refreshToken string) error
 	args := m.Called(ctx, refreshToken)
 	return args.Error(0)
 }
+
+func (m *MockAuthService) ChangePassword(
+	ctx context.Context,
+	userID uuid.UUID,
+	currentRefreshToken string,
+	req *models.ChangePasswordRequest,
+) error {
+	args := m.Called(ctx, userID, currentRefreshToken, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) IssueAccessToken(user *models.User) (string, error) {
+	args := m.Called(user)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockAuthService) JWKS() *models.JWKS {
+	args := m.Called()
+	return args.Get(0).(*models.JWKS)
+}
+
+type MockEmailChangeService struct {
+	mock.Mock
+}
+
+func (m *MockEmailChangeService) RequestChange(ctx context.Context, userID uuid.UUID, req *models.ChangeEmailRequest) error {
+	args := m.Called(ctx, userID, req)
+	return args.Error(0)
+}
+
+func (m *MockEmailChangeService) ConfirmChange(ctx context.Context, userID uuid.UUID, token string) (*models.User, error) {
+	args := m.Called(ctx, userID, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+type MockPasswordResetService struct {
+	mock.Mock
+}
+
+func (m *MockPasswordResetService) RequestReset(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+func (m *MockPasswordResetService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	args := m.Called(ctx, token, newPassword)
+	return args.Error(0)
+}
+
+type MockEmailVerificationService struct {
+	mock.Mock
+}
+
+func (m *MockEmailVerificationService) SendVerification(ctx context.Context, user *models.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockEmailVerificationService) Resend(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+func (m *MockEmailVerificationService) Verify(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+type MockSessionService struct {
+	mock.Mock
+}
+
+func (m *MockSessionService) List(ctx context.Context, userID uuid.UUID, currentRefreshToken string) ([]*models.Session, error) {
+	args := m.Called(ctx, userID, currentRefreshToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Session), args.Error(1)
+}
+
+func (m *MockSessionService) Revoke(ctx context.Context, userID, sessionID uuid.UUID) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockSessionService) RevokeOthers(ctx context.Context, userID uuid.UUID, currentRefreshToken string) error {
+	args := m.Called(ctx, userID, currentRefreshToken)
+	return args.Error(0)
+}
+
+type MockUserService struct {
+	mock.Mock
+}
+
+func (m *MockUserService) GetProfile(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserService) UpdateProfile(ctx context.Context, userID uuid.UUID, req *models.UpdateProfileRequest) (*models.User, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+type MockWorkoutService struct {
+	mock.Mock
+}
+
+func (m *MockWorkoutService) Create(ctx context.Context, userID uuid.UUID, req *models.CreateWorkoutRequest) (*models.Workout, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Workout), args.Error(1)
+}
+
+func (m *MockWorkoutService) Get(ctx context.Context, userID, workoutID uuid.UUID) (*models.Workout, error) {
+	args := m.Called(ctx, userID, workoutID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Workout), args.Error(1)
+}
+
+func (m *MockWorkoutService) List(ctx context.Context, userID uuid.UUID, page models.Pagination) ([]*models.Workout, error) {
+	args := m.Called(ctx, userID, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Workout), args.Error(1)
+}
+
+func (m *MockWorkoutService) Update(
+	ctx context.Context,
+	userID, workoutID uuid.UUID,
+	req *models.UpdateWorkoutRequest,
+) (*models.Workout, error) {
+	args := m.Called(ctx, userID, workoutID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Workout), args.Error(1)
+}
+
+func (m *MockWorkoutService) Delete(ctx context.Context, userID, workoutID uuid.UUID) error {
+	args := m.Called(ctx, userID, workoutID)
+	return args.Error(0)
+}
+
+type MockTokenRevocationService struct {
+	mock.Mock
+}
+
+func (m *MockTokenRevocationService) Revoke(ctx context.Context, claims *services.Claims) error {
+	args := m.Called(ctx, claims)
+	return args.Error(0)
+}
+
+func (m *MockTokenRevocationService) RevokeUser(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, userID, at)
+	return args.Error(0)
+}
+
+func (m *MockTokenRevocationService) Check(ctx context.Context, claims *services.Claims) error {
+	args := m.Called(ctx, claims)
+	return args.Error(0)
+}
+
+func (m *MockTokenRevocationService) PruneExpired(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type MockMFAService struct {
+	mock.Mock
+}
+
+func (m *MockMFAService) EnrollTOTP(ctx context.Context, userID uuid.UUID) (*models.TOTPEnrollment, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TOTPEnrollment), args.Error(1)
+}
+
+func (m *MockMFAService) ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	args := m.Called(ctx, userID, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockMFAService) DisableTOTP(ctx context.Context, userID uuid.UUID, code string) error {
+	args := m.Called(ctx, userID, code)
+	return args.Error(0)
+}
+
+type MockAdminService struct {
+	mock.Mock
+}
+
+func (m *MockAdminService) ListUsers(ctx context.Context, actor models.AuditActor, filter models.UserFilter) ([]*models.User, error) {
+	args := m.Called(ctx, actor, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.User), args.Error(1)
+}
+
+func (m *MockAdminService) GetUser(ctx context.Context, actor models.AuditActor, userID uuid.UUID) (*models.User, error) {
+	args := m.Called(ctx, actor, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockAdminService) ListSessions(ctx context.Context, actor models.AuditActor, userID uuid.UUID) ([]*models.Session, error) {
+	args := m.Called(ctx, actor, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Session), args.Error(1)
+}
+
+func (m *MockAdminService) ForceLogout(ctx context.Context, actor models.AuditActor, userID uuid.UUID) error {
+	args := m.Called(ctx, actor, userID)
+	return args.Error(0)
+}
+
+func (m *MockAdminService) DisableUser(ctx context.Context, actor models.AuditActor, userID uuid.UUID) error {
+	args := m.Called(ctx, actor, userID)
+	return args.Error(0)
+}
+
+func (m *MockAdminService) EnableUser(ctx context.Context, actor models.AuditActor, userID uuid.UUID) error {
+	args := m.Called(ctx, actor, userID)
+	return args.Error(0)
+}
+
+func (m *MockAdminService) SendPasswordReset(ctx context.Context, actor models.AuditActor, userID uuid.UUID) error {
+	args := m.Called(ctx, actor, userID)
+	return args.Error(0)
+}
+
+func (m *MockAdminService) DeleteUser(ctx context.Context, actor models.AuditActor, userID uuid.UUID) error {
+	args := m.Called(ctx, actor, userID)
+	return args.Error(0)
+}
+
+type MockAuditService struct {
+	mock.Mock
+}
+
+func (m *MockAuditService) Record(ctx context.Context, event *models.AuditEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockAuditService) ListForUser(ctx context.Context, userID uuid.UUID, page models.Pagination) ([]*models.AuditEvent, error) {
+	args := m.Called(ctx, userID, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.AuditEvent), args.Error(1)
+}
+
+func (m *MockAuditService) Search(ctx context.Context, actor models.AuditActor, filter models.AuditFilter) ([]*models.AuditEvent, error) {
+	args := m.Called(ctx, actor, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.AuditEvent), args.Error(1)
+}
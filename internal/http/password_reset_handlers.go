@@ -0,0 +1,104 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/aleksandr/strive-api/internal/validation"
+)
+
+type PasswordResetHandlers struct {
+	resetService   services.PasswordResetService
+	logger         *logger.Logger
+	securityLogger *SecurityLogger
+}
+
+func NewPasswordResetHandlers(resetService services.PasswordResetService, logger *logger.Logger) *PasswordResetHandlers {
+	return &PasswordResetHandlers{
+		resetService:   resetService,
+		logger:         logger,
+		securityLogger: NewSecurityLogger(logger),
+	}
+}
+
+// ForgotPassword godoc
+// @Summary Request password reset
+// @Description Email a single-use password reset link. The response is the same whether or not the address
+// @Description belongs to an account.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Param request body models.ForgotPasswordRequest true "Account email"
+// @Success 202 {object} map[string]interface{} "Reset email sent if the account exists"
+// @Failure 400 {object} ErrorResponse "Invalid request data"
+// @Router /api/v1/auth/forgot-password [post]
+func (h *PasswordResetHandlers) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req models.ForgotPasswordRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var validationErrors validation.ValidationErrors
+	validationErrors.Add("email", validation.ValidateEmail(req.Email))
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	// Failures are logged but never surfaced, so the response cannot reveal whether the account exists.
+	if err := h.resetService.RequestReset(r.Context(), req.Email); err != nil {
+		h.logger.Error("Failed to request password reset", "error", err)
+	}
+
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{
+		"message": "If an account exists for this email, a password reset link has been sent",
+	})
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Set a new password using the token from a reset email. All sessions are signed out.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Param request body models.ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} map[string]interface{} "Password reset"
+// @Failure 400 {object} ErrorResponse "Invalid request data or invalid/expired token"
+// @Router /api/v1/auth/reset-password [post]
+func (h *PasswordResetHandlers) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req models.ResetPasswordRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var validationErrors validation.ValidationErrors
+	if req.Token == "" {
+		validationErrors = append(validationErrors, validation.ValidationError{
+			Field:   "token",
+			Message: "token is required",
+		})
+	}
+	validationErrors.Add("new_password", validation.ValidatePassword(req.NewPassword))
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	err := h.resetService.ResetPassword(r.Context(), req.Token, req.NewPassword)
+	if errors.Is(err, services.ErrInvalidResetToken) {
+		h.securityLogger.LogFailedAuth(r, "invalid_password_reset_token")
+	}
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to reset password")
+		return
+	}
+
+	h.logger.Info("Password reset completed")
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Password has been reset",
+	})
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPasswordResetHandlers_ForgotPassword(t *testing.T) {
+	logger := logger.New("INFO", "json")
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockSetup      func(*MockPasswordResetService)
+		expectedStatus int
+	}{
+		{
+			name:        "accepted",
+			requestBody: map[string]string{"email": "user@example.com"},
+			mockSetup: func(m *MockPasswordResetService) {
+				m.On("RequestReset", mock.Anything, "user@example.com").Return(nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:        "delivery failure is not revealed",
+			requestBody: map[string]string{"email": "user@example.com"},
+			mockSetup: func(m *MockPasswordResetService) {
+				m.On("RequestReset", mock.Anything, "user@example.com").Return(errors.New("smtp down"))
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "invalid email",
+			requestBody:    map[string]string{"email": "not-an-email"},
+			mockSetup:      func(m *MockPasswordResetService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPasswordResetService)
+			tt.mockSetup(mockService)
+			handlers := NewPasswordResetHandlers(mockService, logger)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/forgot-password", bytes.NewReader(body))
+			rr := httptest.NewRecorder()
+			handlers.ForgotPassword(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPasswordResetHandlers_ResetPassword(t *testing.T) {
+	logger := logger.New("INFO", "json")
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockSetup      func(*MockPasswordResetService)
+		expectedStatus int
+	}{
+		{
+			name:        "successful reset",
+			requestBody: map[string]string{"token": "reset-token", "new_password": "NewPassword2@"},
+			mockSetup: func(m *MockPasswordResetService) {
+				m.On("ResetPassword", mock.Anything, "reset-token", "NewPassword2@").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing token and weak password",
+			requestBody:    map[string]string{"new_password": "weak"},
+			mockSetup:      func(m *MockPasswordResetService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "expired token",
+			requestBody: map[string]string{"token": "stale-token", "new_password": "NewPassword2@"},
+			mockSetup: func(m *MockPasswordResetService) {
+				m.On("ResetPassword", mock.Anything, "stale-token", "NewPassword2@").Return(services.ErrInvalidResetToken)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPasswordResetService)
+			tt.mockSetup(mockService)
+			handlers := NewPasswordResetHandlers(mockService, logger)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/reset-password", bytes.NewReader(body))
+			rr := httptest.NewRecorder()
+			handlers.ResetPassword(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
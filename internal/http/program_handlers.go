@@ -0,0 +1,444 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/aleksandr/strive-api/internal/validation"
+)
+
+const (
+	maxProgramWeeks      = 52
+	maxDaysPerWeek       = 7
+	maxIntensityPercent  = 120
+	maxLoadRounding      = 50
+	maxDescriptionLength = 2000
+)
+
+type ProgramHandlers struct {
+	programService services.ProgramService
+	logger         *logger.Logger
+}
+
+func NewProgramHandlers(programService services.ProgramService, logger *logger.Logger) *ProgramHandlers {
+	return &ProgramHandlers{
+		programService: programService,
+		logger:         logger,
+	}
+}
+
+type ProgramListResponse struct {
+	Programs []*models.Program `json:"programs"`
+	models.Pagination
+}
+
+func validateProgramFields(req *models.UpdateProgramRequest) validation.ValidationErrors {
+	var validationErrors validation.ValidationErrors
+	if req.Name != nil {
+		validationErrors.Add("name", validation.ValidateString(*req.Name, "name", 1, 255))
+	}
+	if req.Description != nil && len(*req.Description) > maxDescriptionLength {
+		validationErrors.Add("description", fmt.Errorf("description too long (max %d characters)", maxDescriptionLength))
+	}
+	if req.Weeks != nil {
+		validationErrors.Add("weeks", validation.ValidateIntRange(*req.Weeks, "weeks", 1, maxProgramWeeks))
+	}
+	if req.DaysPerWeek != nil {
+		validationErrors.Add("days_per_week",
+			validation.ValidateIntRange(*req.DaysPerWeek, "days_per_week", 1, maxDaysPerWeek))
+	}
+	if req.Progression != nil {
+		validationErrors.Add("progression",
+			validation.ValidateOneOf(string(*req.Progression), "progression", models.ProgressionTypes...))
+	}
+	if req.Increment != nil {
+		validationErrors.Add("increment", validation.ValidateFloatRange(*req.Increment, "increment", 0, maxWeight))
+	}
+	if req.IncrementUnit != nil && *req.IncrementUnit != "" {
+		validationErrors.Add("increment_unit", validation.ValidateOneOf(
+			string(*req.IncrementUnit), "increment_unit", string(models.WeightUnitKg), string(models.WeightUnitLb),
+		))
+	}
+	if req.DeloadWeeks != nil {
+		for _, week := range *req.DeloadWeeks {
+			validationErrors.Add("deload_weeks", validation.ValidateIntRange(week, "deload_weeks", 1, maxProgramWeeks))
+		}
+	}
+	if req.DeloadPercent != nil {
+		validationErrors.Add("deload_percent",
+			validation.ValidateFloatRange(*req.DeloadPercent, "deload_percent", 1, 100))
+	}
+	if req.LoadRounding != nil {
+		validationErrors.Add("load_rounding",
+			validation.ValidateFloatRange(*req.LoadRounding, "load_rounding", 0, maxLoadRounding))
+	}
+	if req.Days != nil {
+		days := *req.Days
+		if len(days) == 0 || len(days) > maxProgramWeeks*maxDaysPerWeek {
+			validationErrors.Add("days",
+				fmt.Errorf("days must contain between 1 and %d entries", maxProgramWeeks*maxDaysPerWeek))
+		}
+		for i, day := range days {
+			field := func(name string) string { return fmt.Sprintf("days[%d].%s", i, name) }
+			validationErrors.Add(field("week"), validation.ValidateIntRange(day.Week, field("week"), 1, maxProgramWeeks))
+			validationErrors.Add(field("day"), validation.ValidateIntRange(day.Day, field("day"), 1, maxDaysPerWeek))
+			if day.IntensityPercent != nil {
+				validationErrors.Add(field("intensity_percent"),
+					validation.ValidateFloatRange(*day.IntensityPercent, field("intensity_percent"), 1, maxIntensityPercent))
+			}
+		}
+	}
+	return validationErrors
+}
+
+// Create godoc
+// @Summary Create training program
+// @Description Create a multi-week program whose days reference the author's templates.
+// @Description Target loads progress by the chosen rule: none, fixed_increment (per week) or percent_of_max.
+// @Tags programs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateProgramRequest true "Program data"
+// @Success 201 {object} models.Program "Program created"
+// @Failure 400 {object} ErrorResponse "Invalid request data or schedule"
+// @Failure 404 {object} ErrorResponse "Template not found"
+// @Router /api/v1/programs [post]
+func (h *ProgramHandlers) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.CreateProgramRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	validationErrors := validateProgramFields(&models.UpdateProgramRequest{
+		Name:          &req.Name,
+		Description:   &req.Description,
+		Weeks:         &req.Weeks,
+		DaysPerWeek:   &req.DaysPerWeek,
+		Increment:     &req.Increment,
+		IncrementUnit: &req.IncrementUnit,
+		DeloadWeeks:   &req.DeloadWeeks,
+		DeloadPercent: req.DeloadPercent,
+		LoadRounding:  req.LoadRounding,
+		Days:          &req.Days,
+	})
+	if req.Progression != "" {
+		validationErrors.Add("progression",
+			validation.ValidateOneOf(string(req.Progression), "progression", models.ProgressionTypes...))
+	}
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	program, err := h.programService.Create(r.Context(), userID, &req)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to create program")
+		return
+	}
+
+	h.logger.Info("Program created", "user_id", userID, "program_id", program.ID)
+
+	writeJSON(w, http.StatusCreated, program)
+}
+
+// List godoc
+// @Summary List training programs
+// @Description List the current user's programs followed by public programs from other users
+// @Tags programs
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Page size (max 100)"
+// @Param offset query int false "Page offset"
+// @Success 200 {object} ProgramListResponse "Programs"
+// @Router /api/v1/programs [get]
+func (h *ProgramHandlers) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	page := queryPagination(r)
+
+	programs, err := h.programService.List(r.Context(), userID, page)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to list programs")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ProgramListResponse{
+		Programs:   programs,
+		Pagination: page,
+	})
+}
+
+// Get godoc
+// @Summary Get training program
+// @Description Get one of the current user's programs or a public program
+// @Tags programs
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Program ID"
+// @Success 200 {object} models.Program "Program"
+// @Failure 404 {object} ErrorResponse "Program not found"
+// @Router /api/v1/programs/{id} [get]
+func (h *ProgramHandlers) Get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	programID, ok := pathUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	program, err := h.programService.Get(r.Context(), userID, programID)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to get program")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, program)
+}
+
+// Update godoc
+// @Summary Update training program
+// @Description Update a program; when days is present it replaces the whole schedule
+// @Tags programs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Program ID"
+// @Param request body models.UpdateProgramRequest true "Fields to update"
+// @Success 200 {object} models.Program "Program updated"
+// @Failure 400 {object} ErrorResponse "Invalid request data or schedule"
+// @Failure 403 {object} ErrorResponse "Not the program author"
+// @Failure 404 {object} ErrorResponse "Program or template not found"
+// @Router /api/v1/programs/{id} [put]
+func (h *ProgramHandlers) Update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	programID, ok := pathUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateProgramRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.Name != nil {
+		trimmed := strings.TrimSpace(*req.Name)
+		req.Name = &trimmed
+	}
+	validationErrors := validateProgramFields(&req)
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	program, err := h.programService.Update(r.Context(), userID, programID, &req)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to update program")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, program)
+}
+
+// Delete godoc
+// @Summary Delete training program
+// @Description Delete a program and all enrollments in it
+// @Tags programs
+// @Security BearerAuth
+// @Param id path string true "Program ID"
+// @Success 204 "Program deleted"
+// @Failure 403 {object} ErrorResponse "Not the program author"
+// @Failure 404 {object} ErrorResponse "Program not found"
+// @Router /api/v1/programs/{id} [delete]
+func (h *ProgramHandlers) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	programID, ok := pathUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.programService.Delete(r.Context(), userID, programID); err != nil {
+		writeServiceError(w, h.logger, err, "Failed to delete program")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Enroll godoc
+// @Summary Enroll in training program
+// @Description Start following a program. Training maxes are used by percent_of_max programs.
+// @Description A user can follow one program at a time.
+// @Tags programs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Program ID"
+// @Param request body models.EnrollRequest false "Training maxes"
+// @Success 201 {object} models.Enrollment "Enrolled"
+// @Failure 400 {object} ErrorResponse "Invalid request data"
+// @Failure 404 {object} ErrorResponse "Program or exercise not found"
+// @Failure 409 {object} ErrorResponse "Already enrolled in a program"
+// @Router /api/v1/programs/{id}/enroll [post]
+func (h *ProgramHandlers) Enroll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	programID, ok := pathUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.EnrollRequest
+	if r.ContentLength != 0 && !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var validationErrors validation.ValidationErrors
+	for i, trainingMax := range req.TrainingMaxes {
+		field := func(name string) string { return fmt.Sprintf("training_maxes[%d].%s", i, name) }
+		validationErrors.Add(field("weight"),
+			validation.ValidateFloatRange(trainingMax.Weight, field("weight"), 0, maxWeight))
+		if trainingMax.WeightUnit != "" {
+			validationErrors.Add(field("weight_unit"), validateWeightUnit(trainingMax.WeightUnit))
+		}
+	}
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	enrollment, err := h.programService.Enroll(r.Context(), userID, programID, &req)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to enroll in program")
+		return
+	}
+
+	h.logger.Info("Enrolled in program", "user_id", userID, "program_id", programID, "enrollment_id", enrollment.ID)
+
+	writeConvertedJSON(w, r, http.StatusCreated, enrollment)
+}
+
+// CurrentEnrollment godoc
+// @Summary Get current enrollment
+// @Description Get the program the current user is following and their progress through it
+// @Tags programs
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.Enrollment "Enrollment"
+// @Failure 404 {object} ErrorResponse "No active enrollment"
+// @Router /api/v1/enrollment [get]
+func (h *ProgramHandlers) CurrentEnrollment(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	enrollment, err := h.programService.CurrentEnrollment(r.Context(), userID)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to get enrollment")
+		return
+	}
+
+	writeConvertedJSON(w, r, http.StatusOK, enrollment)
+}
+
+// Unenroll godoc
+// @Summary Leave current program
+// @Description Stop following the current program; workouts already logged are kept
+// @Tags programs
+// @Security BearerAuth
+// @Success 204 "Enrollment cancelled"
+// @Failure 404 {object} ErrorResponse "No active enrollment"
+// @Router /api/v1/enrollment [delete]
+func (h *ProgramHandlers) Unenroll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.programService.Unenroll(r.Context(), userID); err != nil {
+		writeServiceError(w, h.logger, err, "Failed to leave program")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Today godoc
+// @Summary Today's workout
+// @Description Resolve the next scheduled day of the current program with target loads computed
+// @Tags programs
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.ScheduledWorkout "Scheduled workout"
+// @Failure 404 {object} ErrorResponse "No active enrollment"
+// @Router /api/v1/enrollment/today [get]
+func (h *ProgramHandlers) Today(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	scheduled, err := h.programService.Today(r.Context(), userID)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to resolve today's workout")
+		return
+	}
+
+	writeConvertedJSON(w, r, http.StatusOK, scheduled)
+}
+
+// StartToday godoc
+// @Summary Start today's workout
+// @Description Create a workout from the next scheduled day with computed loads, and advance the enrollment
+// @Tags programs
+// @Produce json
+// @Security BearerAuth
+// @Success 201 {object} models.Workout "Workout started"
+// @Failure 404 {object} ErrorResponse "No active enrollment"
+// @Failure 409 {object} ErrorResponse "Enrollment changed concurrently"
+// @Router /api/v1/enrollment/today/start [post]
+func (h *ProgramHandlers) StartToday(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	workout, err := h.programService.StartToday(r.Context(), userID)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to start today's workout")
+		return
+	}
+
+	h.logger.Info("Program workout started", "user_id", userID, "workout_id", workout.ID)
+
+	writeConvertedJSON(w, r, http.StatusCreated, workout)
+}
@@ -9,13 +9,19 @@ import (
 
 	"github.com/aleksandr/strive-api/internal/config"
 	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
 )
 
 type RateLimiter struct {
 	requests map[string][]time.Time
+	// recorded is when each client's last rejection was added to the audit
+	// log; a client hammering the API is recorded once a minute, not per request.
+	recorded map[string]time.Time
 	mutex    sync.RWMutex
 	config   *config.RateLimitConfig
 	logger   *logger.Logger
+	audit    services.AuditService
 }
 
 type RateLimitError struct {
@@ -25,11 +31,13 @@ type RateLimitError struct {
 	} `json:"error"`
 }
 
-func NewRateLimiter(cfg *config.RateLimitConfig, log *logger.Logger) *RateLimiter {
+func NewRateLimiter(cfg *config.RateLimitConfig, log *logger.Logger, audit services.AuditService) *RateLimiter {
 	return &RateLimiter{
 		requests: make(map[string][]time.Time),
+		recorded: make(map[string]time.Time),
 		config:   cfg,
 		logger:   log,
+		audit:    audit,
 	}
 }
 
@@ -84,6 +92,39 @@ func (rl *RateLimiter) cleanup() {
 			rl.requests[clientID] = validRequests
 		}
 	}
+
+	for clientID, recordedAt := range rl.recorded {
+		if !recordedAt.After(windowStart) {
+			delete(rl.recorded, clientID)
+		}
+	}
+}
+
+// shouldRecord reports whether a rejection of clientID goes in the audit log.
+func (rl *RateLimiter) shouldRecord(clientID string) bool {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	now := time.Now()
+	if recordedAt, ok := rl.recorded[clientID]; ok && recordedAt.After(now.Add(-time.Minute)) {
+		return false
+	}
+	rl.recorded[clientID] = now
+	return true
+}
+
+func (rl *RateLimiter) recordRateLimitExceeded(r *http.Request, clientID string, limit int) {
+	if !rl.shouldRecord(clientID) {
+		return
+	}
+
+	err := rl.audit.Record(r.Context(), &models.AuditEvent{
+		Type:    models.AuditEventRateLimitExceeded,
+		Details: map[string]any{"method": r.Method, "path": r.URL.Path, "limit": limit},
+	})
+	if err != nil {
+		rl.logger.Error("Failed to record rate limit event", "error", err)
+	}
 }
 
 func (rl *RateLimiter) writeRateLimitError(w http.ResponseWriter, r *http.Request, limit int) {
@@ -136,6 +177,7 @@ func (rl *RateLimiter) RateLimitMiddleware() func(http.Handler) http.Handler {
 
 			if !rl.isAllowed(clientID, limit) {
 				rl.writeRateLimitError(w, r, limit)
+				rl.recordRateLimitExceeded(r, clientID, limit)
 				return
 			}
 
@@ -147,8 +189,13 @@ func (rl *RateLimiter) RateLimitMiddleware() func(http.Handler) http.Handler {
 func IsAuthEndpoint(path string) bool {
 	authPaths := []string{
 		"/api/v1/auth/login",
+		"/api/v1/auth/login/mfa",
 		"/api/v1/auth/register",
 		"/api/v1/auth/refresh",
+		"/api/v1/auth/forgot-password",
+		"/api/v1/auth/reset-password",
+		"/api/v1/auth/verify-email",
+		"/api/v1/auth/resend-verification",
 	}
 
 	for _, authPath := range authPaths {
@@ -1,16 +1,28 @@
 package http
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/aleksandr/strive-api/internal/config"
 	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 const testClientIP = "192.168.1.1:12345"
 
+// acceptingAuditService records nothing and expects nothing, for tests about other behaviour.
+func acceptingAuditService() *MockAuditService {
+	auditService := new(MockAuditService)
+	auditService.On("Record", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return auditService
+}
+
 func TestRateLimiter_GeneralRequests(t *testing.T) {
 	cfg := &config.RateLimitConfig{
 		AuthRequestsPerMinute:    5,
@@ -20,7 +32,7 @@ func TestRateLimiter_GeneralRequests(t *testing.T) {
 	}
 
 	log := logger.New("INFO", "text")
-	rateLimiter := NewRateLimiter(cfg, log)
+	rateLimiter := NewRateLimiter(cfg, log, acceptingAuditService())
 
 	handler := rateLimiter.RateLimitMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -57,7 +69,7 @@ func TestRateLimiter_AuthRequests(t *testing.T) {
 	}
 
 	log := logger.New("INFO", "text")
-	rateLimiter := NewRateLimiter(cfg, log)
+	rateLimiter := NewRateLimiter(cfg, log, acceptingAuditService())
 
 	handler := rateLimiter.RateLimitMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -94,7 +106,7 @@ func TestRateLimiter_Disabled(t *testing.T) {
 	}
 
 	log := logger.New("INFO", "text")
-	rateLimiter := NewRateLimiter(cfg, log)
+	rateLimiter := NewRateLimiter(cfg, log, acceptingAuditService())
 
 	handler := rateLimiter.RateLimitMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -123,7 +135,7 @@ func TestRateLimiter_DifferentClients(t *testing.T) {
 	}
 
 	log := logger.New("INFO", "text")
-	rateLimiter := NewRateLimiter(cfg, log)
+	rateLimiter := NewRateLimiter(cfg, log, acceptingAuditService())
 
 	handler := rateLimiter.RateLimitMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -155,14 +167,54 @@ func TestRateLimiter_DifferentClients(t *testing.T) {
 	}
 }
 
+func TestRateLimiter_RecordsRejections(t *testing.T) {
+	cfg := &config.RateLimitConfig{
+		AuthRequestsPerMinute:    1,
+		GeneralRequestsPerMinute: 1,
+		BurstSize:                1,
+		Enabled:                  true,
+	}
+
+	auditService := new(MockAuditService)
+	auditService.On("Record", mock.MatchedBy(func(ctx context.Context) bool {
+		return services.AuditRequestFromContext(ctx).IPAddress == "192.168.1.1"
+	}), mock.MatchedBy(func(event *models.AuditEvent) bool {
+		return event.Type == models.AuditEventRateLimitExceeded && event.Details["path"] == "/api/v1/auth/login"
+	})).Return(nil).Once()
+	rateLimiter := NewRateLimiter(cfg, logger.New("INFO", "text"), auditService)
+
+	handler := AuditRequestMiddleware()(rateLimiter.RateLimitMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", http.NoBody)
+	req.RemoteAddr = testClientIP
+
+	for i := 0; i < 4; i++ {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if i > 0 {
+			assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		}
+	}
+
+	// Only the first rejection in a minute is recorded.
+	auditService.AssertExpectations(t)
+}
+
 func TestIsAuthEndpoint(t *testing.T) {
 	tests := []struct {
 		path     string
 		expected bool
 	}{
 		{"/api/v1/auth/login", true},
+		{"/api/v1/auth/login/mfa", true},
 		{"/api/v1/auth/register", true},
 		{"/api/v1/auth/refresh", true},
+		{"/api/v1/auth/forgot-password", true},
+		{"/api/v1/auth/reset-password", true},
+		{"/api/v1/auth/verify-email", true},
+		{"/api/v1/auth/resend-verification", true},
 		{"/health", false},
 		{"/api/v1/user/profile", false},
 		{"/swagger/", false},
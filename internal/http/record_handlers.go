@@ -0,0 +1,151 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/aleksandr/strive-api/internal/validation"
+	"github.com/google/uuid"
+)
+
+type RecordHandlers struct {
+	recordService services.RecordService
+	logger        *logger.Logger
+}
+
+func NewRecordHandlers(recordService services.RecordService, logger *logger.Logger) *RecordHandlers {
+	return &RecordHandlers{
+		recordService: recordService,
+		logger:        logger,
+	}
+}
+
+type RecordListResponse struct {
+	Records []*models.PersonalRecord `json:"records"`
+	models.Pagination
+}
+
+func (resp RecordListResponse) ConvertWeights(unit models.WeightUnit) {
+	for _, record := range resp.Records {
+		record.ConvertWeights(unit)
+	}
+}
+
+// queryRecordFilter reads the shared record filters. The 1RM formula defaults to Epley.
+func queryRecordFilter(r *http.Request) (models.RecordFilter, validation.ValidationErrors) {
+	query := r.URL.Query()
+	filter := models.RecordFilter{
+		RecordType: models.RecordType(query.Get("type")),
+		Formula:    models.E1RMFormula(query.Get("formula")),
+		History:    query.Get("history") == "true",
+		Pagination: queryPagination(r),
+	}
+	if filter.Formula == "" {
+		filter.Formula = models.E1RMFormulaEpley
+	}
+
+	var validationErrors validation.ValidationErrors
+	if filter.RecordType != "" {
+		validationErrors.Add("type", validation.ValidateOneOf(string(filter.RecordType), "type", models.RecordTypes...))
+	}
+	validationErrors.Add("formula", validation.ValidateOneOf(string(filter.Formula), "formula", models.E1RMFormulas...))
+	return filter, validationErrors
+}
+
+// List godoc
+// @Summary List personal records
+// @Description List the current user's best records per exercise, or the full history with history=true.
+// @Description Weight values are in the preferred weight unit; max_reps values are rep counts at weight_kg.
+// @Tags records
+// @Produce json
+// @Security BearerAuth
+// @Param exercise_id query string false "Catalog exercise ID"
+// @Param type query string false "Record type (max_weight, estimated_1rm, max_reps, session_volume)"
+// @Param formula query string false "1RM formula (epley, brzycki)" default(epley)
+// @Param history query bool false "Return every record set instead of current bests"
+// @Param limit query int false "Page size (max 100)"
+// @Param offset query int false "Page offset"
+// @Success 200 {object} RecordListResponse "Personal records"
+// @Failure 400 {object} ErrorResponse "Invalid filter"
+// @Router /api/v1/records [get]
+func (h *RecordHandlers) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	filter, validationErrors := queryRecordFilter(r)
+	if exerciseID := r.URL.Query().Get("exercise_id"); exerciseID != "" {
+		parsed, err := uuid.Parse(exerciseID)
+		if err != nil {
+			validationErrors = append(validationErrors, validation.ValidationError{
+				Field:   "exercise_id",
+				Message: "exercise_id must be a valid UUID",
+			})
+		} else {
+			filter.ExerciseID = &parsed
+		}
+	}
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	records, err := h.recordService.List(r.Context(), userID, filter)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to list personal records")
+		return
+	}
+
+	writeConvertedJSON(w, r, http.StatusOK, RecordListResponse{
+		Records:    records,
+		Pagination: filter.Pagination,
+	})
+}
+
+// ListForExercise godoc
+// @Summary List personal records for an exercise
+// @Description List the current user's records for one catalog exercise, or their history with history=true
+// @Tags records
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Exercise ID"
+// @Param type query string false "Record type (max_weight, estimated_1rm, max_reps, session_volume)"
+// @Param formula query string false "1RM formula (epley, brzycki)" default(epley)
+// @Param history query bool false "Return every record set instead of current bests"
+// @Param limit query int false "Page size (max 100)"
+// @Param offset query int false "Page offset"
+// @Success 200 {object} RecordListResponse "Personal records"
+// @Failure 400 {object} ErrorResponse "Invalid filter"
+// @Failure 404 {object} ErrorResponse "Exercise not found"
+// @Router /api/v1/exercises/{id}/records [get]
+func (h *RecordHandlers) ListForExercise(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	exerciseID, ok := pathUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	filter, validationErrors := queryRecordFilter(r)
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	records, err := h.recordService.ListForExercise(r.Context(), userID, exerciseID, filter)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to list personal records")
+		return
+	}
+
+	writeConvertedJSON(w, r, http.StatusOK, RecordListResponse{
+		Records:    records,
+		Pagination: filter.Pagination,
+	})
+}
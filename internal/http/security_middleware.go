@@ -41,12 +41,27 @@ func (sl *SecurityLogger) LogFailedAuth(r *http.Request, reason string) {
 	})
 }
 
+// LogAccessDenied records an authenticated request refused for lacking a role or permission.
+func (sl *SecurityLogger) LogAccessDenied(r *http.Request, userID, required string) {
+	sl.LogSecurityEvent("access_denied", r, map[string]interface{}{
+		"user_id":  userID,
+		"required": required,
+	})
+}
+
 func (sl *SecurityLogger) LogSuspiciousActivity(r *http.Request, reason string) {
 	sl.LogSecurityEvent("suspicious_activity", r, map[string]interface{}{
 		"reason": reason,
 	})
 }
 
+// LogRefreshTokenReuse records that a rotated refresh token was replayed and its family revoked.
+func (sl *SecurityLogger) LogRefreshTokenReuse(r *http.Request, detail string) {
+	sl.LogSecurityEvent("refresh_token_reuse", r, map[string]interface{}{
+		"detail": detail,
+	})
+}
+
 func (sl *SecurityLogger) LogRateLimitExceeded(r *http.Request, limit int) {
 	sl.LogSecurityEvent("rate_limit_exceeded", r, map[string]interface{}{
 		"limit": limit,
@@ -59,19 +74,6 @@ func (sl *SecurityLogger) LogInvalidInput(r *http.Request, validationErrors []st
 	})
 }
 
-func getClientIP(r *http.Request) string {
-	if ip := r.Header.Get("X-Forwarded-For"); ip != "" {
-		if idx := strings.Index(ip, ","); idx != -1 {
-			return strings.TrimSpace(ip[:idx])
-		}
-		return strings.TrimSpace(ip)
-	}
-	if ip := r.Header.Get("X-Real-IP"); ip != "" {
-		return strings.TrimSpace(ip)
-	}
-	return r.RemoteAddr
-}
-
 // SecurityMiddleware logs security-related events
 func (sl *SecurityLogger) SecurityMiddleware() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
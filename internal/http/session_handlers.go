@@ -0,0 +1,188 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+)
+
+// Client details are cut to fit their refresh_tokens columns.
+const (
+	maxUserAgentLength  = 512
+	maxIPAddressLength  = 64
+	maxDeviceNameLength = 100
+)
+
+type SessionHandlers struct {
+	sessionService services.SessionService
+	logger         *logger.Logger
+}
+
+func NewSessionHandlers(sessionService services.SessionService, logger *logger.Logger) *SessionHandlers {
+	return &SessionHandlers{
+		sessionService: sessionService,
+		logger:         logger,
+	}
+}
+
+type SessionListResponse struct {
+	Sessions []*models.Session `json:"sessions"`
+}
+
+// List godoc
+// @Summary List sessions
+// @Description List the devices signed in to the account, most recently used first
+// @Tags authentication
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} SessionListResponse
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Router /api/v1/auth/sessions [get]
+func (h *SessionHandlers) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.sessionService.List(r.Context(), userID, refreshTokenFromCookie(r))
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to list sessions")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, SessionListResponse{Sessions: sessions})
+}
+
+// Revoke godoc
+// @Summary Revoke session
+// @Description Sign a device out. Its access token stays valid until it expires.
+// @Tags authentication
+// @Security BearerAuth
+// @Param id path string true "Session ID"
+// @Success 204 "Session revoked"
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Session not found"
+// @Router /api/v1/auth/sessions/{id} [delete]
+func (h *SessionHandlers) Revoke(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	sessionID, ok := pathUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.sessionService.Revoke(r.Context(), userID, sessionID); err != nil {
+		writeServiceError(w, h.logger, err, "Failed to revoke session")
+		return
+	}
+
+	h.logger.Info("Session revoked", "user_id", userID, "session_id", sessionID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeOthers godoc
+// @Summary Log out everywhere else
+// @Description Sign out every session except the one holding the request's refresh token cookie. Without the
+// @Description cookie every session is signed out.
+// @Tags authentication
+// @Security BearerAuth
+// @Success 204 "Other sessions revoked"
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Router /api/v1/auth/sessions [delete]
+func (h *SessionHandlers) RevokeOthers(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.sessionService.RevokeOthers(r.Context(), userID, refreshTokenFromCookie(r)); err != nil {
+		writeServiceError(w, h.logger, err, "Failed to revoke sessions")
+		return
+	}
+
+	h.logger.Info("Other sessions revoked", "user_id", userID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func refreshTokenFromCookie(r *http.Request) string {
+	if cookie, err := r.Cookie("refresh-token"); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+// requestClientInfo describes the device making r. deviceName is used when
+// the client supplied one; otherwise it is derived from the user agent.
+func requestClientInfo(r *http.Request, deviceName string) models.ClientInfo {
+	userAgent := truncate(r.UserAgent(), maxUserAgentLength)
+	if deviceName == "" {
+		deviceName = truncate(deviceNameFromUserAgent(userAgent), maxDeviceNameLength)
+	}
+	return models.ClientInfo{
+		UserAgent:  userAgent,
+		IPAddress:  truncate(getClientIP(r), maxIPAddressLength),
+		DeviceName: deviceName,
+	}
+}
+
+type userAgentMarker struct {
+	token string
+	name  string
+}
+
+// Order matters: Edge and Opera also claim Chrome, and Chrome also claims Safari.
+var (
+	userAgentBrowsers = []userAgentMarker{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+	}
+	userAgentPlatforms = []userAgentMarker{
+		{"iPhone", "iPhone"},
+		{"iPad", "iPad"},
+		{"Android", "Android"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"Linux", "Linux"},
+	}
+)
+
+// deviceNameFromUserAgent returns a coarse label such as "Chrome on macOS".
+// Non-browser clients are named after their product token, e.g. "curl".
+func deviceNameFromUserAgent(userAgent string) string {
+	browser := matchUserAgent(userAgent, userAgentBrowsers)
+	platform := matchUserAgent(userAgent, userAgentPlatforms)
+
+	switch {
+	case browser != "" && platform != "":
+		return browser + " on " + platform
+	case browser != "":
+		return browser
+	case platform != "":
+		return platform
+	case userAgent != "":
+		product, _, _ := strings.Cut(userAgent, "/")
+		return strings.TrimSpace(product)
+	default:
+		return "Unknown device"
+	}
+}
+
+func matchUserAgent(userAgent string, markers []userAgentMarker) string {
+	for _, marker := range markers {
+		if strings.Contains(userAgent, marker.token) {
+			return marker.name
+		}
+	}
+	return ""
+}
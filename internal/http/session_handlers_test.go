@@ -0,0 +1,129 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionHandlers_List(t *testing.T) {
+	logger := logger.New("INFO", "json")
+	userID := uuid.New()
+
+	mockService := new(MockSessionService)
+	mockService.On("List", mock.Anything, userID, "current-token").
+		Return([]*models.Session{{ID: uuid.New(), DeviceName: "Chrome on macOS", Current: true}}, nil)
+	handlers := NewSessionHandlers(mockService, logger)
+
+	req := withUserID(httptest.NewRequest(http.MethodGet, "/sessions", http.NoBody), userID)
+	req.AddCookie(&http.Cookie{Name: "refresh-token", Value: "current-token"})
+	rr := httptest.NewRecorder()
+	handlers.List(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var response SessionListResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Len(t, response.Sessions, 1)
+	assert.True(t, response.Sessions[0].Current)
+	assert.NotContains(t, rr.Body.String(), "current-token")
+	mockService.AssertExpectations(t)
+}
+
+func TestSessionHandlers_Revoke(t *testing.T) {
+	logger := logger.New("INFO", "json")
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{"revoked", nil, http.StatusNoContent},
+		{"not found", services.ErrSessionNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSessionService)
+			mockService.On("Revoke", mock.Anything, userID, sessionID).Return(tt.serviceErr)
+			handlers := NewSessionHandlers(mockService, logger)
+
+			req := withUserID(httptest.NewRequest(http.MethodDelete, "/sessions/"+sessionID.String(), http.NoBody), userID)
+			req.SetPathValue("id", sessionID.String())
+			rr := httptest.NewRecorder()
+			handlers.Revoke(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSessionHandlers_RevokeOthers(t *testing.T) {
+	logger := logger.New("INFO", "json")
+	userID := uuid.New()
+
+	mockService := new(MockSessionService)
+	mockService.On("RevokeOthers", mock.Anything, userID, "current-token").Return(nil)
+	handlers := NewSessionHandlers(mockService, logger)
+
+	req := withUserID(httptest.NewRequest(http.MethodDelete, "/sessions", http.NoBody), userID)
+	req.AddCookie(&http.Cookie{Name: "refresh-token", Value: "current-token"})
+	rr := httptest.NewRecorder()
+	handlers.RevokeOthers(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestDeviceNameFromUserAgent(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		expected  string
+	}{
+		{"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0 Safari/537.36", "Chrome on macOS"},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0 Safari/537.36 Edg/126.0", "Edge on Windows"},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1", "Safari on iPhone"},
+		{"Mozilla/5.0 (X11; Linux x86_64; rv:127.0) Gecko/20100101 Firefox/127.0", "Firefox on Linux"},
+		{"curl/8.7.1", "curl"},
+		{"", "Unknown device"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, deviceNameFromUserAgent(tt.userAgent), tt.userAgent)
+	}
+}
+
+func TestRequestClientInfo(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", http.NoBody)
+	req.Header.Set("User-Agent", strings.Repeat("a", 600))
+	req.RemoteAddr = "[fe80::1%" + strings.Repeat("e", 100) + "]:41234"
+
+	client := requestClientInfo(req, "")
+	assert.Len(t, client.UserAgent, maxUserAgentLength)
+	assert.Len(t, client.IPAddress, maxIPAddressLength, "the address fits refresh_tokens.ip_address")
+	assert.Len(t, client.DeviceName, maxDeviceNameLength, "a name derived from an unknown agent fits refresh_tokens.device_name")
+}
+
+func TestRequestClientInfo_NonASCIIUserAgent(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", http.NoBody)
+	req.Header.Set("User-Agent", "\xff"+strings.Repeat("é", 600))
+
+	client := requestClientInfo(req, "")
+	assert.True(t, utf8.ValidString(client.UserAgent), "invalid bytes are dropped")
+	assert.Equal(t, maxUserAgentLength, utf8.RuneCountInString(client.UserAgent), "the agent is cut on a character boundary")
+	assert.True(t, utf8.ValidString(client.DeviceName))
+	assert.Equal(t, maxDeviceNameLength, utf8.RuneCountInString(client.DeviceName))
+}
@@ -0,0 +1,200 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/aleksandr/strive-api/internal/validation"
+	"github.com/google/uuid"
+)
+
+type StatsHandlers struct {
+	statsService services.StatsService
+	logger       *logger.Logger
+}
+
+func NewStatsHandlers(statsService services.StatsService, logger *logger.Logger) *StatsHandlers {
+	return &StatsHandlers{
+		statsService: statsService,
+		logger:       logger,
+	}
+}
+
+// queryStats reads the shared date-range and grouping parameters. Dates are
+// YYYY-MM-DD and both ends are inclusive. Weeks start on the caller's week_start.
+func queryStats(r *http.Request) (models.StatsQuery, validation.ValidationErrors) {
+	query := r.URL.Query()
+	q := models.StatsQuery{
+		Interval:  models.StatsInterval(query.Get("interval")),
+		Timezone:  query.Get("tz"),
+		WeekStart: preferredWeekStart(r),
+		Muscle:    query.Get("muscle"),
+	}
+
+	var validationErrors validation.ValidationErrors
+	parseDate := func(name string) time.Time {
+		value := query.Get(name)
+		if value == "" {
+			return time.Time{}
+		}
+		parsed, err := time.Parse(models.PeriodLayout, value)
+		if err != nil {
+			validationErrors = append(validationErrors, validation.ValidationError{
+				Field:   name,
+				Message: name + " must be a date in YYYY-MM-DD format",
+			})
+		}
+		return parsed
+	}
+	q.From = parseDate("from")
+	q.To = parseDate("to")
+
+	if q.Interval != "" {
+		validationErrors.Add("interval", validation.ValidateOneOf(string(q.Interval), "interval", models.StatsIntervals...))
+	}
+	if q.Muscle != "" {
+		validationErrors.Add("muscle", validation.ValidateOneOf(q.Muscle, "muscle", models.MuscleGroups...))
+	}
+	if exerciseID := query.Get("exercise_id"); exerciseID != "" {
+		parsed, err := uuid.Parse(exerciseID)
+		if err != nil {
+			validationErrors = append(validationErrors, validation.ValidationError{
+				Field:   "exercise_id",
+				Message: "exercise_id must be a valid UUID",
+			})
+		} else {
+			q.ExerciseID = &parsed
+		}
+	}
+
+	return q, validationErrors
+}
+
+type timeSeriesFunc func(ctx context.Context, userID uuid.UUID, q models.StatsQuery) (*models.TimeSeriesResponse, error)
+
+func (h *StatsHandlers) serveTimeSeries(w http.ResponseWriter, r *http.Request, stats timeSeriesFunc) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	q, validationErrors := queryStats(r)
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	result, err := stats(r.Context(), userID, q)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to compute statistics")
+		return
+	}
+
+	writeConvertedJSON(w, r, http.StatusOK, result)
+}
+
+// Volume godoc
+// @Summary Volume per muscle group
+// @Description Working-set volume (weight x reps, in the preferred weight unit) per primary muscle group, one series per muscle
+// @Tags stats
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day (YYYY-MM-DD), defaults to today"
+// @Param interval query string false "Grouping (day, week, month); weeks start on the user's week_start" default(week)
+// @Param tz query string false "IANA timezone for period boundaries" default(UTC)
+// @Param muscle query string false "Only this muscle group"
+// @Success 200 {object} models.TimeSeriesResponse "Volume series"
+// @Failure 400 {object} ErrorResponse "Invalid parameters"
+// @Router /api/v1/stats/volume [get]
+func (h *StatsHandlers) Volume(w http.ResponseWriter, r *http.Request) {
+	h.serveTimeSeries(w, r, h.statsService.Volume)
+}
+
+// Sets godoc
+// @Summary Sets per exercise
+// @Description Number of working sets per exercise over time, one series per exercise
+// @Tags stats
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day (YYYY-MM-DD), defaults to today"
+// @Param interval query string false "Grouping (day, week, month); weeks start on the user's week_start" default(week)
+// @Param tz query string false "IANA timezone for period boundaries" default(UTC)
+// @Param exercise_id query string false "Only this catalog exercise"
+// @Success 200 {object} models.TimeSeriesResponse "Set count series"
+// @Failure 400 {object} ErrorResponse "Invalid parameters"
+// @Router /api/v1/stats/sets [get]
+func (h *StatsHandlers) Sets(w http.ResponseWriter, r *http.Request) {
+	h.serveTimeSeries(w, r, h.statsService.Sets)
+}
+
+// Frequency godoc
+// @Summary Workout frequency
+// @Description Number of workouts per period
+// @Tags stats
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day (YYYY-MM-DD), defaults to today"
+// @Param interval query string false "Grouping (day, week, month); weeks start on the user's week_start" default(week)
+// @Param tz query string false "IANA timezone for period boundaries" default(UTC)
+// @Success 200 {object} models.TimeSeriesResponse "Workout count series"
+// @Failure 400 {object} ErrorResponse "Invalid parameters"
+// @Router /api/v1/stats/frequency [get]
+func (h *StatsHandlers) Frequency(w http.ResponseWriter, r *http.Request) {
+	h.serveTimeSeries(w, r, h.statsService.Frequency)
+}
+
+// Duration godoc
+// @Summary Average session duration
+// @Description Average length in minutes of finished workouts per period
+// @Tags stats
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day (YYYY-MM-DD), defaults to today"
+// @Param interval query string false "Grouping (day, week, month); weeks start on the user's week_start" default(week)
+// @Param tz query string false "IANA timezone for period boundaries" default(UTC)
+// @Success 200 {object} models.TimeSeriesResponse "Duration series"
+// @Failure 400 {object} ErrorResponse "Invalid parameters"
+// @Router /api/v1/stats/duration [get]
+func (h *StatsHandlers) Duration(w http.ResponseWriter, r *http.Request) {
+	h.serveTimeSeries(w, r, h.statsService.Duration)
+}
+
+// Streaks godoc
+// @Summary Training streaks
+// @Description Current and longest runs of consecutive days or weeks with at least one workout
+// @Tags stats
+// @Produce json
+// @Security BearerAuth
+// @Param interval query string false "Streak unit (day, week, month); weeks start on the user's week_start" default(week)
+// @Param tz query string false "IANA timezone for period boundaries" default(UTC)
+// @Success 200 {object} models.Streaks "Streaks"
+// @Failure 400 {object} ErrorResponse "Invalid parameters"
+// @Router /api/v1/stats/streaks [get]
+func (h *StatsHandlers) Streaks(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	q, validationErrors := queryStats(r)
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	streaks, err := h.statsService.Streaks(r.Context(), userID, q.Interval, q.Timezone, q.WeekStart)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to compute streaks")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, streaks)
+}
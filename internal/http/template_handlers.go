@@ -0,0 +1,295 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/aleksandr/strive-api/internal/validation"
+)
+
+const (
+	maxTemplateExercises = 50
+	maxTargetSets        = 20
+)
+
+type TemplateHandlers struct {
+	templateService services.TemplateService
+	logger          *logger.Logger
+}
+
+func NewTemplateHandlers(templateService services.TemplateService, logger *logger.Logger) *TemplateHandlers {
+	return &TemplateHandlers{
+		templateService: templateService,
+		logger:          logger,
+	}
+}
+
+type TemplateListResponse struct {
+	Templates []*models.WorkoutTemplate `json:"templates"`
+	models.Pagination
+}
+
+func (resp TemplateListResponse) ConvertWeights(unit models.WeightUnit) {
+	for _, template := range resp.Templates {
+		template.ConvertWeights(unit)
+	}
+}
+
+func validateTemplateExercises(inputs []models.TemplateExerciseInput) validation.ValidationErrors {
+	var validationErrors validation.ValidationErrors
+	if len(inputs) > maxTemplateExercises {
+		validationErrors.Add("exercises", fmt.Errorf("exercises must contain at most %d entries", maxTemplateExercises))
+		return validationErrors
+	}
+
+	for i := range inputs {
+		input := &inputs[i]
+		field := func(name string) string { return fmt.Sprintf("exercises[%d].%s", i, name) }
+
+		input.Name = strings.TrimSpace(input.Name)
+		if input.ExerciseID == nil || input.Name != "" {
+			validationErrors.Add(field("name"), validation.ValidateString(input.Name, field("name"), 1, 255))
+		}
+		validationErrors.Add(field("target_sets"),
+			validation.ValidateIntRange(input.TargetSets, field("target_sets"), 1, maxTargetSets))
+		validationErrors.Add(field("target_reps"),
+			validation.ValidateIntRange(input.TargetReps, field("target_reps"), 1, maxReps))
+		if input.TargetWeight != nil {
+			validationErrors.Add(field("target_weight"),
+				validation.ValidateFloatRange(*input.TargetWeight, field("target_weight"), 0, maxWeight))
+		}
+		if input.WeightUnit != "" {
+			validationErrors.Add(field("weight_unit"), validateWeightUnit(input.WeightUnit))
+		}
+		if input.RestSeconds != nil {
+			validationErrors.Add(field("rest_seconds"),
+				validation.ValidateIntRange(*input.RestSeconds, field("rest_seconds"), 0, maxRestSeconds))
+		}
+		validationErrors.Add(field("notes"), validateNotes(input.Notes))
+	}
+	return validationErrors
+}
+
+// Create godoc
+// @Summary Create workout template
+// @Description Create a reusable routine with ordered exercises and their target sets, reps and weight
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateTemplateRequest true "Template data"
+// @Success 201 {object} models.WorkoutTemplate "Template created"
+// @Failure 400 {object} ErrorResponse "Invalid request data"
+// @Failure 404 {object} ErrorResponse "Catalog exercise not found"
+// @Router /api/v1/templates [post]
+func (h *TemplateHandlers) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.CreateTemplateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	var validationErrors validation.ValidationErrors
+	validationErrors.Add("name", validation.ValidateString(req.Name, "name", 1, 255))
+	validationErrors.Add("notes", validateNotes(req.Notes))
+	validationErrors = append(validationErrors, validateTemplateExercises(req.Exercises)...)
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	template, err := h.templateService.Create(r.Context(), userID, &req)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to create template")
+		return
+	}
+
+	h.logger.Info("Workout template created", "user_id", userID, "template_id", template.ID)
+
+	writeConvertedJSON(w, r, http.StatusCreated, template)
+}
+
+// List godoc
+// @Summary List workout templates
+// @Description List the current user's templates by name
+// @Tags templates
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Page size (max 100)"
+// @Param offset query int false "Page offset"
+// @Success 200 {object} TemplateListResponse "Templates"
+// @Router /api/v1/templates [get]
+func (h *TemplateHandlers) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	page := queryPagination(r)
+
+	templates, err := h.templateService.List(r.Context(), userID, page)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to list templates")
+		return
+	}
+
+	writeConvertedJSON(w, r, http.StatusOK, TemplateListResponse{
+		Templates:  templates,
+		Pagination: page,
+	})
+}
+
+// Get godoc
+// @Summary Get workout template
+// @Description Get a single template owned by the current user
+// @Tags templates
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Success 200 {object} models.WorkoutTemplate "Template"
+// @Failure 404 {object} ErrorResponse "Template not found"
+// @Router /api/v1/templates/{id} [get]
+func (h *TemplateHandlers) Get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	templateID, ok := pathUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	template, err := h.templateService.Get(r.Context(), userID, templateID)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to get template")
+		return
+	}
+
+	writeConvertedJSON(w, r, http.StatusOK, template)
+}
+
+// Update godoc
+// @Summary Update workout template
+// @Description Update a template; when exercises is present it replaces the whole exercise list
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Param request body models.UpdateTemplateRequest true "Fields to update"
+// @Success 200 {object} models.WorkoutTemplate "Template updated"
+// @Failure 400 {object} ErrorResponse "Invalid request data"
+// @Failure 404 {object} ErrorResponse "Template or catalog exercise not found"
+// @Router /api/v1/templates/{id} [put]
+func (h *TemplateHandlers) Update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	templateID, ok := pathUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateTemplateRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var validationErrors validation.ValidationErrors
+	if req.Name != nil {
+		trimmed := strings.TrimSpace(*req.Name)
+		req.Name = &trimmed
+		validationErrors.Add("name", validation.ValidateString(trimmed, "name", 1, 255))
+	}
+	if req.Notes != nil {
+		validationErrors.Add("notes", validateNotes(*req.Notes))
+	}
+	if req.Exercises != nil {
+		validationErrors = append(validationErrors, validateTemplateExercises(*req.Exercises)...)
+	}
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	template, err := h.templateService.Update(r.Context(), userID, templateID, &req)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to update template")
+		return
+	}
+
+	writeConvertedJSON(w, r, http.StatusOK, template)
+}
+
+// Delete godoc
+// @Summary Delete workout template
+// @Description Delete a template; workouts already started from it are kept
+// @Tags templates
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Success 204 "Template deleted"
+// @Failure 404 {object} ErrorResponse "Template not found"
+// @Failure 409 {object} ErrorResponse "Template is used by a program"
+// @Router /api/v1/templates/{id} [delete]
+func (h *TemplateHandlers) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	templateID, ok := pathUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.templateService.Delete(r.Context(), userID, templateID); err != nil {
+		writeServiceError(w, h.logger, err, "Failed to delete template")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Start godoc
+// @Summary Start workout from template
+// @Description Create a workout starting now, with the template's exercises and target sets already filled in
+// @Tags templates
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Success 201 {object} models.Workout "Workout started"
+// @Failure 404 {object} ErrorResponse "Template not found"
+// @Router /api/v1/templates/{id}/start [post]
+func (h *TemplateHandlers) Start(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	templateID, ok := pathUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	workout, err := h.templateService.Start(r.Context(), userID, templateID)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to start workout from template")
+		return
+	}
+
+	h.logger.Info("Workout started from template", "user_id", userID, "template_id", templateID, "workout_id", workout.ID)
+
+	writeConvertedJSON(w, r, http.StatusCreated, workout)
+}
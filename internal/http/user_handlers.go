@@ -0,0 +1,142 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/aleksandr/strive-api/internal/validation"
+)
+
+const (
+	minHeightCm = 50
+	maxHeightCm = 300
+)
+
+// localePattern accepts BCP 47 tags of the form language[-Script][-REGION], e.g. "en", "de-DE", "zh-Hant-TW".
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z][a-z]{3})?(-([A-Z]{2}|[0-9]{3}))?$`)
+
+var minBirthDate = time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type UserHandlers struct {
+	userService services.UserService
+	logger      *logger.Logger
+}
+
+func NewUserHandlers(userService services.UserService, logger *logger.Logger) *UserHandlers {
+	return &UserHandlers{
+		userService: userService,
+		logger:      logger,
+	}
+}
+
+func validateBirthDate(value string) error {
+	birthDate, err := time.Parse(models.PeriodLayout, value)
+	if err != nil {
+		return errors.New("birth_date must be a date in YYYY-MM-DD format")
+	}
+	if birthDate.Before(minBirthDate) || birthDate.After(time.Now()) {
+		return errors.New("birth_date must be between 1900-01-01 and today")
+	}
+	return nil
+}
+
+func validateProfile(req *models.UpdateProfileRequest) validation.ValidationErrors {
+	var validationErrors validation.ValidationErrors
+	if req.DisplayName != nil {
+		trimmed := strings.TrimSpace(*req.DisplayName)
+		req.DisplayName = &trimmed
+		if trimmed != "" {
+			validationErrors.Add("display_name", validation.ValidateString(trimmed, "display_name", 1, 100))
+		}
+	}
+	if req.BirthDate != nil && *req.BirthDate != "" {
+		validationErrors.Add("birth_date", validateBirthDate(*req.BirthDate))
+	}
+	if req.Sex != nil && *req.Sex != "" {
+		validationErrors.Add("sex", validation.ValidateOneOf(string(*req.Sex), "sex", models.Sexes...))
+	}
+	if req.HeightCm != nil && *req.HeightCm != 0 {
+		validationErrors.Add("height_cm", validation.ValidateFloatRange(*req.HeightCm, "height_cm", minHeightCm, maxHeightCm))
+	}
+	if req.WeightUnit != nil {
+		validationErrors.Add("weight_unit", validateWeightUnit(*req.WeightUnit))
+	}
+	if req.Locale != nil && !localePattern.MatchString(*req.Locale) {
+		validationErrors.Add("locale", errors.New("locale must be a language tag such as en or de-DE"))
+	}
+	if req.WeekStart != nil {
+		validationErrors.Add("week_start", validation.ValidateOneOf(string(*req.WeekStart), "week_start", models.WeekStarts...))
+	}
+	return validationErrors
+}
+
+// Me godoc
+// @Summary Get current user profile
+// @Description Returns the authenticated user's account and profile as stored
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.User "User profile"
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Failure 404 {object} ErrorResponse "User not found"
+// @Router /api/v1/auth/me [get]
+func (h *UserHandlers) Me(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.GetProfile(r.Context(), userID)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to get user profile")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, user)
+}
+
+// UpdateMe godoc
+// @Summary Update current user profile
+// @Description Update profile fields and preferences; omitted fields are left unchanged.
+// @Description An empty birth_date or sex, or a zero height_cm, clears the value.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.UpdateProfileRequest true "Profile fields to update"
+// @Success 200 {object} models.User "Profile updated"
+// @Failure 400 {object} ErrorResponse "Invalid request data"
+// @Failure 404 {object} ErrorResponse "User not found"
+// @Router /api/v1/users/me [patch]
+func (h *UserHandlers) UpdateMe(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateProfileRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if validationErrors := validateProfile(&req); len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	user, err := h.userService.UpdateProfile(r.Context(), userID, &req)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to update user profile")
+		return
+	}
+
+	h.logger.Info("User profile updated", "user_id", userID)
+
+	writeJSON(w, http.StatusOK, user)
+}
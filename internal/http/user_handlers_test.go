@@ -0,0 +1,147 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserHandlers_Me(t *testing.T) {
+	logger := logger.New("INFO", "json")
+	userID := uuid.New()
+
+	t.Run("returns stored profile", func(t *testing.T) {
+		mockService := new(MockUserService)
+		mockService.On("GetProfile", mock.Anything, userID).Return(&models.User{
+			ID:          userID,
+			Email:       "test@example.com",
+			DisplayName: "Alex",
+			WeightUnit:  models.WeightUnitLb,
+		}, nil)
+		handlers := NewUserHandlers(mockService, logger)
+
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/v1/auth/me", http.NoBody), userID)
+		rr := httptest.NewRecorder()
+		handlers.Me(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, userID.String(), response["id"])
+		assert.Equal(t, "Alex", response["display_name"])
+		assert.Equal(t, "lb", response["weight_unit"])
+		assert.NotContains(t, response, "password_hash")
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("user deleted", func(t *testing.T) {
+		mockService := new(MockUserService)
+		mockService.On("GetProfile", mock.Anything, userID).Return(nil, services.ErrUserNotFound)
+		handlers := NewUserHandlers(mockService, logger)
+
+		req := withUserID(httptest.NewRequest(http.MethodGet, "/api/v1/auth/me", http.NoBody), userID)
+		rr := httptest.NewRecorder()
+		handlers.Me(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestUserHandlers_UpdateMe(t *testing.T) {
+	logger := logger.New("INFO", "json")
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockSetup      func(*MockUserService)
+		expectedStatus int
+	}{
+		{
+			name:        "successful update",
+			requestBody: map[string]interface{}{"display_name": "  Alex  ", "weight_unit": "lb", "locale": "de-DE"},
+			mockSetup: func(m *MockUserService) {
+				m.On("UpdateProfile", mock.Anything, userID, mock.MatchedBy(func(req *models.UpdateProfileRequest) bool {
+					return *req.DisplayName == "Alex" && *req.WeightUnit == models.WeightUnitLb
+				})).Return(&models.User{ID: userID, DisplayName: "Alex"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid fields",
+			requestBody:    map[string]interface{}{"birth_date": "17.05.1990", "weight_unit": "stone", "locale": "english"},
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "height out of range",
+			requestBody:    map[string]interface{}{"height_cm": 20},
+			mockSetup:      func(m *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "unknown timezone",
+			requestBody: map[string]interface{}{"timezone": "Mars/Olympus"},
+			mockSetup: func(m *MockUserService) {
+				m.On("UpdateProfile", mock.Anything, userID, mock.AnythingOfType("*models.UpdateProfileRequest")).
+					Return(nil, services.ErrInvalidTimezone)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockUserService)
+			tt.mockSetup(mockService)
+			handlers := NewUserHandlers(mockService, logger)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := withUserID(httptest.NewRequest(http.MethodPatch, "/api/v1/users/me", bytes.NewReader(body)), userID)
+			rr := httptest.NewRecorder()
+			handlers.UpdateMe(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCurrentUserMiddleware_ConvertsWeights(t *testing.T) {
+	logger := logger.New("INFO", "json")
+	userID := uuid.New()
+
+	mockService := new(MockUserService)
+	mockService.On("GetProfile", mock.Anything, userID).
+		Return(&models.User{ID: userID, WeightUnit: models.WeightUnitLb}, nil).Once()
+
+	handler := CurrentUserMiddleware(mockService, logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeConvertedJSON(w, r, http.StatusOK, ExerciseSetListResponse{Sets: []*models.ExerciseSet{
+			{Weight: 100, WeightUnit: models.WeightUnitKg},
+			{Weight: 135, WeightUnit: models.WeightUnitLb},
+		}})
+	}))
+
+	req := withUserID(httptest.NewRequest(http.MethodGet, "/api/v1/workouts", http.NoBody), userID)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var response ExerciseSetListResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Len(t, response.Sets, 2)
+	assert.Equal(t, 220.46, response.Sets[0].Weight)
+	assert.Equal(t, models.WeightUnitLb, response.Sets[0].WeightUnit)
+	assert.Equal(t, 135.0, response.Sets[1].Weight)
+	mockService.AssertExpectations(t)
+}
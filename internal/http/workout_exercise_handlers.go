@@ -0,0 +1,392 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/aleksandr/strive-api/internal/validation"
+	"github.com/google/uuid"
+)
+
+const (
+	maxReps        = 1000
+	maxWeight      = 10000
+	maxRestSeconds = 3600
+	maxPosition    = 1000
+)
+
+type WorkoutExerciseHandlers struct {
+	exerciseService services.WorkoutExerciseService
+	logger          *logger.Logger
+}
+
+func NewWorkoutExerciseHandlers(exerciseService services.WorkoutExerciseService, logger *logger.Logger) *WorkoutExerciseHandlers {
+	return &WorkoutExerciseHandlers{
+		exerciseService: exerciseService,
+		logger:          logger,
+	}
+}
+
+type WorkoutExerciseListResponse struct {
+	Exercises []*models.WorkoutExercise `json:"exercises"`
+}
+
+func (resp WorkoutExerciseListResponse) ConvertWeights(unit models.WeightUnit) {
+	for _, exercise := range resp.Exercises {
+		exercise.ConvertWeights(unit)
+	}
+}
+
+type ExerciseSetListResponse struct {
+	Sets []*models.ExerciseSet `json:"sets"`
+}
+
+func (resp ExerciseSetListResponse) ConvertWeights(unit models.WeightUnit) {
+	for _, set := range resp.Sets {
+		set.ConvertWeights(unit)
+	}
+}
+
+type exercisePath struct {
+	userID     uuid.UUID
+	workoutID  uuid.UUID
+	exerciseID uuid.UUID
+	setID      uuid.UUID
+}
+
+func parseExercisePath(w http.ResponseWriter, r *http.Request, withExercise, withSet bool) (exercisePath, bool) {
+	var path exercisePath
+	var ok bool
+
+	if path.userID, ok = currentUserID(w, r); !ok {
+		return path, false
+	}
+	if path.workoutID, ok = pathUUID(w, r, "id"); !ok {
+		return path, false
+	}
+	if withExercise {
+		if path.exerciseID, ok = pathUUID(w, r, "exerciseId"); !ok {
+			return path, false
+		}
+	}
+	if withSet {
+		if path.setID, ok = pathUUID(w, r, "setId"); !ok {
+			return path, false
+		}
+	}
+
+	return path, true
+}
+
+func validateWeightUnit(unit models.WeightUnit) error {
+	return validation.ValidateOneOf(string(unit), "weight_unit", string(models.WeightUnitKg), string(models.WeightUnitLb))
+}
+
+func validateSetFields(
+	reps *int,
+	weight *float64,
+	unit *models.WeightUnit,
+	rpe *float64,
+	restSeconds *int,
+) validation.ValidationErrors {
+	var validationErrors validation.ValidationErrors
+	if reps != nil {
+		validationErrors.Add("reps", validation.ValidateIntRange(*reps, "reps", 0, maxReps))
+	}
+	if weight != nil {
+		validationErrors.Add("weight", validation.ValidateFloatRange(*weight, "weight", 0, maxWeight))
+	}
+	if unit != nil {
+		validationErrors.Add("weight_unit", validateWeightUnit(*unit))
+	}
+	if rpe != nil {
+		validationErrors.Add("rpe", validation.ValidateFloatRange(*rpe, "rpe", 1, 10))
+	}
+	if restSeconds != nil {
+		validationErrors.Add("rest_seconds", validation.ValidateIntRange(*restSeconds, "rest_seconds", 0, maxRestSeconds))
+	}
+	return validationErrors
+}
+
+// AddExercise godoc
+// @Summary Add exercise to workout
+// @Description Append an exercise entry to the end of a workout. Pass exercise_id to link a catalog exercise;
+// @Description name then defaults to the catalog name.
+// @Tags workout-exercises
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Workout ID"
+// @Param request body models.CreateWorkoutExerciseRequest true "Exercise data"
+// @Success 201 {object} models.WorkoutExercise "Exercise added"
+// @Failure 400 {object} ErrorResponse "Invalid request data"
+// @Failure 404 {object} ErrorResponse "Workout or catalog exercise not found"
+// @Router /api/v1/workouts/{id}/exercises [post]
+func (h *WorkoutExerciseHandlers) AddExercise(w http.ResponseWriter, r *http.Request) {
+	path, ok := parseExercisePath(w, r, false, false)
+	if !ok {
+		return
+	}
+
+	var req models.CreateWorkoutExerciseRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var validationErrors validation.ValidationErrors
+	if req.ExerciseID == nil || req.Name != "" {
+		validationErrors.Add("name", validation.ValidateString(req.Name, "name", 1, 255))
+	}
+	validationErrors.Add("notes", validateNotes(req.Notes))
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	exercise, err := h.exerciseService.AddExercise(r.Context(), path.userID, path.workoutID, &req)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to add exercise")
+		return
+	}
+
+	writeConvertedJSON(w, r, http.StatusCreated, exercise)
+}
+
+// ListExercises godoc
+// @Summary List workout exercises
+// @Description List exercise entries of a workout in order, including their sets
+// @Tags workout-exercises
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Workout ID"
+// @Success 200 {object} WorkoutExerciseListResponse "Exercises"
+// @Failure 404 {object} ErrorResponse "Workout not found"
+// @Router /api/v1/workouts/{id}/exercises [get]
+func (h *WorkoutExerciseHandlers) ListExercises(w http.ResponseWriter, r *http.Request) {
+	path, ok := parseExercisePath(w, r, false, false)
+	if !ok {
+		return
+	}
+
+	exercises, err := h.exerciseService.ListExercises(r.Context(), path.userID, path.workoutID)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to list exercises")
+		return
+	}
+
+	writeConvertedJSON(w, r, http.StatusOK, WorkoutExerciseListResponse{Exercises: exercises})
+}
+
+// UpdateExercise godoc
+// @Summary Update workout exercise
+// @Description Rename, annotate or reorder an exercise entry
+// @Tags workout-exercises
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Workout ID"
+// @Param exerciseId path string true "Workout exercise ID"
+// @Param request body models.UpdateWorkoutExerciseRequest true "Fields to update"
+// @Success 200 {object} models.WorkoutExercise "Exercise updated"
+// @Failure 400 {object} ErrorResponse "Invalid request data"
+// @Failure 404 {object} ErrorResponse "Workout or exercise not found"
+// @Router /api/v1/workouts/{id}/exercises/{exerciseId} [put]
+func (h *WorkoutExerciseHandlers) UpdateExercise(w http.ResponseWriter, r *http.Request) {
+	path, ok := parseExercisePath(w, r, true, false)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateWorkoutExerciseRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var validationErrors validation.ValidationErrors
+	if req.Name != nil {
+		validationErrors.Add("name", validation.ValidateString(*req.Name, "name", 1, 255))
+	}
+	if req.Notes != nil {
+		validationErrors.Add("notes", validateNotes(*req.Notes))
+	}
+	if req.Position != nil {
+		validationErrors.Add("position", validation.ValidateIntRange(*req.Position, "position", 1, maxPosition))
+	}
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	exercise, err := h.exerciseService.UpdateExercise(r.Context(), path.userID, path.workoutID, path.exerciseID, &req)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to update exercise")
+		return
+	}
+
+	writeConvertedJSON(w, r, http.StatusOK, exercise)
+}
+
+// RemoveExercise godoc
+// @Summary Remove workout exercise
+// @Description Remove an exercise entry and all of its sets
+// @Tags workout-exercises
+// @Security BearerAuth
+// @Param id path string true "Workout ID"
+// @Param exerciseId path string true "Workout exercise ID"
+// @Success 204 "Exercise removed"
+// @Failure 404 {object} ErrorResponse "Workout or exercise not found"
+// @Router /api/v1/workouts/{id}/exercises/{exerciseId} [delete]
+func (h *WorkoutExerciseHandlers) RemoveExercise(w http.ResponseWriter, r *http.Request) {
+	path, ok := parseExercisePath(w, r, true, false)
+	if !ok {
+		return
+	}
+
+	if err := h.exerciseService.RemoveExercise(r.Context(), path.userID, path.workoutID, path.exerciseID); err != nil {
+		writeServiceError(w, h.logger, err, "Failed to remove exercise")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddSet godoc
+// @Summary Log a set
+// @Description Append a set to a workout exercise. new_records lists any personal records the set established.
+// @Tags workout-exercises
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Workout ID"
+// @Param exerciseId path string true "Workout exercise ID"
+// @Param request body models.CreateExerciseSetRequest true "Set data"
+// @Success 201 {object} models.ExerciseSet "Set logged"
+// @Failure 400 {object} ErrorResponse "Invalid request data"
+// @Failure 404 {object} ErrorResponse "Workout or exercise not found"
+// @Router /api/v1/workouts/{id}/exercises/{exerciseId}/sets [post]
+func (h *WorkoutExerciseHandlers) AddSet(w http.ResponseWriter, r *http.Request) {
+	path, ok := parseExercisePath(w, r, true, false)
+	if !ok {
+		return
+	}
+
+	var req models.CreateExerciseSetRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.WeightUnit == "" {
+		req.WeightUnit = models.WeightUnitKg
+	}
+
+	validationErrors := validateSetFields(&req.Reps, &req.Weight, &req.WeightUnit, req.RPE, req.RestSeconds)
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	set, err := h.exerciseService.AddSet(r.Context(), path.userID, path.workoutID, path.exerciseID, &req)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to add set")
+		return
+	}
+
+	writeConvertedJSON(w, r, http.StatusCreated, set)
+}
+
+// ListSets godoc
+// @Summary List sets
+// @Description List the sets of a workout exercise in order
+// @Tags workout-exercises
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Workout ID"
+// @Param exerciseId path string true "Workout exercise ID"
+// @Success 200 {object} ExerciseSetListResponse "Sets"
+// @Failure 404 {object} ErrorResponse "Workout or exercise not found"
+// @Router /api/v1/workouts/{id}/exercises/{exerciseId}/sets [get]
+func (h *WorkoutExerciseHandlers) ListSets(w http.ResponseWriter, r *http.Request) {
+	path, ok := parseExercisePath(w, r, true, false)
+	if !ok {
+		return
+	}
+
+	sets, err := h.exerciseService.ListSets(r.Context(), path.userID, path.workoutID, path.exerciseID)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to list sets")
+		return
+	}
+
+	writeConvertedJSON(w, r, http.StatusOK, ExerciseSetListResponse{Sets: sets})
+}
+
+// UpdateSet godoc
+// @Summary Update set
+// @Description Update fields of a logged set. new_records lists any personal records the updated set established.
+// @Tags workout-exercises
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Workout ID"
+// @Param exerciseId path string true "Workout exercise ID"
+// @Param setId path string true "Set ID"
+// @Param request body models.UpdateExerciseSetRequest true "Fields to update"
+// @Success 200 {object} models.ExerciseSet "Set updated"
+// @Failure 400 {object} ErrorResponse "Invalid request data"
+// @Failure 404 {object} ErrorResponse "Workout, exercise or set not found"
+// @Router /api/v1/workouts/{id}/exercises/{exerciseId}/sets/{setId} [put]
+func (h *WorkoutExerciseHandlers) UpdateSet(w http.ResponseWriter, r *http.Request) {
+	path, ok := parseExercisePath(w, r, true, true)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateExerciseSetRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	validationErrors := validateSetFields(req.Reps, req.Weight, req.WeightUnit, req.RPE, req.RestSeconds)
+	if req.Position != nil {
+		validationErrors.Add("position", validation.ValidateIntRange(*req.Position, "position", 1, maxPosition))
+	}
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	set, err := h.exerciseService.UpdateSet(r.Context(), path.userID, path.workoutID, path.exerciseID, path.setID, &req)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to update set")
+		return
+	}
+
+	writeConvertedJSON(w, r, http.StatusOK, set)
+}
+
+// RemoveSet godoc
+// @Summary Remove set
+// @Description Delete a logged set
+// @Tags workout-exercises
+// @Security BearerAuth
+// @Param id path string true "Workout ID"
+// @Param exerciseId path string true "Workout exercise ID"
+// @Param setId path string true "Set ID"
+// @Success 204 "Set removed"
+// @Failure 404 {object} ErrorResponse "Workout, exercise or set not found"
+// @Router /api/v1/workouts/{id}/exercises/{exerciseId}/sets/{setId} [delete]
+func (h *WorkoutExerciseHandlers) RemoveSet(w http.ResponseWriter, r *http.Request) {
+	path, ok := parseExercisePath(w, r, true, true)
+	if !ok {
+		return
+	}
+
+	if err := h.exerciseService.RemoveSet(r.Context(), path.userID, path.workoutID, path.exerciseID, path.setID); err != nil {
+		writeServiceError(w, h.logger, err, "Failed to remove set")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,235 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/aleksandr/strive-api/internal/validation"
+)
+
+const maxNotesLength = 2000
+
+type WorkoutHandlers struct {
+	workoutService services.WorkoutService
+	logger         *logger.Logger
+}
+
+func NewWorkoutHandlers(workoutService services.WorkoutService, logger *logger.Logger) *WorkoutHandlers {
+	return &WorkoutHandlers{
+		workoutService: workoutService,
+		logger:         logger,
+	}
+}
+
+type WorkoutListResponse struct {
+	Workouts []*models.Workout `json:"workouts"`
+	models.Pagination
+}
+
+func (resp WorkoutListResponse) ConvertWeights(unit models.WeightUnit) {
+	for _, workout := range resp.Workouts {
+		workout.ConvertWeights(unit)
+	}
+}
+
+func validateNotes(notes string) error {
+	if len(notes) > maxNotesLength {
+		return errors.New("notes too long (max 2000 characters)")
+	}
+	return nil
+}
+
+// Create godoc
+// @Summary Create workout
+// @Description Start a new workout session for the current user
+// @Tags workouts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateWorkoutRequest true "Workout data"
+// @Success 201 {object} models.Workout "Workout created"
+// @Failure 400 {object} ErrorResponse "Invalid request data"
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Router /api/v1/workouts [post]
+func (h *WorkoutHandlers) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.CreateWorkoutRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var validationErrors validation.ValidationErrors
+	validationErrors.Add("title", validation.ValidateString(req.Title, "title", 1, 255))
+	validationErrors.Add("notes", validateNotes(req.Notes))
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	workout, err := h.workoutService.Create(r.Context(), userID, &req)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to create workout")
+		return
+	}
+
+	h.logger.Info("Workout created", "user_id", userID, "workout_id", workout.ID)
+
+	writeConvertedJSON(w, r, http.StatusCreated, workout)
+}
+
+// List godoc
+// @Summary List workouts
+// @Description List the current user's workouts, most recent first
+// @Tags workouts
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Page size (max 100)"
+// @Param offset query int false "Page offset"
+// @Success 200 {object} WorkoutListResponse "Workouts"
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Router /api/v1/workouts [get]
+func (h *WorkoutHandlers) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	page := queryPagination(r)
+
+	workouts, err := h.workoutService.List(r.Context(), userID, page)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to list workouts")
+		return
+	}
+
+	writeConvertedJSON(w, r, http.StatusOK, WorkoutListResponse{
+		Workouts:   workouts,
+		Pagination: page,
+	})
+}
+
+// Get godoc
+// @Summary Get workout
+// @Description Get a single workout owned by the current user
+// @Tags workouts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Workout ID"
+// @Success 200 {object} models.Workout "Workout"
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Workout not found"
+// @Router /api/v1/workouts/{id} [get]
+func (h *WorkoutHandlers) Get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	workoutID, ok := pathUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	workout, err := h.workoutService.Get(r.Context(), userID, workoutID)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to get workout")
+		return
+	}
+
+	writeConvertedJSON(w, r, http.StatusOK, workout)
+}
+
+// Update godoc
+// @Summary Update workout
+// @Description Update fields of a workout owned by the current user; clear_finished_at reopens a finished workout
+// @Tags workouts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Workout ID"
+// @Param request body models.UpdateWorkoutRequest true "Fields to update"
+// @Success 200 {object} models.Workout "Workout updated"
+// @Failure 400 {object} ErrorResponse "Invalid request data"
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Workout not found"
+// @Router /api/v1/workouts/{id} [put]
+func (h *WorkoutHandlers) Update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	workoutID, ok := pathUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.UpdateWorkoutRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	var validationErrors validation.ValidationErrors
+	if req.Title != nil {
+		validationErrors.Add("title", validation.ValidateString(*req.Title, "title", 1, 255))
+	}
+	if req.Notes != nil {
+		validationErrors.Add("notes", validateNotes(*req.Notes))
+	}
+	if req.FinishedAt != nil && req.ClearFinishedAt {
+		validationErrors = append(validationErrors, validation.ValidationError{
+			Field:   "clear_finished_at",
+			Message: "clear_finished_at cannot be combined with finished_at",
+		})
+	}
+	if len(validationErrors) > 0 {
+		writeValidationErrors(w, validationErrors)
+		return
+	}
+
+	workout, err := h.workoutService.Update(r.Context(), userID, workoutID, &req)
+	if err != nil {
+		writeServiceError(w, h.logger, err, "Failed to update workout")
+		return
+	}
+
+	writeConvertedJSON(w, r, http.StatusOK, workout)
+}
+
+// Delete godoc
+// @Summary Delete workout
+// @Description Delete a workout owned by the current user
+// @Tags workouts
+// @Security BearerAuth
+// @Param id path string true "Workout ID"
+// @Success 204 "Workout deleted"
+// @Failure 401 {object} AuthError "Unauthorized"
+// @Failure 404 {object} ErrorResponse "Workout not found"
+// @Router /api/v1/workouts/{id} [delete]
+func (h *WorkoutHandlers) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := currentUserID(w, r)
+	if !ok {
+		return
+	}
+
+	workoutID, ok := pathUUID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.workoutService.Delete(r.Context(), userID, workoutID); err != nil {
+		writeServiceError(w, h.logger, err, "Failed to delete workout")
+		return
+	}
+
+	h.logger.Info("Workout deleted", "user_id", userID, "workout_id", workoutID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
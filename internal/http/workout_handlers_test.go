@@ -0,0 +1,193 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func withUserID(req *http.Request, userID uuid.UUID) *http.Request {
+	ctx := context.WithValue(req.Context(), UserIDKey, userID.String())
+	return req.WithContext(ctx)
+}
+
+func TestWorkoutHandlers_Create(t *testing.T) {
+	logger := logger.New("INFO", "json")
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockSetup      func(*MockWorkoutService)
+		expectedStatus int
+	}{
+		{
+			name:        "successful creation",
+			requestBody: map[string]string{"title": "Push day"},
+			mockSetup: func(m *MockWorkoutService) {
+				m.On("Create", mock.Anything, userID, mock.AnythingOfType("*models.CreateWorkoutRequest")).
+					Return(&models.Workout{ID: uuid.New(), UserID: userID, Title: "Push day"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "missing title",
+			requestBody:    map[string]string{"notes": "no title"},
+			mockSetup:      func(m *MockWorkoutService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "finish before start",
+			requestBody: map[string]string{"title": "Push day"},
+			mockSetup: func(m *MockWorkoutService) {
+				m.On("Create", mock.Anything, userID, mock.AnythingOfType("*models.CreateWorkoutRequest")).
+					Return(nil, services.ErrInvalidWorkoutTimes)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockWorkoutService)
+			tt.mockSetup(mockService)
+			handlers := NewWorkoutHandlers(mockService, logger)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/workouts", bytes.NewReader(body))
+			req = withUserID(req, userID)
+
+			rr := httptest.NewRecorder()
+			handlers.Create(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWorkoutHandlers_Get(t *testing.T) {
+	logger := logger.New("INFO", "json")
+	userID := uuid.New()
+	workoutID := uuid.New()
+
+	tests := []struct {
+		name           string
+		pathID         string
+		mockSetup      func(*MockWorkoutService)
+		expectedStatus int
+	}{
+		{
+			name:   "found",
+			pathID: workoutID.String(),
+			mockSetup: func(m *MockWorkoutService) {
+				m.On("Get", mock.Anything, userID, workoutID).
+					Return(&models.Workout{ID: workoutID, UserID: userID}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "not found",
+			pathID: workoutID.String(),
+			mockSetup: func(m *MockWorkoutService) {
+				m.On("Get", mock.Anything, userID, workoutID).Return(nil, services.ErrWorkoutNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid id",
+			pathID:         "not-a-uuid",
+			mockSetup:      func(m *MockWorkoutService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockWorkoutService)
+			tt.mockSetup(mockService)
+			handlers := NewWorkoutHandlers(mockService, logger)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/workouts/"+tt.pathID, http.NoBody)
+			req.SetPathValue("id", tt.pathID)
+			req = withUserID(req, userID)
+
+			rr := httptest.NewRecorder()
+			handlers.Get(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWorkoutHandlers_Update(t *testing.T) {
+	logger := logger.New("INFO", "json")
+	userID := uuid.New()
+	workoutID := uuid.New()
+
+	t.Run("ClearFinishedAt", func(t *testing.T) {
+		mockService := new(MockWorkoutService)
+		mockService.On("Update", mock.Anything, userID, workoutID, mock.MatchedBy(func(req *models.UpdateWorkoutRequest) bool {
+			return req.ClearFinishedAt && req.FinishedAt == nil
+		})).Return(&models.Workout{ID: workoutID, UserID: userID}, nil)
+		handlers := NewWorkoutHandlers(mockService, logger)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/workouts/"+workoutID.String(), strings.NewReader(`{"clear_finished_at":true}`))
+		req.SetPathValue("id", workoutID.String())
+		rr := httptest.NewRecorder()
+		handlers.Update(rr, withUserID(req, userID))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("ClearAndSetFinishedAt", func(t *testing.T) {
+		mockService := new(MockWorkoutService)
+		handlers := NewWorkoutHandlers(mockService, logger)
+
+		body := `{"finished_at":"2025-01-01T19:15:00Z","clear_finished_at":true}`
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/workouts/"+workoutID.String(), strings.NewReader(body))
+		req.SetPathValue("id", workoutID.String())
+		rr := httptest.NewRecorder()
+		handlers.Update(rr, withUserID(req, userID))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockService.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestWorkoutHandlers_List(t *testing.T) {
+	logger := logger.New("INFO", "json")
+	userID := uuid.New()
+
+	mockService := new(MockWorkoutService)
+	mockService.On("List", mock.Anything, userID, models.Pagination{Limit: 100, Offset: 10}).
+		Return([]*models.Workout{{ID: uuid.New(), UserID: userID}}, nil)
+	handlers := NewWorkoutHandlers(mockService, logger)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/workouts?limit=500&offset=10", http.NoBody)
+	req = withUserID(req, userID)
+
+	rr := httptest.NewRecorder()
+	handlers.List(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response WorkoutListResponse
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Len(t, response.Workouts, 1)
+	assert.Equal(t, 100, response.Limit)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,103 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/config"
+	"github.com/aleksandr/strive-api/internal/logger"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender delivers transactional email. Implementations must be safe for concurrent use.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// NewSender returns the sender selected by cfg.Driver.
+func NewSender(cfg *config.MailConfig, log *logger.Logger) (Sender, error) {
+	switch cfg.Driver {
+	case "log":
+		return NewLogSender(cfg.From, log), nil
+	case "file":
+		return NewFileSender(cfg.From, cfg.OutputDir)
+	default:
+		return nil, fmt.Errorf("unknown mail driver: %s", cfg.Driver)
+	}
+}
+
+type logSender struct {
+	from   string
+	logger *logger.Logger
+}
+
+// NewLogSender writes every message to the application log. Intended for local
+// development only, since message bodies contain one-time links.
+func NewLogSender(from string, log *logger.Logger) Sender {
+	return &logSender{
+		from:   from,
+		logger: log,
+	}
+}
+
+func (s *logSender) Send(ctx context.Context, msg Message) error {
+	s.logger.Info("Email sent",
+		"from", s.from,
+		"to", msg.To,
+		"subject", msg.Subject,
+		"body", msg.Body,
+	)
+	return nil
+}
+
+type fileSender struct {
+	from string
+	dir  string
+	mu   sync.Mutex
+	seq  int
+}
+
+// NewFileSender writes every message as an .eml file in dir, creating it if needed.
+func NewFileSender(from, dir string) (Sender, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create mail directory: %w", err)
+	}
+	return &fileSender{
+		from: from,
+		dir:  dir,
+	}, nil
+}
+
+func (s *fileSender) Send(ctx context.Context, msg Message) error {
+	s.mu.Lock()
+	s.seq++
+	seq := s.seq
+	s.mu.Unlock()
+
+	now := time.Now().UTC()
+	recipient := strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(msg.To)
+	name := fmt.Sprintf("%s-%04d-%s.eml", now.Format("20060102T150405"), seq, recipient)
+
+	var content strings.Builder
+	fmt.Fprintf(&content, "From: %s\r\n", s.from)
+	fmt.Fprintf(&content, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&content, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&content, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	content.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	content.WriteString(msg.Body)
+
+	if err := os.WriteFile(filepath.Join(s.dir, name), []byte(content.String()), 0o600); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	return nil
+}
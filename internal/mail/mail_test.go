@@ -0,0 +1,49 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aleksandr/strive-api/internal/config"
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSender_WritesMessage(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	sender, err := NewFileSender("Strive <no-reply@strive.local>", dir)
+	require.NoError(t, err)
+
+	err = sender.Send(context.Background(), Message{
+		To:      "user@example.com",
+		Subject: "Reset your password",
+		Body:    "https://app.example.com/reset-password?token=abc",
+	})
+	require.NoError(t, err)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.True(t, strings.HasSuffix(entries[0].Name(), "user_at_example.com.eml"))
+
+	content, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "To: user@example.com\r\n")
+	assert.Contains(t, string(content), "Subject: Reset your password\r\n")
+	assert.True(t, strings.HasSuffix(string(content), "\r\n\r\nhttps://app.example.com/reset-password?token=abc"))
+}
+
+func TestNewSender(t *testing.T) {
+	log := logger.New("INFO", "json")
+
+	sender, err := NewSender(&config.MailConfig{Driver: "log"}, log)
+	require.NoError(t, err)
+	assert.NoError(t, sender.Send(context.Background(), Message{To: "user@example.com"}))
+
+	_, err = NewSender(&config.MailConfig{Driver: "smtp"}, log)
+	assert.Error(t, err)
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audit event types.
+const (
+	AuditEventAdminUsersListed       = "admin.users_listed"
+	AuditEventAdminUserViewed        = "admin.user_viewed"
+	AuditEventAdminSessionsViewed    = "admin.sessions_viewed"
+	AuditEventAdminUserLoggedOut     = "admin.user_logged_out"
+	AuditEventAdminUserDisabled      = "admin.user_disabled"
+	AuditEventAdminUserEnabled       = "admin.user_enabled"
+	AuditEventAdminPasswordResetSent = "admin.password_reset_sent"
+	AuditEventAdminUserDeleted       = "admin.user_deleted"
+	AuditEventAdminAuditSearched     = "admin.audit_searched"
+
+	AuditEventLogin              = "auth.login"
+	AuditEventLoginFailed        = "auth.login_failed"
+	AuditEventTokenRefreshed     = "auth.token_refreshed"
+	AuditEventRefreshTokenReused = "auth.refresh_token_reused"
+	AuditEventLogout             = "auth.logout"
+	AuditEventPasswordChanged    = "auth.password_changed"
+	AuditEventPasswordReset      = "auth.password_reset"
+	AuditEventEmailChanged       = "auth.email_changed"
+	AuditEventMFAEnrolled        = "auth.2fa_enrolled"
+	AuditEventMFAEnabled         = "auth.2fa_enabled"
+	AuditEventMFADisabled        = "auth.2fa_disabled"
+	AuditEventRecoveryCodeUsed   = "auth.2fa_recovery_code_used"
+
+	AuditEventRateLimitExceeded = "security.rate_limit_exceeded"
+)
+
+var AuditEventTypes = []string{
+	AuditEventAdminUsersListed, AuditEventAdminUserViewed, AuditEventAdminSessionsViewed, AuditEventAdminUserLoggedOut,
+	AuditEventAdminUserDisabled, AuditEventAdminUserEnabled, AuditEventAdminPasswordResetSent, AuditEventAdminUserDeleted,
+	AuditEventAdminAuditSearched,
+	AuditEventLogin, AuditEventLoginFailed, AuditEventTokenRefreshed, AuditEventRefreshTokenReused, AuditEventLogout,
+	AuditEventPasswordChanged, AuditEventPasswordReset, AuditEventEmailChanged,
+	AuditEventMFAEnrolled, AuditEventMFAEnabled, AuditEventMFADisabled, AuditEventRecoveryCodeUsed,
+	AuditEventRateLimitExceeded,
+}
+
+// AuditEvent is an append-only record of something done to an account. The
+// actor and target are kept as plain IDs so events outlive deleted users.
+type AuditEvent struct {
+	ID           uuid.UUID      `json:"id" db:"id"`
+	Type         string         `json:"type" db:"event_type"`
+	ActorID      *uuid.UUID     `json:"actor_id,omitempty" db:"actor_id"`
+	TargetUserID *uuid.UUID     `json:"target_user_id,omitempty" db:"target_user_id"`
+	IPAddress    string         `json:"ip_address" db:"ip_address"`
+	UserAgent    string         `json:"user_agent" db:"user_agent"`
+	RequestID    string         `json:"request_id" db:"request_id"`
+	Details      map[string]any `json:"details,omitempty" db:"details"`
+	CreatedAt    time.Time      `json:"created_at" db:"created_at"`
+}
+
+// AuditRequest is the request an audited action arrived with.
+type AuditRequest struct {
+	IPAddress string
+	UserAgent string
+	RequestID string
+}
+
+// AuditActor is who performed an audited action, and from where.
+type AuditActor struct {
+	UserID uuid.UUID
+	AuditRequest
+}
+
+// AuditFilter narrows an audit log search. UserID matches events concerning a
+// user, whoever performed them; ActorID matches events a user performed.
+type AuditFilter struct {
+	UserID  *uuid.UUID
+	ActorID *uuid.UUID
+	Types   []string
+	From    *time.Time
+	To      *time.Time
+	Pagination
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var MuscleGroups = []string{
+	"chest", "upper_back", "lats", "traps", "lower_back", "shoulders", "rear_delts",
+	"biceps", "triceps", "forearms", "abs", "obliques", "glutes", "quadriceps",
+	"hamstrings", "adductors", "calves", "full_body",
+}
+
+var Equipment = []string{
+	"barbell", "dumbbell", "kettlebell", "machine", "cable", "bodyweight", "band", "smith_machine", "other",
+}
+
+var MovementPatterns = []string{
+	"horizontal_push", "vertical_push", "horizontal_pull", "vertical_pull",
+	"squat", "hinge", "lunge", "carry", "isolation", "core",
+}
+
+type Exercise struct {
+	ID               uuid.UUID  `json:"id" db:"id"`
+	OwnerID          *uuid.UUID `json:"owner_id,omitempty" db:"owner_id"`
+	Name             string     `json:"name" db:"name"`
+	PrimaryMuscles   []string   `json:"primary_muscles" db:"primary_muscles"`
+	SecondaryMuscles []string   `json:"secondary_muscles" db:"secondary_muscles"`
+	Equipment        string     `json:"equipment" db:"equipment"`
+	MovementPattern  string     `json:"movement_pattern" db:"movement_pattern"`
+	IsUnilateral     bool       `json:"is_unilateral" db:"is_unilateral"`
+	IsCustom         bool       `json:"is_custom" db:"-"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+type ExerciseFilter struct {
+	Query           string
+	Muscle          string
+	Equipment       string
+	MovementPattern string
+	CustomOnly      bool
+	Pagination
+}
+
+type CreateExerciseRequest struct {
+	Name             string   `json:"name" validate:"required,max=255" example:"Landmine press"`
+	PrimaryMuscles   []string `json:"primary_muscles" validate:"required,min=1" example:"shoulders"`
+	SecondaryMuscles []string `json:"secondary_muscles" example:"triceps"`
+	Equipment        string   `json:"equipment" validate:"required" example:"barbell"`
+	MovementPattern  string   `json:"movement_pattern" validate:"required" example:"vertical_push"`
+	IsUnilateral     bool     `json:"is_unilateral" example:"true"`
+}
+
+type UpdateExerciseRequest struct {
+	Name             *string   `json:"name,omitempty" validate:"omitempty,max=255" example:"Landmine press"`
+	PrimaryMuscles   *[]string `json:"primary_muscles,omitempty" example:"shoulders"`
+	SecondaryMuscles *[]string `json:"secondary_muscles,omitempty" example:"triceps"`
+	Equipment        *string   `json:"equipment,omitempty" example:"barbell"`
+	MovementPattern  *string   `json:"movement_pattern,omitempty" example:"vertical_push"`
+	IsUnilateral     *bool     `json:"is_unilateral,omitempty" example:"true"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Scopes failed sign-ins are counted in.
+const (
+	LoginFailureScopeAccount = "account"
+	LoginFailureScopeIP      = "ip"
+	// LoginFailureScopeStepUp counts wrong codes entered from a signed-in
+	// session to confirm a sensitive change, keyed by user ID.
+	LoginFailureScopeStepUp = "step_up"
+)
+
+// LoginFailure counts recent failed sign-ins for one account email or one IP
+// address, along with attempts still being checked. Key is the normalized
+// email, whether or not an account uses it.
+type LoginFailure struct {
+	Scope         string    `json:"scope" db:"scope"`
+	Key           string    `json:"key" db:"key"`
+	Failures      int       `json:"failures" db:"failures"`
+	LastFailureAt time.Time `json:"last_failure_at" db:"last_failure_at"`
+	// PreviousFailureAt is LastFailureAt before the latest attempt was counted;
+	// releasing that attempt restores it.
+	PreviousFailureAt *time.Time `json:"previous_failure_at,omitempty" db:"previous_failure_at"`
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LengthUnit string
+
+const (
+	LengthUnitCm LengthUnit = "cm"
+	LengthUnitIn LengthUnit = "in"
+)
+
+const centimetersPerInch = 2.54
+
+// ConvertLength converts a length between units; unknown units pass through unchanged.
+func ConvertLength(length float64, from, to LengthUnit) float64 {
+	switch {
+	case from == to:
+		return length
+	case from == LengthUnitIn && to == LengthUnitCm:
+		return length * centimetersPerInch
+	case from == LengthUnitCm && to == LengthUnitIn:
+		return length / centimetersPerInch
+	default:
+		return length
+	}
+}
+
+var CircumferenceSites = []string{
+	"neck", "shoulders", "chest", "waist", "hips",
+	"left_arm", "right_arm", "left_forearm", "right_forearm",
+	"left_thigh", "right_thigh", "left_calf", "right_calf",
+}
+
+// Trend metrics besides the circumference sites.
+const (
+	MeasurementMetricBodyweight = "bodyweight"
+	MeasurementMetricBodyFat    = "body_fat"
+)
+
+type Measurement struct {
+	ID             uuid.UUID          `json:"id" db:"id"`
+	UserID         uuid.UUID          `json:"user_id" db:"user_id"`
+	MeasuredAt     time.Time          `json:"measured_at" db:"measured_at"`
+	Bodyweight     *float64           `json:"bodyweight,omitempty" db:"bodyweight"`
+	WeightUnit     WeightUnit         `json:"weight_unit" db:"weight_unit"`
+	BodyFatPercent *float64           `json:"body_fat_percent,omitempty" db:"body_fat_percent"`
+	Circumferences map[string]float64 `json:"circumferences" db:"circumferences"`
+	LengthUnit     LengthUnit         `json:"length_unit" db:"length_unit"`
+	Notes          string             `json:"notes" db:"notes"`
+	CreatedAt      time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+type CreateMeasurementRequest struct {
+	MeasuredAt     *time.Time         `json:"measured_at,omitempty" example:"2025-01-01T07:30:00Z"`
+	Bodyweight     *float64           `json:"bodyweight,omitempty" validate:"omitempty,gt=0" example:"82.4"`
+	WeightUnit     WeightUnit         `json:"weight_unit" validate:"omitempty,oneof=kg lb" example:"kg"`
+	BodyFatPercent *float64           `json:"body_fat_percent,omitempty" validate:"omitempty,gt=0,lt=100" example:"15.5"`
+	Circumferences map[string]float64 `json:"circumferences,omitempty"`
+	LengthUnit     LengthUnit         `json:"length_unit" validate:"omitempty,oneof=cm in" example:"cm"`
+	Notes          string             `json:"notes" validate:"max=2000" example:"Morning, fasted"`
+}
+
+// UpdateMeasurementRequest replaces the fields that are present; circumferences
+// replaces the whole map.
+type UpdateMeasurementRequest struct {
+	MeasuredAt     *time.Time          `json:"measured_at,omitempty" example:"2025-01-01T07:30:00Z"`
+	Bodyweight     *float64            `json:"bodyweight,omitempty" validate:"omitempty,gt=0" example:"82.4"`
+	WeightUnit     *WeightUnit         `json:"weight_unit,omitempty" validate:"omitempty,oneof=kg lb" example:"kg"`
+	BodyFatPercent *float64            `json:"body_fat_percent,omitempty" validate:"omitempty,gt=0,lt=100" example:"15.5"`
+	Circumferences *map[string]float64 `json:"circumferences,omitempty"`
+	LengthUnit     *LengthUnit         `json:"length_unit,omitempty" validate:"omitempty,oneof=cm in" example:"cm"`
+	Notes          *string             `json:"notes,omitempty" validate:"omitempty,max=2000" example:"Morning, fasted"`
+}
+
+type MeasurementFilter struct {
+	From *time.Time
+	To   *time.Time
+	Pagination
+}
+
+type TrendPoint struct {
+	MeasuredAt    time.Time `json:"measured_at"`
+	Value         float64   `json:"value" example:"82.4"`
+	MovingAverage float64   `json:"moving_average" example:"82.1"`
+}
+
+// MeasurementTrend reports one metric in kg (or the caller's preferred weight
+// unit), percent or cm with a trailing moving average over WindowDays.
+type MeasurementTrend struct {
+	Metric     string       `json:"metric" example:"bodyweight"`
+	Unit       string       `json:"unit" example:"kg"`
+	WindowDays int          `json:"window_days" example:"7"`
+	Points     []TrendPoint `json:"points"`
+	Change     *float64     `json:"change,omitempty" example:"-1.8"`
+}
+
+func (m *Measurement) ConvertWeights(unit WeightUnit) {
+	if unit == "" {
+		return
+	}
+	if m.Bodyweight != nil {
+		converted := displayWeight(*m.Bodyweight, m.WeightUnit, unit)
+		m.Bodyweight = &converted
+	}
+	m.WeightUnit = unit
+}
+
+// ConvertWeights converts a bodyweight trend (unit kg) to unit.
+func (t *MeasurementTrend) ConvertWeights(unit WeightUnit) {
+	if unit == "" || t.Unit != string(WeightUnitKg) {
+		return
+	}
+	for i := range t.Points {
+		t.Points[i].Value = displayWeight(t.Points[i].Value, WeightUnitKg, unit)
+		t.Points[i].MovingAverage = displayWeight(t.Points[i].MovingAverage, WeightUnitKg, unit)
+	}
+	if t.Change != nil {
+		change := displayWeight(*t.Change, WeightUnitKg, unit)
+		t.Change = &change
+	}
+	t.Unit = string(unit)
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TOTPCredential is a user's authenticator app secret. Two-factor
+// authentication is on once the enrollment is confirmed with a first code.
+type TOTPCredential struct {
+	UserID      uuid.UUID  `json:"user_id" db:"user_id"`
+	Secret      string     `json:"-" db:"secret"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty" db:"confirmed_at"`
+	// LastUsedStep is the time step of the last accepted code; codes from it
+	// or earlier steps are rejected.
+	LastUsedStep int64     `json:"-" db:"last_used_step"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// IsConfirmed reports whether the credential is required at sign-in.
+func (c *TOTPCredential) IsConfirmed() bool {
+	return c.ConfirmedAt != nil
+}
+
+// RecoveryCode is a single-use substitute for a TOTP code. Only the SHA-256
+// digest of the code is stored.
+type RecoveryCode struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	UserID    uuid.UUID  `json:"user_id" db:"user_id"`
+	CodeHash  string     `json:"-" db:"code_hash"`
+	UsedAt    *time.Time `json:"used_at,omitempty" db:"used_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// TOTPEnrollment is what an authenticator app needs to start generating codes.
+type TOTPEnrollment struct {
+	Secret string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	URI    string `json:"otpauth_uri" example:"otpauth://totp/Strive:user@example.com?issuer=Strive&secret=JBSWY3DPEHPK3PXP"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"k3m9q-x7t2p,4hd8w-n6cvz"`
+}
+
+type TOTPCodeRequest struct {
+	// Code is a 6-digit TOTP code or, where accepted, a recovery code.
+	Code string `json:"code" validate:"required" example:"123456"`
+}
@@ -0,0 +1,24 @@
+package models
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+type Pagination struct {
+	Limit  int `json:"limit" example:"20"`
+	Offset int `json:"offset" example:"0"`
+}
+
+func (p Pagination) Normalize() Pagination {
+	if p.Limit <= 0 {
+		p.Limit = DefaultPageLimit
+	}
+	if p.Limit > MaxPageLimit {
+		p.Limit = MaxPageLimit
+	}
+	if p.Offset < 0 {
+		p.Offset = 0
+	}
+	return p
+}
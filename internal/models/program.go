@@ -0,0 +1,164 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProgressionType string
+
+const (
+	// ProgressionNone uses the template target weights as written.
+	ProgressionNone ProgressionType = "none"
+	// ProgressionFixedIncrement adds the program increment to every target weight once per week.
+	ProgressionFixedIncrement ProgressionType = "fixed_increment"
+	// ProgressionPercentOfMax derives target weights from the enrollment's training maxes
+	// and the day's intensity percentage.
+	ProgressionPercentOfMax ProgressionType = "percent_of_max"
+)
+
+var ProgressionTypes = []string{
+	string(ProgressionNone),
+	string(ProgressionFixedIncrement),
+	string(ProgressionPercentOfMax),
+}
+
+type EnrollmentStatus string
+
+const (
+	EnrollmentStatusActive    EnrollmentStatus = "active"
+	EnrollmentStatusCompleted EnrollmentStatus = "completed"
+	EnrollmentStatusCancelled EnrollmentStatus = "cancelled"
+)
+
+type Program struct {
+	ID          uuid.UUID       `json:"id" db:"id"`
+	UserID      uuid.UUID       `json:"user_id" db:"user_id"`
+	Name        string          `json:"name" db:"name"`
+	Description string          `json:"description" db:"description"`
+	Weeks       int             `json:"weeks" db:"weeks"`
+	DaysPerWeek int             `json:"days_per_week" db:"days_per_week"`
+	IsPublic    bool            `json:"is_public" db:"is_public"`
+	Progression ProgressionType `json:"progression" db:"progression"`
+	Increment   float64         `json:"increment" db:"increment"`
+	// IncrementUnit is the unit Increment is in; it is converted to each exercise's unit.
+	IncrementUnit WeightUnit    `json:"increment_unit" db:"increment_unit"`
+	DeloadWeeks   []int         `json:"deload_weeks" db:"deload_weeks"`
+	DeloadPercent float64       `json:"deload_percent" db:"deload_percent"`
+	LoadRounding  float64       `json:"load_rounding" db:"load_rounding"`
+	Days          []*ProgramDay `json:"days" db:"-"`
+	CreatedAt     time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// IsDeloadWeek reports whether the given 1-based week is a deload week.
+func (p *Program) IsDeloadWeek(week int) bool {
+	for _, deload := range p.DeloadWeeks {
+		if deload == week {
+			return true
+		}
+	}
+	return false
+}
+
+type ProgramDay struct {
+	ID               uuid.UUID `json:"id" db:"id"`
+	ProgramID        uuid.UUID `json:"program_id" db:"program_id"`
+	Week             int       `json:"week" db:"week"`
+	Day              int       `json:"day" db:"day"`
+	TemplateID       uuid.UUID `json:"template_id" db:"template_id"`
+	IntensityPercent *float64  `json:"intensity_percent,omitempty" db:"intensity_percent"`
+}
+
+type ProgramDayInput struct {
+	Week             int       `json:"week" validate:"required,min=1" example:"1"`
+	Day              int       `json:"day" validate:"required,min=1" example:"1"`
+	TemplateID       uuid.UUID `json:"template_id" validate:"required" example:"3fa85f64-5717-4562-b3fc-2c963f66afa6"`
+	IntensityPercent *float64  `json:"intensity_percent,omitempty" validate:"omitempty,min=1,max=120" example:"75"`
+}
+
+type CreateProgramRequest struct {
+	Name          string            `json:"name" validate:"required,max=255" example:"12-week strength"`
+	Description   string            `json:"description" validate:"max=2000" example:"Four days a week, linear progression"`
+	Weeks         int               `json:"weeks" validate:"required,min=1,max=52" example:"12"`
+	DaysPerWeek   int               `json:"days_per_week" validate:"required,min=1,max=7" example:"4"`
+	IsPublic      bool              `json:"is_public" example:"false"`
+	Progression   ProgressionType   `json:"progression" validate:"omitempty,oneof=none fixed_increment percent_of_max"`
+	Increment     float64           `json:"increment" validate:"min=0" example:"2.5"`
+	IncrementUnit WeightUnit        `json:"increment_unit" validate:"omitempty,oneof=kg lb" example:"kg"`
+	DeloadWeeks   []int             `json:"deload_weeks" example:"4,8"`
+	DeloadPercent *float64          `json:"deload_percent,omitempty" validate:"omitempty,min=1,max=100" example:"60"`
+	LoadRounding  *float64          `json:"load_rounding,omitempty" validate:"omitempty,min=0" example:"2.5"`
+	Days          []ProgramDayInput `json:"days" validate:"required,min=1"`
+}
+
+type UpdateProgramRequest struct {
+	Name          *string            `json:"name,omitempty" validate:"omitempty,max=255" example:"12-week strength"`
+	Description   *string            `json:"description,omitempty" validate:"omitempty,max=2000" example:"Four days a week"`
+	Weeks         *int               `json:"weeks,omitempty" validate:"omitempty,min=1,max=52" example:"12"`
+	DaysPerWeek   *int               `json:"days_per_week,omitempty" validate:"omitempty,min=1,max=7" example:"4"`
+	IsPublic      *bool              `json:"is_public,omitempty" example:"true"`
+	Progression   *ProgressionType   `json:"progression,omitempty" example:"percent_of_max"`
+	Increment     *float64           `json:"increment,omitempty" validate:"omitempty,min=0" example:"2.5"`
+	IncrementUnit *WeightUnit        `json:"increment_unit,omitempty" validate:"omitempty,oneof=kg lb" example:"kg"`
+	DeloadWeeks   *[]int             `json:"deload_weeks,omitempty" example:"4,8"`
+	DeloadPercent *float64           `json:"deload_percent,omitempty" validate:"omitempty,min=1,max=100" example:"60"`
+	LoadRounding  *float64           `json:"load_rounding,omitempty" validate:"omitempty,min=0" example:"2.5"`
+	Days          *[]ProgramDayInput `json:"days,omitempty"`
+}
+
+type TrainingMax struct {
+	ExerciseID uuid.UUID  `json:"exercise_id" db:"exercise_id" example:"3fa85f64-5717-4562-b3fc-2c963f66afa6"`
+	Weight     float64    `json:"weight" db:"weight" validate:"required,min=0" example:"140"`
+	WeightUnit WeightUnit `json:"weight_unit" db:"weight_unit" validate:"omitempty,oneof=kg lb" example:"kg"`
+}
+
+type Enrollment struct {
+	ID            uuid.UUID        `json:"id" db:"id"`
+	UserID        uuid.UUID        `json:"user_id" db:"user_id"`
+	ProgramID     uuid.UUID        `json:"program_id" db:"program_id"`
+	Status        EnrollmentStatus `json:"status" db:"status"`
+	CompletedDays int              `json:"completed_days" db:"completed_days"`
+	TrainingMaxes []TrainingMax    `json:"training_maxes" db:"-"`
+	CreatedAt     time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+type EnrollRequest struct {
+	TrainingMaxes []TrainingMax `json:"training_maxes"`
+}
+
+// ScheduledWorkout is the next program day for an enrollment with target loads
+// already resolved from the program's progression rule.
+type ScheduledWorkout struct {
+	EnrollmentID uuid.UUID           `json:"enrollment_id"`
+	ProgramID    uuid.UUID           `json:"program_id"`
+	ProgramName  string              `json:"program_name"`
+	Week         int                 `json:"week"`
+	Day          int                 `json:"day"`
+	DayNumber    int                 `json:"day_number"`
+	TotalDays    int                 `json:"total_days"`
+	IsDeload     bool                `json:"is_deload"`
+	TemplateID   uuid.UUID           `json:"template_id"`
+	Name         string              `json:"name"`
+	Notes        string              `json:"notes"`
+	Exercises    []*TemplateExercise `json:"exercises"`
+}
+
+func (e *Enrollment) ConvertWeights(unit WeightUnit) {
+	if unit == "" {
+		return
+	}
+	for i := range e.TrainingMaxes {
+		trainingMax := &e.TrainingMaxes[i]
+		trainingMax.Weight = displayWeight(trainingMax.Weight, trainingMax.WeightUnit, unit)
+		trainingMax.WeightUnit = unit
+	}
+}
+
+func (w *ScheduledWorkout) ConvertWeights(unit WeightUnit) {
+	for _, exercise := range w.Exercises {
+		exercise.ConvertWeights(unit)
+	}
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RecordType string
+
+const (
+	RecordTypeMaxWeight     RecordType = "max_weight"
+	RecordTypeEstimated1RM  RecordType = "estimated_1rm"
+	RecordTypeMaxReps       RecordType = "max_reps"
+	RecordTypeSessionVolume RecordType = "session_volume"
+)
+
+var RecordTypes = []string{
+	string(RecordTypeMaxWeight),
+	string(RecordTypeEstimated1RM),
+	string(RecordTypeMaxReps),
+	string(RecordTypeSessionVolume),
+}
+
+type E1RMFormula string
+
+const (
+	E1RMFormulaEpley   E1RMFormula = "epley"
+	E1RMFormulaBrzycki E1RMFormula = "brzycki"
+)
+
+var E1RMFormulas = []string{
+	string(E1RMFormulaEpley),
+	string(E1RMFormulaBrzycki),
+}
+
+// PersonalRecord is one entry in a user's PR history for a catalog exercise.
+// Value is in kilograms (kg x reps for session volume), except for max_reps
+// where it is the rep count at WeightKg. ConvertWeights re-expresses Value and
+// Weight in another unit for output; WeightKg always stays in kilograms.
+type PersonalRecord struct {
+	ID           uuid.UUID   `json:"id" db:"id"`
+	UserID       uuid.UUID   `json:"user_id" db:"user_id"`
+	ExerciseID   uuid.UUID   `json:"exercise_id" db:"exercise_id"`
+	ExerciseName string      `json:"exercise_name" db:"-"`
+	WorkoutID    uuid.UUID   `json:"workout_id" db:"workout_id"`
+	SetID        *uuid.UUID  `json:"set_id,omitempty" db:"set_id"`
+	RecordType   RecordType  `json:"record_type" db:"record_type"`
+	Formula      E1RMFormula `json:"formula,omitempty" db:"formula"`
+	Value        float64     `json:"value" db:"value"`
+	WeightKg     float64     `json:"weight_kg" db:"weight_kg"`
+	Weight       float64     `json:"weight" db:"weight"`
+	WeightUnit   WeightUnit  `json:"weight_unit" db:"weight_unit"`
+	Reps         int         `json:"reps" db:"reps"`
+	AchievedAt   time.Time   `json:"achieved_at" db:"achieved_at"`
+	CreatedAt    time.Time   `json:"created_at" db:"created_at"`
+}
+
+type RecordFilter struct {
+	ExerciseID *uuid.UUID
+	RecordType RecordType
+	Formula    E1RMFormula
+	// History returns every record ever set instead of only the current bests.
+	History bool
+	Pagination
+}
+
+func (r *PersonalRecord) ConvertWeights(unit WeightUnit) {
+	if unit == "" {
+		return
+	}
+	if r.RecordType != RecordTypeMaxReps {
+		r.Value = displayWeight(r.Value, WeightUnitKg, unit)
+	}
+	r.Weight = displayWeight(r.Weight, r.WeightUnit, unit)
+	r.WeightUnit = unit
+}
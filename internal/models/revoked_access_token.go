@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RevokedAccessToken blocks one access token, identified by its jti claim,
+// until the token would have expired anyway.
+type RevokedAccessToken struct {
+	JTI       uuid.UUID `json:"jti" db:"jti"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
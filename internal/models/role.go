@@ -0,0 +1,20 @@
+package models
+
+// Role is what a user is allowed to do beyond their own data. The permissions
+// each role grants are stored in the role_permissions table.
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleCoach Role = "coach"
+	RoleAdmin Role = "admin"
+)
+
+var Roles = []string{string(RoleUser), string(RoleCoach), string(RoleAdmin)}
+
+// Permissions granted by roles.
+const (
+	PermissionUsersRead   = "users:read"
+	PermissionUsersManage = "users:manage"
+	PermissionAuditRead   = "audit:read"
+)
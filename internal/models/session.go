@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClientInfo describes the device a session was opened or last used from.
+type ClientInfo struct {
+	UserAgent  string
+	IPAddress  string
+	DeviceName string
+}
+
+// Session is a signed-in device, backed by the live refresh token of a token
+// family. ID is the family ID, so it stays the same across rotations.
+type Session struct {
+	ID         uuid.UUID `json:"id" example:"3fa85f64-5717-4562-b3fc-2c963f66afa6"`
+	DeviceName string    `json:"device_name" example:"Chrome on macOS"`
+	UserAgent  string    `json:"user_agent" example:"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7)"`
+	IPAddress  string    `json:"ip_address" example:"203.0.113.7"`
+	Current    bool      `json:"current" example:"true"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
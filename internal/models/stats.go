@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StatsInterval string
+
+const (
+	StatsIntervalDay   StatsInterval = "day"
+	StatsIntervalWeek  StatsInterval = "week"
+	StatsIntervalMonth StatsInterval = "month"
+)
+
+var StatsIntervals = []string{
+	string(StatsIntervalDay),
+	string(StatsIntervalWeek),
+	string(StatsIntervalMonth),
+}
+
+// PeriodLayout is the format of time-series period keys: the first day of the period.
+const PeriodLayout = "2006-01-02"
+
+// StatsQuery selects workouts started in [From, To) and buckets them by
+// Interval using the calendar of Timezone, with weeks starting on WeekStart.
+type StatsQuery struct {
+	From       time.Time
+	To         time.Time
+	Interval   StatsInterval
+	Timezone   string
+	WeekStart  WeekStart
+	ExerciseID *uuid.UUID
+	Muscle     string
+}
+
+// StatsRow is one aggregated value for a series in a period, as returned by the repository.
+type StatsRow struct {
+	Key    string
+	Label  string
+	Period string
+	Value  float64
+}
+
+type TimeSeriesPoint struct {
+	Period string  `json:"period" example:"2025-01-06"`
+	Value  float64 `json:"value" example:"12500"`
+}
+
+type TimeSeries struct {
+	Key    string            `json:"key" example:"chest"`
+	Label  string            `json:"label" example:"chest"`
+	Points []TimeSeriesPoint `json:"points"`
+}
+
+// TimeSeriesResponse is shared by the stats endpoints. Every series has a point
+// for every period in the range, with zero where there was no training.
+type TimeSeriesResponse struct {
+	From     string        `json:"from" example:"2025-01-01"`
+	To       string        `json:"to" example:"2025-03-31"`
+	Interval StatsInterval `json:"interval" example:"week"`
+	Unit     string        `json:"unit" example:"kg"`
+	Periods  []string      `json:"periods"`
+	Series   []TimeSeries  `json:"series"`
+}
+
+type Streaks struct {
+	Interval   StatsInterval `json:"interval" example:"week"`
+	Current    int           `json:"current" example:"5"`
+	Longest    int           `json:"longest" example:"14"`
+	LastActive *string       `json:"last_active,omitempty" example:"2025-03-24"`
+}
+
+// ConvertWeights converts weight-based series (unit kg) to unit.
+func (r *TimeSeriesResponse) ConvertWeights(unit WeightUnit) {
+	if unit == "" || r.Unit != string(WeightUnitKg) {
+		return
+	}
+	for i := range r.Series {
+		for j := range r.Series[i].Points {
+			point := &r.Series[i].Points[j]
+			point.Value = displayWeight(point.Value, WeightUnitKg, unit)
+		}
+	}
+	r.Unit = string(unit)
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WorkoutTemplate struct {
+	ID        uuid.UUID           `json:"id" db:"id"`
+	UserID    uuid.UUID           `json:"user_id" db:"user_id"`
+	Name      string              `json:"name" db:"name"`
+	Notes     string              `json:"notes" db:"notes"`
+	Exercises []*TemplateExercise `json:"exercises" db:"-"`
+	CreatedAt time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at" db:"updated_at"`
+}
+
+type TemplateExercise struct {
+	ID           uuid.UUID  `json:"id" db:"id"`
+	TemplateID   uuid.UUID  `json:"template_id" db:"template_id"`
+	ExerciseID   *uuid.UUID `json:"exercise_id,omitempty" db:"exercise_id"`
+	Name         string     `json:"name" db:"name"`
+	Position     int        `json:"position" db:"position"`
+	TargetSets   int        `json:"target_sets" db:"target_sets"`
+	TargetReps   int        `json:"target_reps" db:"target_reps"`
+	TargetWeight *float64   `json:"target_weight,omitempty" db:"target_weight"`
+	WeightUnit   WeightUnit `json:"weight_unit" db:"weight_unit"`
+	RestSeconds  *int       `json:"rest_seconds,omitempty" db:"rest_seconds"`
+	Notes        string     `json:"notes" db:"notes"`
+}
+
+type TemplateExerciseInput struct {
+	ExerciseID   *uuid.UUID `json:"exercise_id,omitempty" example:"3fa85f64-5717-4562-b3fc-2c963f66afa6"`
+	Name         string     `json:"name" validate:"required_without=ExerciseID,max=255" example:"Bench press"`
+	TargetSets   int        `json:"target_sets" validate:"required,min=1" example:"3"`
+	TargetReps   int        `json:"target_reps" validate:"required,min=1" example:"8"`
+	TargetWeight *float64   `json:"target_weight,omitempty" validate:"omitempty,min=0" example:"80"`
+	WeightUnit   WeightUnit `json:"weight_unit" validate:"omitempty,oneof=kg lb" example:"kg"`
+	RestSeconds  *int       `json:"rest_seconds,omitempty" validate:"omitempty,min=0" example:"120"`
+	Notes        string     `json:"notes" validate:"max=2000" example:"Pause at the bottom"`
+}
+
+type CreateTemplateRequest struct {
+	Name      string                  `json:"name" validate:"required,max=255" example:"Upper A"`
+	Notes     string                  `json:"notes" validate:"max=2000" example:"Heavy upper body day"`
+	Exercises []TemplateExerciseInput `json:"exercises"`
+}
+
+type UpdateTemplateRequest struct {
+	Name      *string                  `json:"name,omitempty" validate:"omitempty,max=255" example:"Upper A"`
+	Notes     *string                  `json:"notes,omitempty" validate:"omitempty,max=2000" example:"Heavy upper body day"`
+	Exercises *[]TemplateExerciseInput `json:"exercises,omitempty"`
+}
+
+func (e *TemplateExercise) ConvertWeights(unit WeightUnit) {
+	if unit == "" {
+		return
+	}
+	if e.TargetWeight != nil {
+		converted := displayWeight(*e.TargetWeight, e.WeightUnit, unit)
+		e.TargetWeight = &converted
+	}
+	e.WeightUnit = unit
+}
+
+func (t *WorkoutTemplate) ConvertWeights(unit WeightUnit) {
+	for _, exercise := range t.Exercises {
+		exercise.ConvertWeights(unit)
+	}
+}
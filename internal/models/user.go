@@ -6,12 +6,87 @@ import (
 	"github.com/google/uuid"
 )
 
+type Sex string
+
+const (
+	SexMale   Sex = "male"
+	SexFemale Sex = "female"
+	SexOther  Sex = "other"
+)
+
+var Sexes = []string{string(SexMale), string(SexFemale), string(SexOther)}
+
+type WeekStart string
+
+const (
+	WeekStartMonday   WeekStart = "monday"
+	WeekStartSunday   WeekStart = "sunday"
+	WeekStartSaturday WeekStart = "saturday"
+)
+
+var WeekStarts = []string{string(WeekStartMonday), string(WeekStartSunday), string(WeekStartSaturday)}
+
+// Weekday returns the first day of the week, Monday unless set otherwise.
+func (w WeekStart) Weekday() time.Weekday {
+	switch w {
+	case WeekStartSunday:
+		return time.Sunday
+	case WeekStartSaturday:
+		return time.Saturday
+	default:
+		return time.Monday
+	}
+}
+
+// Profile defaults for new users.
+const (
+	DefaultTimezone = "UTC"
+	DefaultLocale   = "en"
+)
+
 type User struct {
 	ID           uuid.UUID `json:"id" db:"id"`
 	Email        string    `json:"email" db:"email"`
 	PasswordHash string    `json:"-" db:"password_hash"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	DisplayName  string    `json:"display_name" db:"display_name"`
+	// BirthDate is a calendar date in YYYY-MM-DD format.
+	BirthDate  *string    `json:"birth_date,omitempty" db:"birth_date"`
+	Sex        *Sex       `json:"sex,omitempty" db:"sex"`
+	HeightCm   *float64   `json:"height_cm,omitempty" db:"height_cm"`
+	WeightUnit WeightUnit `json:"weight_unit" db:"weight_unit"`
+	Timezone   string     `json:"timezone" db:"timezone"`
+	Locale     string     `json:"locale" db:"locale"`
+	WeekStart  WeekStart  `json:"week_start" db:"week_start"`
+	Role       Role       `json:"role" db:"role"`
+	// Permissions are granted by Role; they are loaded with the user, not stored on it.
+	Permissions []string `json:"-" db:"-"`
+	// EmailVerifiedAt is nil until the user confirms they own Email.
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty" db:"email_verified_at"`
+	// TokensValidAfter rejects access tokens issued before it, e.g. once the
+	// password changed. It only ever moves forward.
+	TokensValidAfter *time.Time `json:"-" db:"tokens_valid_after"`
+	// DisabledAt is set while an admin has disabled the account; it cannot sign in.
+	DisabledAt *time.Time `json:"disabled_at,omitempty" db:"disabled_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// IsEmailVerified reports whether the user has confirmed their email address.
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// IsDisabled reports whether an admin has disabled the account.
+func (u *User) IsDisabled() bool {
+	return u.DisabledAt != nil
+}
+
+// UserFilter narrows an admin user search. Query matches email or display name.
+type UserFilter struct {
+	Query    string
+	Role     Role
+	Disabled *bool
+	Pagination
 }
 
 type CreateUserRequest struct {
@@ -19,8 +94,24 @@ type CreateUserRequest struct {
 	Password string `json:"password" validate:"required,min=8"`
 }
 
-type UpdateUserRequest struct {
-	Email string `json:"email" validate:"omitempty,email"`
+// ChangeEmailRequest starts an email change. The address only changes once the
+// link sent to NewEmail is confirmed.
+type ChangeEmailRequest struct {
+	NewEmail        string `json:"new_email" validate:"required,email" example:"new@example.com"`
+	CurrentPassword string `json:"current_password" validate:"required" example:"Password123!"`
+}
+
+// UpdateProfileRequest changes the fields that are present. An empty
+// birth_date or sex, or a zero height_cm, clears the stored value.
+type UpdateProfileRequest struct {
+	DisplayName *string     `json:"display_name,omitempty" validate:"omitempty,max=100" example:"Alex"`
+	BirthDate   *string     `json:"birth_date,omitempty" example:"1990-05-17"`
+	Sex         *Sex        `json:"sex,omitempty" validate:"omitempty,oneof=male female other" example:"female"`
+	HeightCm    *float64    `json:"height_cm,omitempty" validate:"omitempty,min=0" example:"172.5"`
+	WeightUnit  *WeightUnit `json:"weight_unit,omitempty" validate:"omitempty,oneof=kg lb" example:"kg"`
+	Timezone    *string     `json:"timezone,omitempty" example:"Europe/Berlin"`
+	Locale      *string     `json:"locale,omitempty" example:"de-DE"`
+	WeekStart   *WeekStart  `json:"week_start,omitempty" validate:"omitempty,oneof=monday sunday saturday" example:"monday"`
 }
 
 type ChangePasswordRequest struct {
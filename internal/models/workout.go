@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Workout struct {
+	ID         uuid.UUID          `json:"id" db:"id"`
+	UserID     uuid.UUID          `json:"user_id" db:"user_id"`
+	Title      string             `json:"title" db:"title"`
+	StartedAt  time.Time          `json:"started_at" db:"started_at"`
+	FinishedAt *time.Time         `json:"finished_at,omitempty" db:"finished_at"`
+	Notes      string             `json:"notes" db:"notes"`
+	Exercises  []*WorkoutExercise `json:"exercises,omitempty" db:"-"`
+	CreatedAt  time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+type CreateWorkoutRequest struct {
+	Title      string     `json:"title" validate:"required,max=255" example:"Push day"`
+	StartedAt  *time.Time `json:"started_at,omitempty" example:"2025-01-01T18:00:00Z"`
+	FinishedAt *time.Time `json:"finished_at,omitempty" example:"2025-01-01T19:15:00Z"`
+	Notes      string     `json:"notes" validate:"max=2000" example:"Felt strong today"`
+}
+
+// UpdateWorkoutRequest changes the fields that are set. ClearFinishedAt
+// reopens a workout that was marked finished.
+type UpdateWorkoutRequest struct {
+	Title           *string    `json:"title,omitempty" validate:"omitempty,max=255" example:"Push day"`
+	StartedAt       *time.Time `json:"started_at,omitempty" example:"2025-01-01T18:00:00Z"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty" example:"2025-01-01T19:15:00Z"`
+	ClearFinishedAt bool       `json:"clear_finished_at,omitempty" example:"false"`
+	Notes           *string    `json:"notes,omitempty" validate:"omitempty,max=2000" example:"Felt strong today"`
+}
+
+func (w *Workout) ConvertWeights(unit WeightUnit) {
+	for _, exercise := range w.Exercises {
+		exercise.ConvertWeights(unit)
+	}
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WeightUnit string
+
+const (
+	WeightUnitKg WeightUnit = "kg"
+	WeightUnitLb WeightUnit = "lb"
+)
+
+const poundsPerKilogram = 2.2046226218
+
+// ConvertWeight converts a weight between units; unknown units pass through unchanged.
+func ConvertWeight(weight float64, from, to WeightUnit) float64 {
+	switch {
+	case from == to:
+		return weight
+	case from == WeightUnitKg && to == WeightUnitLb:
+		return weight * poundsPerKilogram
+	case from == WeightUnitLb && to == WeightUnitKg:
+		return weight / poundsPerKilogram
+	default:
+		return weight
+	}
+}
+
+// displayWeight converts weight for output, rounding to two decimals when the
+// unit actually changes so stored values are echoed back untouched.
+func displayWeight(weight float64, from, to WeightUnit) float64 {
+	if from == to || to == "" {
+		return weight
+	}
+	return math.Round(ConvertWeight(weight, from, to)*100) / 100
+}
+
+type WorkoutExercise struct {
+	ID         uuid.UUID      `json:"id" db:"id"`
+	WorkoutID  uuid.UUID      `json:"workout_id" db:"workout_id"`
+	ExerciseID *uuid.UUID     `json:"exercise_id,omitempty" db:"exercise_id"`
+	Name       string         `json:"name" db:"name"`
+	Position   int            `json:"position" db:"position"`
+	Notes      string         `json:"notes" db:"notes"`
+	Sets       []*ExerciseSet `json:"sets" db:"-"`
+	CreatedAt  time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+type ExerciseSet struct {
+	ID                uuid.UUID  `json:"id" db:"id"`
+	WorkoutExerciseID uuid.UUID  `json:"workout_exercise_id" db:"workout_exercise_id"`
+	Position          int        `json:"position" db:"position"`
+	Reps              int        `json:"reps" db:"reps"`
+	Weight            float64    `json:"weight" db:"weight"`
+	WeightUnit        WeightUnit `json:"weight_unit" db:"weight_unit"`
+	RPE               *float64   `json:"rpe,omitempty" db:"rpe"`
+	RestSeconds       *int       `json:"rest_seconds,omitempty" db:"rest_seconds"`
+	IsWarmup          bool       `json:"is_warmup" db:"is_warmup"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
+	// NewRecords lists the personal records this set established when it was saved.
+	NewRecords []*PersonalRecord `json:"new_records,omitempty" db:"-"`
+}
+
+type CreateWorkoutExerciseRequest struct {
+	ExerciseID *uuid.UUID `json:"exercise_id,omitempty" example:"3fa85f64-5717-4562-b3fc-2c963f66afa6"`
+	Name       string     `json:"name" validate:"required_without=ExerciseID,max=255" example:"Bench press"`
+	Notes      string     `json:"notes" validate:"max=2000" example:"Paused reps"`
+}
+
+type UpdateWorkoutExerciseRequest struct {
+	Name     *string `json:"name,omitempty" validate:"omitempty,max=255" example:"Bench press"`
+	Notes    *string `json:"notes,omitempty" validate:"omitempty,max=2000" example:"Paused reps"`
+	Position *int    `json:"position,omitempty" validate:"omitempty,min=1" example:"1"`
+}
+
+type CreateExerciseSetRequest struct {
+	Reps        int        `json:"reps" validate:"min=0,max=1000" example:"5"`
+	Weight      float64    `json:"weight" validate:"min=0" example:"100"`
+	WeightUnit  WeightUnit `json:"weight_unit" validate:"required,oneof=kg lb" example:"kg"`
+	RPE         *float64   `json:"rpe,omitempty" validate:"omitempty,min=1,max=10" example:"8.5"`
+	RestSeconds *int       `json:"rest_seconds,omitempty" validate:"omitempty,min=0" example:"180"`
+	IsWarmup    bool       `json:"is_warmup" example:"false"`
+}
+
+type UpdateExerciseSetRequest struct {
+	Reps        *int        `json:"reps,omitempty" validate:"omitempty,min=0,max=1000" example:"5"`
+	Weight      *float64    `json:"weight,omitempty" validate:"omitempty,min=0" example:"100"`
+	WeightUnit  *WeightUnit `json:"weight_unit,omitempty" validate:"omitempty,oneof=kg lb" example:"kg"`
+	RPE         *float64    `json:"rpe,omitempty" validate:"omitempty,min=1,max=10" example:"8.5"`
+	RestSeconds *int        `json:"rest_seconds,omitempty" validate:"omitempty,min=0" example:"180"`
+	IsWarmup    *bool       `json:"is_warmup,omitempty" example:"false"`
+	Position    *int        `json:"position,omitempty" validate:"omitempty,min=1" example:"1"`
+}
+
+// ConvertWeights expresses the set, and any records it set, in unit.
+func (s *ExerciseSet) ConvertWeights(unit WeightUnit) {
+	if unit == "" {
+		return
+	}
+	s.Weight = displayWeight(s.Weight, s.WeightUnit, unit)
+	s.WeightUnit = unit
+	for _, record := range s.NewRecords {
+		record.ConvertWeights(unit)
+	}
+}
+
+func (e *WorkoutExercise) ConvertWeights(unit WeightUnit) {
+	for _, set := range e.Sets {
+		set.ConvertWeights(unit)
+	}
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AuditRepository interface {
+	// Create appends an event; events are never changed afterwards.
+	Create(ctx context.Context, event *models.AuditEvent) error
+	// List returns events matching filter, newest first.
+	List(ctx context.Context, filter models.AuditFilter) ([]*models.AuditEvent, error)
+}
+
+type auditRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAuditRepository(pool *pgxpool.Pool) AuditRepository {
+	return &auditRepository{
+		pool: pool,
+	}
+}
+
+func (r *auditRepository) Create(ctx context.Context, event *models.AuditEvent) error {
+	query := `
+		INSERT INTO audit_events (
+			id, event_type, actor_id, target_user_id, ip_address, user_agent, request_id, details, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	details := event.Details
+	if details == nil {
+		details = map[string]any{}
+	}
+
+	_, err := r.pool.Exec(ctx, query,
+		event.ID, event.Type, event.ActorID, event.TargetUserID, event.IPAddress, event.UserAgent, event.RequestID,
+		details, event.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create audit event: %w", err)
+	}
+
+	return nil
+}
+
+func (r *auditRepository) List(ctx context.Context, filter models.AuditFilter) ([]*models.AuditEvent, error) {
+	conditions := []string{"TRUE"}
+	var args []interface{}
+
+	addCondition := func(format string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if filter.UserID != nil {
+		addCondition("target_user_id = $%d", *filter.UserID)
+	}
+	if filter.ActorID != nil {
+		addCondition("actor_id = $%d", *filter.ActorID)
+	}
+	if len(filter.Types) > 0 {
+		addCondition("event_type = ANY($%d)", filter.Types)
+	}
+	if filter.From != nil {
+		addCondition("created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		addCondition("created_at < $%d", *filter.To)
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`
+		SELECT id, event_type, actor_id, target_user_id, ip_address, user_agent, request_id, details, created_at
+		FROM audit_events
+		WHERE %s
+		ORDER BY created_at DESC, id
+		LIMIT $%d OFFSET $%d
+	`, strings.Join(conditions, " AND "), len(args)-1, len(args))
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]*models.AuditEvent, 0)
+	for rows.Next() {
+		var event models.AuditEvent
+		err := rows.Scan(
+			&event.ID, &event.Type, &event.ActorID, &event.TargetUserID, &event.IPAddress, &event.UserAgent, &event.RequestID,
+			&event.Details, &event.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit event: %w", err)
+		}
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate audit events: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type EmailChangeRepository interface {
+	Create(ctx context.Context, token *models.EmailChangeToken) error
+	// GetValidByHash returns an unused, unexpired token; ErrNotFound otherwise.
+	GetValidByHash(ctx context.Context, tokenHash string) (*models.EmailChangeToken, error)
+	// MarkUsed consumes the token, returning ErrNotFound if it was already used.
+	MarkUsed(ctx context.Context, id uuid.UUID) error
+	DeleteUnusedByUserID(ctx context.Context, userID uuid.UUID) error
+}
+
+type emailChangeRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewEmailChangeRepository(pool *pgxpool.Pool) EmailChangeRepository {
+	return &emailChangeRepository{
+		pool: pool,
+	}
+}
+
+func (r *emailChangeRepository) Create(ctx context.Context, token *models.EmailChangeToken) error {
+	query := `
+		INSERT INTO email_change_tokens (id, user_id, new_email, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.pool.Exec(ctx, query, token.ID, token.UserID, token.NewEmail, token.TokenHash, token.ExpiresAt, token.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create email change token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *emailChangeRepository) GetValidByHash(ctx context.Context, tokenHash string) (*models.EmailChangeToken, error) {
+	query := `
+		SELECT id, user_id, new_email, token_hash, expires_at, used_at, created_at
+		FROM email_change_tokens
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+	`
+
+	token := &models.EmailChangeToken{}
+	err := r.pool.QueryRow(ctx, query, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.NewEmail,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email change token: %w", err)
+	}
+
+	return token, nil
+}
+
+func (r *emailChangeRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE email_change_tokens SET used_at = NOW() WHERE id = $1 AND used_at IS NULL`
+
+	tag, err := r.pool.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark email change token used: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *emailChangeRepository) DeleteUnusedByUserID(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM email_change_tokens WHERE user_id = $1 AND used_at IS NULL`
+
+	_, err := r.pool.Exec(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete email change tokens: %w", err)
+	}
+
+	return nil
+}
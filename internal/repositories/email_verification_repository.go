@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type EmailVerificationRepository interface {
+	Create(ctx context.Context, token *models.EmailVerificationToken) error
+	// GetValidByHash returns an unused, unexpired token; ErrNotFound otherwise.
+	GetValidByHash(ctx context.Context, tokenHash string) (*models.EmailVerificationToken, error)
+	// MarkUsed consumes the token, returning ErrNotFound if it was already used.
+	MarkUsed(ctx context.Context, id uuid.UUID) error
+	DeleteUnusedByUserID(ctx context.Context, userID uuid.UUID) error
+}
+
+type emailVerificationRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewEmailVerificationRepository(pool *pgxpool.Pool) EmailVerificationRepository {
+	return &emailVerificationRepository{
+		pool: pool,
+	}
+}
+
+func (r *emailVerificationRepository) Create(ctx context.Context, token *models.EmailVerificationToken) error {
+	query := `
+		INSERT INTO email_verification_tokens (id, user_id, email, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.pool.Exec(ctx, query, token.ID, token.UserID, token.Email, token.TokenHash, token.ExpiresAt, token.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create email verification token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *emailVerificationRepository) GetValidByHash(ctx context.Context, tokenHash string) (*models.EmailVerificationToken, error) {
+	query := `
+		SELECT id, user_id, email, token_hash, expires_at, used_at, created_at
+		FROM email_verification_tokens
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+	`
+
+	token := &models.EmailVerificationToken{}
+	err := r.pool.QueryRow(ctx, query, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Email,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email verification token: %w", err)
+	}
+
+	return token, nil
+}
+
+func (r *emailVerificationRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE email_verification_tokens SET used_at = NOW() WHERE id = $1 AND used_at IS NULL`
+
+	tag, err := r.pool.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark email verification token used: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *emailVerificationRepository) DeleteUnusedByUserID(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM email_verification_tokens WHERE user_id = $1 AND used_at IS NULL`
+
+	_, err := r.pool.Exec(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete email verification tokens: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type EnrollmentRepository interface {
+	Create(ctx context.Context, enrollment *models.Enrollment) error
+	GetActiveByUserID(ctx context.Context, userID uuid.UUID) (*models.Enrollment, error)
+	// Advance moves the enrollment forward one day, provided it is still active
+	// and has completedDays completed; it returns ErrNotFound otherwise.
+	Advance(ctx context.Context, id uuid.UUID, completedDays int, status models.EnrollmentStatus) error
+	UpdateStatus(ctx context.Context, id, userID uuid.UUID, status models.EnrollmentStatus) error
+}
+
+type enrollmentRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewEnrollmentRepository(pool *pgxpool.Pool) EnrollmentRepository {
+	return &enrollmentRepository{
+		pool: pool,
+	}
+}
+
+func (r *enrollmentRepository) Create(ctx context.Context, enrollment *models.Enrollment) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO program_enrollments (id, user_id, program_id, status, completed_days, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`,
+		enrollment.ID, enrollment.UserID, enrollment.ProgramID, enrollment.Status,
+		enrollment.CompletedDays, enrollment.CreatedAt, enrollment.UpdatedAt,
+	)
+	if isUniqueViolation(err) {
+		return ErrAlreadyExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create enrollment: %w", err)
+	}
+
+	batch := &pgx.Batch{}
+	for _, trainingMax := range enrollment.TrainingMaxes {
+		batch.Queue(`
+			INSERT INTO enrollment_training_maxes (enrollment_id, exercise_id, weight, weight_unit)
+			VALUES ($1, $2, $3, $4)
+		`, enrollment.ID, trainingMax.ExerciseID, trainingMax.Weight, trainingMax.WeightUnit)
+	}
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to create training maxes: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit enrollment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *enrollmentRepository) GetActiveByUserID(ctx context.Context, userID uuid.UUID) (*models.Enrollment, error) {
+	query := `
+		SELECT id, user_id, program_id, status, completed_days, created_at, updated_at
+		FROM program_enrollments
+		WHERE user_id = $1 AND status = 'active'
+	`
+
+	enrollment := &models.Enrollment{}
+	err := r.pool.QueryRow(ctx, query, userID).Scan(
+		&enrollment.ID,
+		&enrollment.UserID,
+		&enrollment.ProgramID,
+		&enrollment.Status,
+		&enrollment.CompletedDays,
+		&enrollment.CreatedAt,
+		&enrollment.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active enrollment: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT exercise_id, weight::float8, weight_unit
+		FROM enrollment_training_maxes
+		WHERE enrollment_id = $1
+	`, enrollment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list training maxes: %w", err)
+	}
+	defer rows.Close()
+
+	enrollment.TrainingMaxes = []models.TrainingMax{}
+	for rows.Next() {
+		var trainingMax models.TrainingMax
+		if err := rows.Scan(&trainingMax.ExerciseID, &trainingMax.Weight, &trainingMax.WeightUnit); err != nil {
+			return nil, fmt.Errorf("failed to scan training max: %w", err)
+		}
+		enrollment.TrainingMaxes = append(enrollment.TrainingMaxes, trainingMax)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate training maxes: %w", err)
+	}
+
+	return enrollment, nil
+}
+
+func (r *enrollmentRepository) Advance(
+	ctx context.Context,
+	id uuid.UUID,
+	completedDays int,
+	status models.EnrollmentStatus,
+) error {
+	query := `
+		UPDATE program_enrollments
+		SET completed_days = completed_days + 1, status = $3, updated_at = NOW()
+		WHERE id = $1 AND completed_days = $2 AND status = 'active'
+	`
+
+	tag, err := r.pool.Exec(ctx, query, id, completedDays, status)
+	if err != nil {
+		return fmt.Errorf("failed to advance enrollment: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *enrollmentRepository) UpdateStatus(
+	ctx context.Context,
+	id, userID uuid.UUID,
+	status models.EnrollmentStatus,
+) error {
+	query := `
+		UPDATE program_enrollments
+		SET status = $3, updated_at = NOW()
+		WHERE id = $1 AND user_id = $2
+	`
+
+	tag, err := r.pool.Exec(ctx, query, id, userID, status)
+	if err != nil {
+		return fmt.Errorf("failed to update enrollment status: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ExerciseRepository interface {
+	Create(ctx context.Context, exercise *models.Exercise) error
+	GetByID(ctx context.Context, id, userID uuid.UUID) (*models.Exercise, error)
+	Search(ctx context.Context, userID uuid.UUID, filter models.ExerciseFilter) ([]*models.Exercise, error)
+	Update(ctx context.Context, exercise *models.Exercise) error
+	Delete(ctx context.Context, id, ownerID uuid.UUID) error
+}
+
+type exerciseRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewExerciseRepository(pool *pgxpool.Pool) ExerciseRepository {
+	return &exerciseRepository{
+		pool: pool,
+	}
+}
+
+const exerciseColumns = `
+	id, owner_id, name, primary_muscles, secondary_muscles,
+	equipment, movement_pattern, is_unilateral, created_at, updated_at
+`
+
+func scanExercise(row pgx.Row) (*models.Exercise, error) {
+	exercise := &models.Exercise{}
+	err := row.Scan(
+		&exercise.ID,
+		&exercise.OwnerID,
+		&exercise.Name,
+		&exercise.PrimaryMuscles,
+		&exercise.SecondaryMuscles,
+		&exercise.Equipment,
+		&exercise.MovementPattern,
+		&exercise.IsUnilateral,
+		&exercise.CreatedAt,
+		&exercise.UpdatedAt,
+	)
+	exercise.IsCustom = exercise.OwnerID != nil
+	return exercise, err
+}
+
+func (r *exerciseRepository) Create(ctx context.Context, exercise *models.Exercise) error {
+	query := `
+		INSERT INTO exercises (
+			id, owner_id, name, primary_muscles, secondary_muscles,
+			equipment, movement_pattern, is_unilateral, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		exercise.ID, exercise.OwnerID, exercise.Name, exercise.PrimaryMuscles, exercise.SecondaryMuscles,
+		exercise.Equipment, exercise.MovementPattern, exercise.IsUnilateral, exercise.CreatedAt, exercise.UpdatedAt,
+	)
+	if isUniqueViolation(err) {
+		return ErrAlreadyExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create exercise: %w", err)
+	}
+
+	return nil
+}
+
+func (r *exerciseRepository) GetByID(ctx context.Context, id, userID uuid.UUID) (*models.Exercise, error) {
+	query := `SELECT` + exerciseColumns + `
+		FROM exercises
+		WHERE id = $1 AND (owner_id IS NULL OR owner_id = $2)
+	`
+
+	exercise, err := scanExercise(r.pool.QueryRow(ctx, query, id, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exercise by id: %w", err)
+	}
+
+	return exercise, nil
+}
+
+func (r *exerciseRepository) Search(ctx context.Context, userID uuid.UUID, filter models.ExerciseFilter) ([]*models.Exercise, error) {
+	conditions := []string{"(owner_id IS NULL OR owner_id = $1)"}
+	args := []interface{}{userID}
+
+	addCondition := func(format string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if filter.Query != "" {
+		addCondition("name ILIKE '%%' || $%d || '%%'", filter.Query)
+	}
+	if filter.Muscle != "" {
+		addCondition("($%[1]d = ANY(primary_muscles) OR $%[1]d = ANY(secondary_muscles))", filter.Muscle)
+	}
+	if filter.Equipment != "" {
+		addCondition("equipment = $%d", filter.Equipment)
+	}
+	if filter.MovementPattern != "" {
+		addCondition("movement_pattern = $%d", filter.MovementPattern)
+	}
+	if filter.CustomOnly {
+		conditions = append(conditions, "owner_id IS NOT NULL")
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`SELECT`+exerciseColumns+`
+		FROM exercises
+		WHERE %s
+		ORDER BY name
+		LIMIT $%d OFFSET $%d
+	`, strings.Join(conditions, " AND "), len(args)-1, len(args))
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search exercises: %w", err)
+	}
+	defer rows.Close()
+
+	exercises := make([]*models.Exercise, 0)
+	for rows.Next() {
+		exercise, err := scanExercise(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan exercise: %w", err)
+		}
+		exercises = append(exercises, exercise)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate exercises: %w", err)
+	}
+
+	return exercises, nil
+}
+
+func (r *exerciseRepository) Update(ctx context.Context, exercise *models.Exercise) error {
+	query := `
+		UPDATE exercises
+		SET name = $3, primary_muscles = $4, secondary_muscles = $5, equipment = $6,
+			movement_pattern = $7, is_unilateral = $8, updated_at = $9
+		WHERE id = $1 AND owner_id = $2
+	`
+
+	tag, err := r.pool.Exec(ctx, query,
+		exercise.ID, exercise.OwnerID, exercise.Name, exercise.PrimaryMuscles, exercise.SecondaryMuscles,
+		exercise.Equipment, exercise.MovementPattern, exercise.IsUnilateral, exercise.UpdatedAt,
+	)
+	if isUniqueViolation(err) {
+		return ErrAlreadyExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update exercise: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *exerciseRepository) Delete(ctx context.Context, id, ownerID uuid.UUID) error {
+	query := `DELETE FROM exercises WHERE id = $1 AND owner_id = $2`
+
+	tag, err := r.pool.Exec(ctx, query, id, ownerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete exercise: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
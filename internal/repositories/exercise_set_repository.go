@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ExerciseSetRepository interface {
+	Create(ctx context.Context, set *models.ExerciseSet) error
+	GetByID(ctx context.Context, id, workoutExerciseID uuid.UUID) (*models.ExerciseSet, error)
+	ListByWorkoutExerciseID(ctx context.Context, workoutExerciseID uuid.UUID) ([]*models.ExerciseSet, error)
+	ListByWorkoutID(ctx context.Context, workoutID uuid.UUID) ([]*models.ExerciseSet, error)
+	// Update saves the set and, in the same transaction, drops the personal
+	// records it set, so they can be detected again from its new values.
+	Update(ctx context.Context, set *models.ExerciseSet) error
+	Delete(ctx context.Context, id, workoutExerciseID uuid.UUID) error
+}
+
+type exerciseSetRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewExerciseSetRepository(pool *pgxpool.Pool) ExerciseSetRepository {
+	return &exerciseSetRepository{
+		pool: pool,
+	}
+}
+
+const exerciseSetColumns = `
+	s.id, s.workout_exercise_id, s.position, s.reps, s.weight::float8, s.weight_unit,
+	s.rpe::float8, s.rest_seconds, s.is_warmup, s.created_at, s.updated_at
+`
+
+func scanExerciseSet(row pgx.Row) (*models.ExerciseSet, error) {
+	set := &models.ExerciseSet{}
+	err := row.Scan(
+		&set.ID,
+		&set.WorkoutExerciseID,
+		&set.Position,
+		&set.Reps,
+		&set.Weight,
+		&set.WeightUnit,
+		&set.RPE,
+		&set.RestSeconds,
+		&set.IsWarmup,
+		&set.CreatedAt,
+		&set.UpdatedAt,
+	)
+	return set, err
+}
+
+func (r *exerciseSetRepository) Create(ctx context.Context, set *models.ExerciseSet) error {
+	query := `
+		INSERT INTO exercise_sets (
+			id, workout_exercise_id, position, reps, weight, weight_unit,
+			rpe, rest_seconds, is_warmup, created_at, updated_at
+		)
+		VALUES ($1, $2,
+			(SELECT COALESCE(MAX(position), 0) + 1 FROM exercise_sets WHERE workout_exercise_id = $2),
+			$3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING position
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		set.ID, set.WorkoutExerciseID, set.Reps, set.Weight, set.WeightUnit,
+		set.RPE, set.RestSeconds, set.IsWarmup, set.CreatedAt, set.UpdatedAt,
+	).Scan(&set.Position)
+	if err != nil {
+		return fmt.Errorf("failed to create exercise set: %w", err)
+	}
+
+	return nil
+}
+
+func (r *exerciseSetRepository) GetByID(ctx context.Context, id, workoutExerciseID uuid.UUID) (*models.ExerciseSet, error) {
+	query := `SELECT` + exerciseSetColumns + `
+		FROM exercise_sets s
+		WHERE s.id = $1 AND s.workout_exercise_id = $2
+	`
+
+	set, err := scanExerciseSet(r.pool.QueryRow(ctx, query, id, workoutExerciseID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exercise set by id: %w", err)
+	}
+
+	return set, nil
+}
+
+func (r *exerciseSetRepository) ListByWorkoutExerciseID(ctx context.Context, workoutExerciseID uuid.UUID) ([]*models.ExerciseSet, error) {
+	query := `SELECT` + exerciseSetColumns + `
+		FROM exercise_sets s
+		WHERE s.workout_exercise_id = $1
+		ORDER BY s.position, s.created_at
+	`
+
+	return r.list(ctx, query, workoutExerciseID)
+}
+
+func (r *exerciseSetRepository) ListByWorkoutID(ctx context.Context, workoutID uuid.UUID) ([]*models.ExerciseSet, error) {
+	query := `SELECT` + exerciseSetColumns + `
+		FROM exercise_sets s
+		JOIN workout_exercises we ON we.id = s.workout_exercise_id
+		WHERE we.workout_id = $1
+		ORDER BY we.position, s.position, s.created_at
+	`
+
+	return r.list(ctx, query, workoutID)
+}
+
+func (r *exerciseSetRepository) list(ctx context.Context, query string, args ...interface{}) ([]*models.ExerciseSet, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exercise sets: %w", err)
+	}
+	defer rows.Close()
+
+	sets := make([]*models.ExerciseSet, 0)
+	for rows.Next() {
+		set, err := scanExerciseSet(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan exercise set: %w", err)
+		}
+		sets = append(sets, set)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate exercise sets: %w", err)
+	}
+
+	return sets, nil
+}
+
+func (r *exerciseSetRepository) Update(ctx context.Context, set *models.ExerciseSet) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+		UPDATE exercise_sets
+		SET position = $3, reps = $4, weight = $5, weight_unit = $6,
+			rpe = $7, rest_seconds = $8, is_warmup = $9, updated_at = $10
+		WHERE id = $1 AND workout_exercise_id = $2
+	`
+
+	tag, err := tx.Exec(ctx, query,
+		set.ID, set.WorkoutExerciseID, set.Position, set.Reps, set.Weight, set.WeightUnit,
+		set.RPE, set.RestSeconds, set.IsWarmup, set.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update exercise set: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM personal_records WHERE set_id = $1`, set.ID); err != nil {
+		return fmt.Errorf("failed to delete personal records of exercise set: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit exercise set: %w", err)
+	}
+
+	return nil
+}
+
+func (r *exerciseSetRepository) Delete(ctx context.Context, id, workoutExerciseID uuid.UUID) error {
+	query := `DELETE FROM exercise_sets WHERE id = $1 AND workout_exercise_id = $2`
+
+	tag, err := r.pool.Exec(ctx, query, id, workoutExerciseID)
+	if err != nil {
+		return fmt.Errorf("failed to delete exercise set: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type LoginFailureRepository interface {
+	// Get returns the failures counted for key; ErrNotFound if there are none.
+	Get(ctx context.Context, scope, key string) (*models.LoginFailure, error)
+	// Reserve counts an attempt at now, before its credentials are checked, and
+	// returns the updated row. A count whose last attempt is before resetBefore
+	// starts again from one. waits[n-1] is how long to wait after n attempts,
+	// the last entry applying to any more; while that has not passed the
+	// attempt is not counted, and reserved is false.
+	Reserve(
+		ctx context.Context,
+		scope, key string,
+		now, resetBefore time.Time,
+		waits []time.Duration,
+	) (failure *models.LoginFailure, reserved bool, err error)
+	// Release uncounts a reserved attempt that turned out not to be a guess and
+	// moves the last attempt back to the one before it, so released attempts
+	// never push a wait or the window further out.
+	Release(ctx context.Context, scope, key string) error
+	Delete(ctx context.Context, scope, key string) error
+	// DeleteStale removes counts whose last failure is before the given time.
+	DeleteStale(ctx context.Context, before time.Time) error
+}
+
+type loginFailureRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewLoginFailureRepository(pool *pgxpool.Pool) LoginFailureRepository {
+	return &loginFailureRepository{
+		pool: pool,
+	}
+}
+
+func (r *loginFailureRepository) Get(ctx context.Context, scope, key string) (*models.LoginFailure, error) {
+	query := `
+		SELECT scope, key, failures, last_failure_at, previous_failure_at
+		FROM login_failures
+		WHERE scope = $1 AND key = $2
+	`
+
+	failure := &models.LoginFailure{}
+	err := r.pool.QueryRow(ctx, query, scope, key).Scan(
+		&failure.Scope,
+		&failure.Key,
+		&failure.Failures,
+		&failure.LastFailureAt,
+		&failure.PreviousFailureAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get login failures: %w", err)
+	}
+
+	return failure, nil
+}
+
+func (r *loginFailureRepository) Reserve(
+	ctx context.Context,
+	scope, key string,
+	now, resetBefore time.Time,
+	waits []time.Duration,
+) (*models.LoginFailure, bool, error) {
+	// A single upsert, so concurrent attempts are counted one after another and
+	// each is checked against the count the previous one left.
+	query := `
+		INSERT INTO login_failures AS f (scope, key, failures, last_failure_at)
+		VALUES ($1, $2, 1, $3)
+		ON CONFLICT (scope, key) DO UPDATE
+		SET failures = CASE
+				WHEN f.last_failure_at < $4 THEN 1
+				WHEN f.last_failure_at + ($5::interval[])[LEAST(f.failures, cardinality($5::interval[]))] > $3
+					THEN f.failures
+				ELSE f.failures + 1
+			END,
+			previous_failure_at = CASE
+				WHEN f.last_failure_at < $4 THEN f.last_failure_at
+				WHEN f.last_failure_at + ($5::interval[])[LEAST(f.failures, cardinality($5::interval[]))] > $3
+					THEN f.previous_failure_at
+				ELSE f.last_failure_at
+			END,
+			last_failure_at = CASE
+				WHEN f.last_failure_at < $4 THEN EXCLUDED.last_failure_at
+				WHEN f.last_failure_at + ($5::interval[])[LEAST(f.failures, cardinality($5::interval[]))] > $3
+					THEN f.last_failure_at
+				ELSE EXCLUDED.last_failure_at
+			END
+		RETURNING scope, key, failures, last_failure_at, previous_failure_at, last_failure_at = $3
+	`
+
+	failure := &models.LoginFailure{}
+	var reserved bool
+	err := r.pool.QueryRow(ctx, query, scope, key, now, resetBefore, waits).Scan(
+		&failure.Scope,
+		&failure.Key,
+		&failure.Failures,
+		&failure.LastFailureAt,
+		&failure.PreviousFailureAt,
+		&reserved,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to reserve login attempt: %w", err)
+	}
+
+	return failure, reserved, nil
+}
+
+func (r *loginFailureRepository) Release(ctx context.Context, scope, key string) error {
+	query := `
+		UPDATE login_failures
+		SET failures = failures - 1,
+			last_failure_at = COALESCE(previous_failure_at, last_failure_at),
+			previous_failure_at = NULL
+		WHERE scope = $1 AND key = $2 AND failures > 0
+	`
+
+	_, err := r.pool.Exec(ctx, query, scope, key)
+	if err != nil {
+		return fmt.Errorf("failed to release login attempt: %w", err)
+	}
+
+	return nil
+}
+
+func (r *loginFailureRepository) Delete(ctx context.Context, scope, key string) error {
+	query := `DELETE FROM login_failures WHERE scope = $1 AND key = $2`
+
+	_, err := r.pool.Exec(ctx, query, scope, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete login failures: %w", err)
+	}
+
+	return nil
+}
+
+func (r *loginFailureRepository) DeleteStale(ctx context.Context, before time.Time) error {
+	query := `DELETE FROM login_failures WHERE last_failure_at < $1`
+
+	_, err := r.pool.Exec(ctx, query, before)
+	if err != nil {
+		return fmt.Errorf("failed to delete stale login failures: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type MeasurementRepository interface {
+	Create(ctx context.Context, measurement *models.Measurement) error
+	GetByID(ctx context.Context, id, userID uuid.UUID) (*models.Measurement, error)
+	// ListByUserID returns measurements newest first, optionally bounded to [From, To).
+	ListByUserID(ctx context.Context, userID uuid.UUID, filter models.MeasurementFilter) ([]*models.Measurement, error)
+	// ListInRange returns every measurement in [from, to), oldest first.
+	ListInRange(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*models.Measurement, error)
+	Update(ctx context.Context, measurement *models.Measurement) error
+	Delete(ctx context.Context, id, userID uuid.UUID) error
+}
+
+type measurementRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewMeasurementRepository(pool *pgxpool.Pool) MeasurementRepository {
+	return &measurementRepository{
+		pool: pool,
+	}
+}
+
+const measurementColumns = `
+	id, user_id, measured_at, bodyweight::float8, weight_unit, body_fat_percent::float8,
+	circumferences, length_unit, notes, created_at, updated_at
+`
+
+func scanMeasurement(row pgx.Row) (*models.Measurement, error) {
+	measurement := &models.Measurement{}
+	err := row.Scan(
+		&measurement.ID,
+		&measurement.UserID,
+		&measurement.MeasuredAt,
+		&measurement.Bodyweight,
+		&measurement.WeightUnit,
+		&measurement.BodyFatPercent,
+		&measurement.Circumferences,
+		&measurement.LengthUnit,
+		&measurement.Notes,
+		&measurement.CreatedAt,
+		&measurement.UpdatedAt,
+	)
+	return measurement, err
+}
+
+func (r *measurementRepository) Create(ctx context.Context, measurement *models.Measurement) error {
+	query := `
+		INSERT INTO measurements (
+			id, user_id, measured_at, bodyweight, weight_unit, body_fat_percent,
+			circumferences, length_unit, notes, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		measurement.ID, measurement.UserID, measurement.MeasuredAt, measurement.Bodyweight,
+		measurement.WeightUnit, measurement.BodyFatPercent, measurement.Circumferences,
+		measurement.LengthUnit, measurement.Notes, measurement.CreatedAt, measurement.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create measurement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *measurementRepository) GetByID(ctx context.Context, id, userID uuid.UUID) (*models.Measurement, error) {
+	query := `SELECT` + measurementColumns + `
+		FROM measurements
+		WHERE id = $1 AND user_id = $2
+	`
+
+	measurement, err := scanMeasurement(r.pool.QueryRow(ctx, query, id, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get measurement by id: %w", err)
+	}
+
+	return measurement, nil
+}
+
+func (r *measurementRepository) list(ctx context.Context, query string, args ...interface{}) ([]*models.Measurement, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list measurements: %w", err)
+	}
+	defer rows.Close()
+
+	measurements := make([]*models.Measurement, 0)
+	for rows.Next() {
+		measurement, err := scanMeasurement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan measurement: %w", err)
+		}
+		measurements = append(measurements, measurement)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate measurements: %w", err)
+	}
+
+	return measurements, nil
+}
+
+func (r *measurementRepository) ListByUserID(
+	ctx context.Context,
+	userID uuid.UUID,
+	filter models.MeasurementFilter,
+) ([]*models.Measurement, error) {
+	query := `SELECT` + measurementColumns + `
+		FROM measurements
+		WHERE user_id = $1
+			AND ($2::timestamptz IS NULL OR measured_at >= $2)
+			AND ($3::timestamptz IS NULL OR measured_at < $3)
+		ORDER BY measured_at DESC
+		LIMIT $4 OFFSET $5
+	`
+
+	return r.list(ctx, query, userID, filter.From, filter.To, filter.Limit, filter.Offset)
+}
+
+func (r *measurementRepository) ListInRange(
+	ctx context.Context,
+	userID uuid.UUID,
+	from, to time.Time,
+) ([]*models.Measurement, error) {
+	query := `SELECT` + measurementColumns + `
+		FROM measurements
+		WHERE user_id = $1 AND measured_at >= $2 AND measured_at < $3
+		ORDER BY measured_at
+	`
+
+	return r.list(ctx, query, userID, from, to)
+}
+
+func (r *measurementRepository) Update(ctx context.Context, measurement *models.Measurement) error {
+	query := `
+		UPDATE measurements
+		SET measured_at = $3, bodyweight = $4, weight_unit = $5, body_fat_percent = $6,
+			circumferences = $7, length_unit = $8, notes = $9, updated_at = $10
+		WHERE id = $1 AND user_id = $2
+	`
+
+	tag, err := r.pool.Exec(ctx, query,
+		measurement.ID, measurement.UserID, measurement.MeasuredAt, measurement.Bodyweight,
+		measurement.WeightUnit, measurement.BodyFatPercent, measurement.Circumferences,
+		measurement.LengthUnit, measurement.Notes, measurement.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update measurement: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *measurementRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	query := `DELETE FROM measurements WHERE id = $1 AND user_id = $2`
+
+	tag, err := r.pool.Exec(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete measurement: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
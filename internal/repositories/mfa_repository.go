@@ -0,0 +1,181 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type MFARepository interface {
+	// GetTOTP returns the user's TOTP credential, confirmed or not; ErrNotFound otherwise.
+	GetTOTP(ctx context.Context, userID uuid.UUID) (*models.TOTPCredential, error)
+	// SaveTOTP stores a new unconfirmed credential, replacing an earlier
+	// unconfirmed one. It returns ErrNotFound if a confirmed credential exists.
+	SaveTOTP(ctx context.Context, credential *models.TOTPCredential) error
+	// ConfirmTOTP turns two-factor authentication on, records step as used and
+	// replaces the user's recovery codes in one transaction. It returns
+	// ErrNotFound if there is no unconfirmed credential.
+	ConfirmTOTP(ctx context.Context, userID uuid.UUID, step int64, codes []*models.RecoveryCode) error
+	// UseTOTPStep records step as the last accepted one, returning ErrNotFound
+	// if it is not later than the current one, so each code works only once.
+	UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error
+	// UseRecoveryCode consumes the code with digest codeHash, returning
+	// ErrNotFound if the user has no such unused code.
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error
+	// DeleteTOTP removes the user's credential and recovery codes.
+	DeleteTOTP(ctx context.Context, userID uuid.UUID) error
+}
+
+type mfaRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewMFARepository(pool *pgxpool.Pool) MFARepository {
+	return &mfaRepository{
+		pool: pool,
+	}
+}
+
+func (r *mfaRepository) GetTOTP(ctx context.Context, userID uuid.UUID) (*models.TOTPCredential, error) {
+	query := `
+		SELECT user_id, secret, confirmed_at, last_used_step, created_at, updated_at
+		FROM user_totp
+		WHERE user_id = $1
+	`
+
+	credential := &models.TOTPCredential{}
+	err := r.pool.QueryRow(ctx, query, userID).Scan(
+		&credential.UserID,
+		&credential.Secret,
+		&credential.ConfirmedAt,
+		&credential.LastUsedStep,
+		&credential.CreatedAt,
+		&credential.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get totp credential: %w", err)
+	}
+
+	return credential, nil
+}
+
+func (r *mfaRepository) SaveTOTP(ctx context.Context, credential *models.TOTPCredential) error {
+	query := `
+		INSERT INTO user_totp (user_id, secret, confirmed_at, last_used_step, created_at, updated_at)
+		VALUES ($1, $2, NULL, 0, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, last_used_step = 0, created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at
+		WHERE user_totp.confirmed_at IS NULL
+	`
+
+	tag, err := r.pool.Exec(ctx, query, credential.UserID, credential.Secret, credential.CreatedAt, credential.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save totp credential: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *mfaRepository) ConfirmTOTP(ctx context.Context, userID uuid.UUID, step int64, codes []*models.RecoveryCode) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE user_totp SET confirmed_at = NOW(), last_used_step = $2, updated_at = NOW()
+		WHERE user_id = $1 AND confirmed_at IS NULL
+	`, userID, step)
+	if err != nil {
+		return fmt.Errorf("failed to confirm totp credential: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	for _, code := range codes {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO mfa_recovery_codes (id, user_id, code_hash, created_at)
+			VALUES ($1, $2, $3, $4)
+		`, code.ID, code.UserID, code.CodeHash, code.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create recovery code: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit totp confirmation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *mfaRepository) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	query := `
+		UPDATE user_totp SET last_used_step = $2, updated_at = NOW()
+		WHERE user_id = $1 AND last_used_step < $2
+	`
+
+	tag, err := r.pool.Exec(ctx, query, userID, step)
+	if err != nil {
+		return fmt.Errorf("failed to record totp step: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *mfaRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	query := `
+		UPDATE mfa_recovery_codes SET used_at = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`
+
+	tag, err := r.pool.Exec(ctx, query, userID, codeHash)
+	if err != nil {
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *mfaRepository) DeleteTOTP(ctx context.Context, userID uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM user_totp WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete totp credential: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit totp removal: %w", err)
+	}
+
+	return nil
+}
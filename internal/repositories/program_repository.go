@@ -0,0 +1,259 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ProgramRepository interface {
+	Create(ctx context.Context, program *models.Program) error
+	GetByID(ctx context.Context, id, userID uuid.UUID) (*models.Program, error)
+	List(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Program, error)
+	Update(ctx context.Context, program *models.Program) error
+	Delete(ctx context.Context, id, userID uuid.UUID) error
+}
+
+type programRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewProgramRepository(pool *pgxpool.Pool) ProgramRepository {
+	return &programRepository{
+		pool: pool,
+	}
+}
+
+const programColumns = `
+	id, user_id, name, description, weeks, days_per_week, is_public, progression,
+	increment::float8, increment_unit, deload_weeks, deload_percent::float8, load_rounding::float8, created_at, updated_at
+`
+
+func scanProgram(row pgx.Row) (*models.Program, error) {
+	program := &models.Program{}
+	err := row.Scan(
+		&program.ID,
+		&program.UserID,
+		&program.Name,
+		&program.Description,
+		&program.Weeks,
+		&program.DaysPerWeek,
+		&program.IsPublic,
+		&program.Progression,
+		&program.Increment,
+		&program.IncrementUnit,
+		&program.DeloadWeeks,
+		&program.DeloadPercent,
+		&program.LoadRounding,
+		&program.CreatedAt,
+		&program.UpdatedAt,
+	)
+	return program, err
+}
+
+func insertProgramDays(ctx context.Context, tx pgx.Tx, program *models.Program) error {
+	batch := &pgx.Batch{}
+	for _, day := range program.Days {
+		batch.Queue(`
+			INSERT INTO program_days (id, program_id, week, day, template_id, intensity_percent)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`,
+			day.ID, program.ID, day.Week, day.Day, day.TemplateID, day.IntensityPercent,
+		)
+	}
+	return tx.SendBatch(ctx, batch).Close()
+}
+
+func (r *programRepository) Create(ctx context.Context, program *models.Program) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO programs (
+			id, user_id, name, description, weeks, days_per_week, is_public, progression,
+			increment, increment_unit, deload_weeks, deload_percent, load_rounding, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`,
+		program.ID, program.UserID, program.Name, program.Description, program.Weeks, program.DaysPerWeek,
+		program.IsPublic, program.Progression, program.Increment, program.IncrementUnit, program.DeloadWeeks,
+		program.DeloadPercent, program.LoadRounding, program.CreatedAt, program.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create program: %w", err)
+	}
+
+	if err := insertProgramDays(ctx, tx, program); err != nil {
+		return fmt.Errorf("failed to create program days: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit program: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID returns a program owned by the user or shared publicly.
+func (r *programRepository) GetByID(ctx context.Context, id, userID uuid.UUID) (*models.Program, error) {
+	query := `SELECT` + programColumns + `
+		FROM programs
+		WHERE id = $1 AND (user_id = $2 OR is_public)
+	`
+
+	program, err := scanProgram(r.pool.QueryRow(ctx, query, id, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get program by id: %w", err)
+	}
+
+	if err := r.loadDays(ctx, []*models.Program{program}); err != nil {
+		return nil, err
+	}
+
+	return program, nil
+}
+
+// List returns the user's own programs followed by public programs from others.
+func (r *programRepository) List(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Program, error) {
+	query := `SELECT` + programColumns + `
+		FROM programs
+		WHERE user_id = $1 OR is_public
+		ORDER BY user_id <> $1, name, created_at
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list programs: %w", err)
+	}
+	defer rows.Close()
+
+	programs := make([]*models.Program, 0)
+	for rows.Next() {
+		program, err := scanProgram(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan program: %w", err)
+		}
+		programs = append(programs, program)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate programs: %w", err)
+	}
+
+	if err := r.loadDays(ctx, programs); err != nil {
+		return nil, err
+	}
+
+	return programs, nil
+}
+
+func (r *programRepository) loadDays(ctx context.Context, programs []*models.Program) error {
+	if len(programs) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(programs))
+	byID := make(map[uuid.UUID]*models.Program, len(programs))
+	for _, program := range programs {
+		program.Days = []*models.ProgramDay{}
+		ids = append(ids, program.ID)
+		byID[program.ID] = program
+	}
+
+	query := `
+		SELECT id, program_id, week, day, template_id, intensity_percent::float8
+		FROM program_days
+		WHERE program_id = ANY($1)
+		ORDER BY program_id, week, day
+	`
+
+	rows, err := r.pool.Query(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("failed to list program days: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		day := &models.ProgramDay{}
+		err := rows.Scan(&day.ID, &day.ProgramID, &day.Week, &day.Day, &day.TemplateID, &day.IntensityPercent)
+		if err != nil {
+			return fmt.Errorf("failed to scan program day: %w", err)
+		}
+		if program, ok := byID[day.ProgramID]; ok {
+			program.Days = append(program.Days, day)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate program days: %w", err)
+	}
+
+	return nil
+}
+
+// Update saves the program fields and replaces its schedule. Only the owner can update.
+func (r *programRepository) Update(ctx context.Context, program *models.Program) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE programs
+		SET name = $3, description = $4, weeks = $5, days_per_week = $6, is_public = $7, progression = $8,
+			increment = $9, increment_unit = $10, deload_weeks = $11, deload_percent = $12, load_rounding = $13,
+			updated_at = $14
+		WHERE id = $1 AND user_id = $2
+	`,
+		program.ID, program.UserID, program.Name, program.Description, program.Weeks, program.DaysPerWeek,
+		program.IsPublic, program.Progression, program.Increment, program.IncrementUnit, program.DeloadWeeks,
+		program.DeloadPercent, program.LoadRounding, program.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update program: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM program_days WHERE program_id = $1`, program.ID); err != nil {
+		return fmt.Errorf("failed to clear program days: %w", err)
+	}
+
+	if err := insertProgramDays(ctx, tx, program); err != nil {
+		return fmt.Errorf("failed to update program days: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit program: %w", err)
+	}
+
+	return nil
+}
+
+func (r *programRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	query := `DELETE FROM programs WHERE id = $1 AND user_id = $2`
+
+	tag, err := r.pool.Exec(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete program: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
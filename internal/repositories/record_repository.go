@@ -0,0 +1,204 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RecordRepository interface {
+	Create(ctx context.Context, records []*models.PersonalRecord) error
+	// GetBest returns the highest record of a kind. weightKg is only used for
+	// max_reps, where records are kept per weight.
+	GetBest(
+		ctx context.Context,
+		userID, exerciseID uuid.UUID,
+		recordType models.RecordType,
+		formula models.E1RMFormula,
+		weightKg float64,
+	) (*models.PersonalRecord, error)
+	List(ctx context.Context, userID uuid.UUID, filter models.RecordFilter) ([]*models.PersonalRecord, error)
+	DeleteSessionVolume(ctx context.Context, workoutID, exerciseID uuid.UUID) error
+}
+
+type recordRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRecordRepository(pool *pgxpool.Pool) RecordRepository {
+	return &recordRepository{
+		pool: pool,
+	}
+}
+
+const recordColumns = `
+	pr.id, pr.user_id, pr.exercise_id, e.name, pr.workout_id, pr.set_id, pr.record_type, pr.formula,
+	pr.value::float8, pr.weight_kg::float8, pr.weight::float8, pr.weight_unit, pr.reps, pr.achieved_at, pr.created_at
+`
+
+func scanRecord(row pgx.Row) (*models.PersonalRecord, error) {
+	record := &models.PersonalRecord{}
+	err := row.Scan(
+		&record.ID,
+		&record.UserID,
+		&record.ExerciseID,
+		&record.ExerciseName,
+		&record.WorkoutID,
+		&record.SetID,
+		&record.RecordType,
+		&record.Formula,
+		&record.Value,
+		&record.WeightKg,
+		&record.Weight,
+		&record.WeightUnit,
+		&record.Reps,
+		&record.AchievedAt,
+		&record.CreatedAt,
+	)
+	return record, err
+}
+
+func (r *recordRepository) Create(ctx context.Context, records []*models.PersonalRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	for _, record := range records {
+		batch.Queue(`
+			INSERT INTO personal_records (
+				id, user_id, exercise_id, workout_id, set_id, record_type, formula,
+				value, weight_kg, weight, weight_unit, reps, achieved_at, created_at
+			)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		`,
+			record.ID, record.UserID, record.ExerciseID, record.WorkoutID, record.SetID, record.RecordType,
+			record.Formula, record.Value, record.WeightKg, record.Weight, record.WeightUnit, record.Reps,
+			record.AchievedAt, record.CreatedAt,
+		)
+	}
+
+	if err := r.pool.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to create personal records: %w", err)
+	}
+
+	return nil
+}
+
+func (r *recordRepository) GetBest(
+	ctx context.Context,
+	userID, exerciseID uuid.UUID,
+	recordType models.RecordType,
+	formula models.E1RMFormula,
+	weightKg float64,
+) (*models.PersonalRecord, error) {
+	query := `SELECT` + recordColumns + `
+		FROM personal_records pr
+		JOIN exercises e ON e.id = pr.exercise_id
+		WHERE pr.user_id = $1 AND pr.exercise_id = $2 AND pr.record_type = $3 AND pr.formula = $4
+			AND ($3 <> 'max_reps' OR pr.weight_kg = $5)
+		ORDER BY pr.value DESC, pr.achieved_at
+		LIMIT 1
+	`
+
+	record, err := scanRecord(r.pool.QueryRow(ctx, query, userID, exerciseID, recordType, formula, weightKg))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get best record: %w", err)
+	}
+
+	return record, nil
+}
+
+func (r *recordRepository) List(
+	ctx context.Context,
+	userID uuid.UUID,
+	filter models.RecordFilter,
+) ([]*models.PersonalRecord, error) {
+	conditions := []string{"pr.user_id = $1"}
+	args := []interface{}{userID}
+
+	addCondition := func(format string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if filter.ExerciseID != nil {
+		addCondition("pr.exercise_id = $%d", *filter.ExerciseID)
+	}
+	if filter.RecordType != "" {
+		addCondition("pr.record_type = $%d", filter.RecordType)
+	}
+	if filter.Formula != "" {
+		addCondition("(pr.record_type <> 'estimated_1rm' OR pr.formula = $%d)", filter.Formula)
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	where := strings.Join(conditions, " AND ")
+
+	var query string
+	if filter.History {
+		query = fmt.Sprintf(`SELECT`+recordColumns+`
+			FROM personal_records pr
+			JOIN exercises e ON e.id = pr.exercise_id
+			WHERE %s
+			ORDER BY pr.achieved_at DESC, pr.created_at DESC
+			LIMIT $%d OFFSET $%d
+		`, where, len(args)-1, len(args))
+	} else {
+		// Keep the best row per exercise and kind; rep records are kept per weight.
+		query = fmt.Sprintf(`SELECT * FROM (
+				SELECT DISTINCT ON (
+					pr.exercise_id, pr.record_type, pr.formula,
+					CASE WHEN pr.record_type = 'max_reps' THEN pr.weight_kg END
+				)`+recordColumns+`
+				FROM personal_records pr
+				JOIN exercises e ON e.id = pr.exercise_id
+				WHERE %s
+				ORDER BY pr.exercise_id, pr.record_type, pr.formula,
+					CASE WHEN pr.record_type = 'max_reps' THEN pr.weight_kg END, pr.value DESC, pr.achieved_at
+			) best
+			ORDER BY name, record_type, weight_kg
+			LIMIT $%d OFFSET $%d
+		`, where, len(args)-1, len(args))
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list personal records: %w", err)
+	}
+	defer rows.Close()
+
+	records := make([]*models.PersonalRecord, 0)
+	for rows.Next() {
+		record, err := scanRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan personal record: %w", err)
+		}
+		records = append(records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate personal records: %w", err)
+	}
+
+	return records, nil
+}
+
+func (r *recordRepository) DeleteSessionVolume(ctx context.Context, workoutID, exerciseID uuid.UUID) error {
+	query := `DELETE FROM personal_records WHERE workout_id = $1 AND exercise_id = $2 AND record_type = 'session_volume'`
+
+	if _, err := r.pool.Exec(ctx, query, workoutID, exerciseID); err != nil {
+		return fmt.Errorf("failed to delete session volume records: %w", err)
+	}
+
+	return nil
+}
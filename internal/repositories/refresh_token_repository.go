@@ -2,19 +2,34 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/aleksandr/strive-api/internal/models"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type RefreshTokenRepository interface {
 	Create(ctx context.Context, token *models.RefreshToken) error
-	GetByToken(ctx context.Context, token string) (*models.RefreshToken, error)
+	// GetByToken looks a token up by its digest. It returns unexpired tokens,
+	// including revoked ones so that reuse can be detected; ErrNotFound otherwise.
+	GetByToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	// GetByUserID returns the user's live (unexpired, unrevoked) tokens.
 	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.RefreshToken, error)
-	Delete(ctx context.Context, token string) error
+	Delete(ctx context.Context, tokenHash string) error
+	// Rotate revokes the token oldID and stores next in one transaction. It
+	// returns ErrNotFound if oldID was already revoked, so of several concurrent
+	// rotations of the same token only one succeeds.
+	Rotate(ctx context.Context, oldID uuid.UUID, next *models.RefreshToken) error
+	// RevokeFamily revokes every live token in one of the user's families,
+	// returning ErrNotFound if the family has none.
+	RevokeFamily(ctx context.Context, userID, familyID uuid.UUID) error
 	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+	// DeleteByUserIDExcept removes every token of the user outside the family of
+	// the token with digest keepTokenHash.
+	DeleteByUserIDExcept(ctx context.Context, userID uuid.UUID, keepTokenHash string) error
 	DeleteExpired(ctx context.Context) error
 }
 
@@ -28,13 +43,47 @@ func NewRefreshTokenRepository(pool *pgxpool.Pool) RefreshTokenRepository {
 	}
 }
 
-func (r *refreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
-	query := `
-		INSERT INTO refresh_tokens (id, user_id, token, expires_at, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
-	`
+const refreshTokenColumns = `
+	id, user_id, family_id, token_hash, user_agent, ip_address, device_name, last_used_at, expires_at, revoked_at,
+	created_at, updated_at
+`
+
+func scanRefreshToken(row pgx.Row) (*models.RefreshToken, error) {
+	token := &models.RefreshToken{}
+	err := row.Scan(
+		&token.ID,
+		&token.UserID,
+		&token.FamilyID,
+		&token.TokenHash,
+		&token.UserAgent,
+		&token.IPAddress,
+		&token.DeviceName,
+		&token.LastUsedAt,
+		&token.ExpiresAt,
+		&token.RevokedAt,
+		&token.CreatedAt,
+		&token.UpdatedAt,
+	)
+	return token, err
+}
+
+const insertRefreshTokenQuery = `
+	INSERT INTO refresh_tokens (
+		id, user_id, family_id, token_hash, user_agent, ip_address, device_name, last_used_at, expires_at, revoked_at,
+		created_at, updated_at
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+`
+
+func refreshTokenArgs(token *models.RefreshToken) []any {
+	return []any{
+		token.ID, token.UserID, token.FamilyID, token.TokenHash, token.UserAgent, token.IPAddress, token.DeviceName,
+		token.LastUsedAt, token.ExpiresAt, token.RevokedAt, token.CreatedAt, token.UpdatedAt,
+	}
+}
 
-	_, err := r.pool.Exec(ctx, query, token.ID, token.UserID, token.Token, token.ExpiresAt, token.CreatedAt, token.UpdatedAt)
+func (r *refreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	_, err := r.pool.Exec(ctx, insertRefreshTokenQuery, refreshTokenArgs(token)...)
 	if err != nil {
 		return fmt.Errorf("failed to create refresh token: %w", err)
 	}
@@ -42,22 +91,16 @@ func (r *refreshTokenRepository) Create(ctx context.Context, token *models.Refre
 	return nil
 }
 
-func (r *refreshTokenRepository) GetByToken(ctx context.Context, token string) (*models.RefreshToken, error) {
-	query := `
-		SELECT id, user_id, token, expires_at, created_at, updated_at
+func (r *refreshTokenRepository) GetByToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	query := `SELECT` + refreshTokenColumns + `
 		FROM refresh_tokens
-		WHERE token = $1 AND expires_at > NOW()
+		WHERE token_hash = $1 AND expires_at > NOW()
 	`
 
-	refreshToken := &models.RefreshToken{}
-	err := r.pool.QueryRow(ctx, query, token).Scan(
-		&refreshToken.ID,
-		&refreshToken.UserID,
-		&refreshToken.Token,
-		&refreshToken.ExpiresAt,
-		&refreshToken.CreatedAt,
-		&refreshToken.UpdatedAt,
-	)
+	refreshToken, err := scanRefreshToken(r.pool.QueryRow(ctx, query, tokenHash))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get refresh token: %w", err)
 	}
@@ -66,11 +109,10 @@ func (r *refreshTokenRepository) GetByToken(ctx context.Context, token string) (
 }
 
 func (r *refreshTokenRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.RefreshToken, error) {
-	query := `
-		SELECT id, user_id, token, expires_at, created_at, updated_at
+	query := `SELECT` + refreshTokenColumns + `
 		FROM refresh_tokens
-		WHERE user_id = $1 AND expires_at > NOW()
-		ORDER BY created_at DESC
+		WHERE user_id = $1 AND expires_at > NOW() AND revoked_at IS NULL
+		ORDER BY last_used_at DESC
 	`
 
 	rows, err := r.pool.Query(ctx, query, userID)
@@ -81,15 +123,7 @@ func (r *refreshTokenRepository) GetByUserID(ctx context.Context, userID uuid.UU
 
 	var tokens []*models.RefreshToken
 	for rows.Next() {
-		token := &models.RefreshToken{}
-		err := rows.Scan(
-			&token.ID,
-			&token.UserID,
-			&token.Token,
-			&token.ExpiresAt,
-			&token.CreatedAt,
-			&token.UpdatedAt,
-		)
+		token, err := scanRefreshToken(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan refresh token: %w", err)
 		}
@@ -103,10 +137,10 @@ func (r *refreshTokenRepository) GetByUserID(ctx context.Context, userID uuid.UU
 	return tokens, nil
 }
 
-func (r *refreshTokenRepository) Delete(ctx context.Context, token string) error {
-	query := `DELETE FROM refresh_tokens WHERE token = $1`
+func (r *refreshTokenRepository) Delete(ctx context.Context, tokenHash string) error {
+	query := `DELETE FROM refresh_tokens WHERE token_hash = $1`
 
-	_, err := r.pool.Exec(ctx, query, token)
+	_, err := r.pool.Exec(ctx, query, tokenHash)
 	if err != nil {
 		return fmt.Errorf("failed to delete refresh token: %w", err)
 	}
@@ -114,6 +148,54 @@ func (r *refreshTokenRepository) Delete(ctx context.Context, token string) error
 	return nil
 }
 
+func (r *refreshTokenRepository) Rotate(ctx context.Context, oldID uuid.UUID, next *models.RefreshToken) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// The conditional update locks the row, so a concurrent rotation waits here
+	// and then finds it already revoked.
+	tag, err := tx.Exec(ctx, `
+		UPDATE refresh_tokens SET revoked_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+	`, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	if _, err := tx.Exec(ctx, insertRefreshTokenQuery, refreshTokenArgs(next)...); err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit refresh token rotation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, userID, familyID uuid.UUID) error {
+	query := `
+		UPDATE refresh_tokens SET revoked_at = NOW()
+		WHERE user_id = $1 AND family_id = $2 AND revoked_at IS NULL AND expires_at > NOW()
+	`
+
+	tag, err := r.pool.Exec(ctx, query, userID, familyID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
 func (r *refreshTokenRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
 	query := `DELETE FROM refresh_tokens WHERE user_id = $1`
 
@@ -125,6 +207,22 @@ func (r *refreshTokenRepository) DeleteByUserID(ctx context.Context, userID uuid
 	return nil
 }
 
+func (r *refreshTokenRepository) DeleteByUserIDExcept(ctx context.Context, userID uuid.UUID, keepTokenHash string) error {
+	// IS DISTINCT FROM: when keepTokenHash is unknown the subquery is NULL and every token goes.
+	query := `
+		DELETE FROM refresh_tokens
+		WHERE user_id = $1
+			AND family_id IS DISTINCT FROM (SELECT family_id FROM refresh_tokens WHERE token_hash = $2 AND user_id = $1)
+	`
+
+	_, err := r.pool.Exec(ctx, query, userID, keepTokenHash)
+	if err != nil {
+		return fmt.Errorf("failed to delete other refresh tokens: %w", err)
+	}
+
+	return nil
+}
+
 func (r *refreshTokenRepository) DeleteExpired(ctx context.Context) error {
 	query := `DELETE FROM refresh_tokens WHERE expires_at <= NOW()`
 
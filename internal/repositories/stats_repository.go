@@ -0,0 +1,199 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StatsRepository interface {
+	VolumeByMuscle(ctx context.Context, userID uuid.UUID, q models.StatsQuery) ([]models.StatsRow, error)
+	SetsByExercise(ctx context.Context, userID uuid.UUID, q models.StatsQuery) ([]models.StatsRow, error)
+	WorkoutCounts(ctx context.Context, userID uuid.UUID, q models.StatsQuery) ([]models.StatsRow, error)
+	AverageDurations(ctx context.Context, userID uuid.UUID, q models.StatsQuery) ([]models.StatsRow, error)
+	// ActivePeriods lists every period with at least one workout, oldest first, ignoring the date range.
+	ActivePeriods(
+		ctx context.Context,
+		userID uuid.UUID,
+		interval models.StatsInterval,
+		timezone string,
+		weekStart models.WeekStart,
+	) ([]string, error)
+}
+
+type statsRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewStatsRepository(pool *pgxpool.Pool) StatsRepository {
+	return &statsRepository{
+		pool: pool,
+	}
+}
+
+// Every query binds $1 user, $2 from, $3 to, $4 interval, $5 timezone and $6
+// the days weeks start before Monday. date_trunc always starts weeks on Monday,
+// so the time is shifted forward by $6 days before truncating and back after.
+const (
+	statsPeriod = `to_char(
+		date_trunc($4, (w.started_at AT TIME ZONE $5) + make_interval(days => $6)) - make_interval(days => $6),
+		'YYYY-MM-DD'
+	)`
+	statsRange  = `w.user_id = $1 AND w.started_at >= $2 AND w.started_at < $3`
+	setWeightKg = `CASE WHEN s.weight_unit = 'lb' THEN s.weight * 0.45359237 ELSE s.weight END`
+)
+
+func statsArgs(userID uuid.UUID, q models.StatsQuery) []interface{} {
+	return []interface{}{userID, q.From, q.To, string(q.Interval), q.Timezone, weekOffset(q.Interval, q.WeekStart)}
+}
+
+// weekOffset returns how many days before Monday weeks start, or 0 when the
+// interval is not weeks.
+func weekOffset(interval models.StatsInterval, weekStart models.WeekStart) int {
+	if interval != models.StatsIntervalWeek {
+		return 0
+	}
+	return (int(time.Monday) - int(weekStart.Weekday()) + 7) % 7
+}
+
+func (r *statsRepository) queryRows(ctx context.Context, query string, args ...interface{}) ([]models.StatsRow, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stats: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]models.StatsRow, 0)
+	for rows.Next() {
+		var row models.StatsRow
+		if err := rows.Scan(&row.Key, &row.Label, &row.Period, &row.Value); err != nil {
+			return nil, fmt.Errorf("failed to scan stats row: %w", err)
+		}
+		result = append(result, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate stats rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// VolumeByMuscle sums weight x reps in kg of working sets. Each set counts in
+// full towards every primary muscle of its catalog exercise.
+func (r *statsRepository) VolumeByMuscle(ctx context.Context, userID uuid.UUID, q models.StatsQuery) ([]models.StatsRow, error) {
+	args := statsArgs(userID, q)
+	muscleCondition := ""
+	if q.Muscle != "" {
+		args = append(args, q.Muscle)
+		muscleCondition = fmt.Sprintf("AND m.muscle = $%d", len(args))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT m.muscle, m.muscle, %s AS period, SUM(%s * s.reps)::float8
+		FROM workouts w
+		JOIN workout_exercises we ON we.workout_id = w.id
+		JOIN exercises e ON e.id = we.exercise_id
+		CROSS JOIN LATERAL unnest(e.primary_muscles) AS m(muscle)
+		JOIN exercise_sets s ON s.workout_exercise_id = we.id
+		WHERE %s AND NOT s.is_warmup %s
+		GROUP BY 1, 2, 3
+		ORDER BY 3, 1
+	`, statsPeriod, setWeightKg, statsRange, muscleCondition)
+
+	return r.queryRows(ctx, query, args...)
+}
+
+// SetsByExercise counts working sets per exercise. Entries without a catalog
+// exercise are grouped by their name.
+func (r *statsRepository) SetsByExercise(ctx context.Context, userID uuid.UUID, q models.StatsQuery) ([]models.StatsRow, error) {
+	args := statsArgs(userID, q)
+	exerciseCondition := ""
+	if q.ExerciseID != nil {
+		args = append(args, *q.ExerciseID)
+		exerciseCondition = fmt.Sprintf("AND we.exercise_id = $%d", len(args))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT COALESCE(we.exercise_id::text, LOWER(we.name)), MIN(COALESCE(e.name, we.name)),
+			%s AS period, COUNT(s.id)::float8
+		FROM workouts w
+		JOIN workout_exercises we ON we.workout_id = w.id
+		LEFT JOIN exercises e ON e.id = we.exercise_id
+		JOIN exercise_sets s ON s.workout_exercise_id = we.id
+		WHERE %s AND NOT s.is_warmup %s
+		GROUP BY 1, 3
+		ORDER BY 3, 2
+	`, statsPeriod, statsRange, exerciseCondition)
+
+	return r.queryRows(ctx, query, args...)
+}
+
+func (r *statsRepository) WorkoutCounts(ctx context.Context, userID uuid.UUID, q models.StatsQuery) ([]models.StatsRow, error) {
+	query := fmt.Sprintf(`
+		SELECT 'workouts', 'Workouts', %s AS period, COUNT(*)::float8
+		FROM workouts w
+		WHERE %s
+		GROUP BY 3
+		ORDER BY 3
+	`, statsPeriod, statsRange)
+
+	return r.queryRows(ctx, query, statsArgs(userID, q)...)
+}
+
+// AverageDurations averages finished workouts' length in minutes.
+func (r *statsRepository) AverageDurations(ctx context.Context, userID uuid.UUID, q models.StatsQuery) ([]models.StatsRow, error) {
+	query := fmt.Sprintf(`
+		SELECT 'duration', 'Average duration', %s AS period,
+			AVG(EXTRACT(EPOCH FROM w.finished_at - w.started_at) / 60)::float8
+		FROM workouts w
+		WHERE %s AND w.finished_at IS NOT NULL
+		GROUP BY 3
+		ORDER BY 3
+	`, statsPeriod, statsRange)
+
+	return r.queryRows(ctx, query, statsArgs(userID, q)...)
+}
+
+func (r *statsRepository) ActivePeriods(
+	ctx context.Context,
+	userID uuid.UUID,
+	interval models.StatsInterval,
+	timezone string,
+	weekStart models.WeekStart,
+) ([]string, error) {
+	query := `
+		SELECT DISTINCT to_char(
+			date_trunc($2, (w.started_at AT TIME ZONE $3) + make_interval(days => $4)) - make_interval(days => $4),
+			'YYYY-MM-DD'
+		) AS period
+		FROM workouts w
+		WHERE w.user_id = $1
+		ORDER BY period
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID, string(interval), timezone, weekOffset(interval, weekStart))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query active periods: %w", err)
+	}
+	defer rows.Close()
+
+	periods := make([]string, 0)
+	for rows.Next() {
+		var period string
+		if err := rows.Scan(&period); err != nil {
+			return nil, fmt.Errorf("failed to scan active period: %w", err)
+		}
+		periods = append(periods, period)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate active periods: %w", err)
+	}
+
+	return periods, nil
+}
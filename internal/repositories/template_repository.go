@@ -0,0 +1,274 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TemplateRepository interface {
+	Create(ctx context.Context, template *models.WorkoutTemplate) error
+	GetByID(ctx context.Context, id, userID uuid.UUID) (*models.WorkoutTemplate, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.WorkoutTemplate, error)
+	Update(ctx context.Context, template *models.WorkoutTemplate) error
+	Delete(ctx context.Context, id, userID uuid.UUID) error
+}
+
+type templateRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewTemplateRepository(pool *pgxpool.Pool) TemplateRepository {
+	return &templateRepository{
+		pool: pool,
+	}
+}
+
+const templateExerciseColumns = `
+	id, template_id, exercise_id, name, position, target_sets, target_reps,
+	target_weight::float8, weight_unit, rest_seconds, notes
+`
+
+func scanTemplateExercise(row pgx.Row) (*models.TemplateExercise, error) {
+	exercise := &models.TemplateExercise{}
+	err := row.Scan(
+		&exercise.ID,
+		&exercise.TemplateID,
+		&exercise.ExerciseID,
+		&exercise.Name,
+		&exercise.Position,
+		&exercise.TargetSets,
+		&exercise.TargetReps,
+		&exercise.TargetWeight,
+		&exercise.WeightUnit,
+		&exercise.RestSeconds,
+		&exercise.Notes,
+	)
+	return exercise, err
+}
+
+// insertTemplateExercises queues the template's exercises on the transaction. The
+// caller is responsible for clearing any previous rows.
+func insertTemplateExercises(ctx context.Context, tx pgx.Tx, template *models.WorkoutTemplate) error {
+	batch := &pgx.Batch{}
+	for _, exercise := range template.Exercises {
+		batch.Queue(`
+			INSERT INTO template_exercises (
+				id, template_id, exercise_id, name, position, target_sets, target_reps,
+				target_weight, weight_unit, rest_seconds, notes
+			)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		`,
+			exercise.ID, template.ID, exercise.ExerciseID, exercise.Name, exercise.Position,
+			exercise.TargetSets, exercise.TargetReps, exercise.TargetWeight, exercise.WeightUnit,
+			exercise.RestSeconds, exercise.Notes,
+		)
+	}
+	return tx.SendBatch(ctx, batch).Close()
+}
+
+func (r *templateRepository) Create(ctx context.Context, template *models.WorkoutTemplate) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO workout_templates (id, user_id, name, notes, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`,
+		template.ID, template.UserID, template.Name, template.Notes, template.CreatedAt, template.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create template: %w", err)
+	}
+
+	if err := insertTemplateExercises(ctx, tx, template); err != nil {
+		return fmt.Errorf("failed to create template exercises: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit template: %w", err)
+	}
+
+	return nil
+}
+
+func (r *templateRepository) GetByID(ctx context.Context, id, userID uuid.UUID) (*models.WorkoutTemplate, error) {
+	query := `
+		SELECT id, user_id, name, notes, created_at, updated_at
+		FROM workout_templates
+		WHERE id = $1 AND user_id = $2
+	`
+
+	template := &models.WorkoutTemplate{}
+	err := r.pool.QueryRow(ctx, query, id, userID).Scan(
+		&template.ID,
+		&template.UserID,
+		&template.Name,
+		&template.Notes,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template by id: %w", err)
+	}
+
+	if err := r.loadExercises(ctx, []*models.WorkoutTemplate{template}); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (r *templateRepository) ListByUserID(
+	ctx context.Context,
+	userID uuid.UUID,
+	limit, offset int,
+) ([]*models.WorkoutTemplate, error) {
+	query := `
+		SELECT id, user_id, name, notes, created_at, updated_at
+		FROM workout_templates
+		WHERE user_id = $1
+		ORDER BY name, created_at
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := make([]*models.WorkoutTemplate, 0)
+	for rows.Next() {
+		template := &models.WorkoutTemplate{}
+		err := rows.Scan(
+			&template.ID,
+			&template.UserID,
+			&template.Name,
+			&template.Notes,
+			&template.CreatedAt,
+			&template.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan template: %w", err)
+		}
+		templates = append(templates, template)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate templates: %w", err)
+	}
+
+	if err := r.loadExercises(ctx, templates); err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (r *templateRepository) loadExercises(ctx context.Context, templates []*models.WorkoutTemplate) error {
+	if len(templates) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(templates))
+	byID := make(map[uuid.UUID]*models.WorkoutTemplate, len(templates))
+	for _, template := range templates {
+		template.Exercises = []*models.TemplateExercise{}
+		ids = append(ids, template.ID)
+		byID[template.ID] = template
+	}
+
+	query := `SELECT` + templateExerciseColumns + `
+		FROM template_exercises
+		WHERE template_id = ANY($1)
+		ORDER BY template_id, position
+	`
+
+	rows, err := r.pool.Query(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("failed to list template exercises: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		exercise, err := scanTemplateExercise(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan template exercise: %w", err)
+		}
+		if template, ok := byID[exercise.TemplateID]; ok {
+			template.Exercises = append(template.Exercises, exercise)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate template exercises: %w", err)
+	}
+
+	return nil
+}
+
+// Update saves the template fields and replaces its exercise list.
+func (r *templateRepository) Update(ctx context.Context, template *models.WorkoutTemplate) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE workout_templates
+		SET name = $3, notes = $4, updated_at = $5
+		WHERE id = $1 AND user_id = $2
+	`,
+		template.ID, template.UserID, template.Name, template.Notes, template.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update template: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM template_exercises WHERE template_id = $1`, template.ID); err != nil {
+		return fmt.Errorf("failed to clear template exercises: %w", err)
+	}
+
+	if err := insertTemplateExercises(ctx, tx, template); err != nil {
+		return fmt.Errorf("failed to update template exercises: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit template: %w", err)
+	}
+
+	return nil
+}
+
+func (r *templateRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	query := `DELETE FROM workout_templates WHERE id = $1 AND user_id = $2`
+
+	tag, err := r.pool.Exec(ctx, query, id, userID)
+	if isForeignKeyViolation(err) {
+		return ErrReferenced
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
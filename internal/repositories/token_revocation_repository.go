@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TokenRevocationRepository interface {
+	// Revoke records a revoked access token; revoking it again is a no-op.
+	Revoke(ctx context.Context, token *models.RevokedAccessToken) error
+	// ListActive returns the revoked tokens that have not expired yet.
+	ListActive(ctx context.Context) ([]*models.RevokedAccessToken, error)
+	// ListTokensValidAfter returns every user's tokens_valid_after cutoff that is
+	// later than since; older cutoffs cannot affect an unexpired token.
+	ListTokensValidAfter(ctx context.Context, since time.Time) (map[uuid.UUID]time.Time, error)
+	// SetTokensValidAfter moves the user's tokens_valid_after forward to at,
+	// leaving a later cutoff in place. It returns ErrNotFound for unknown users.
+	SetTokensValidAfter(ctx context.Context, userID uuid.UUID, at time.Time) error
+	DeleteExpired(ctx context.Context) error
+}
+
+type tokenRevocationRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewTokenRevocationRepository(pool *pgxpool.Pool) TokenRevocationRepository {
+	return &tokenRevocationRepository{
+		pool: pool,
+	}
+}
+
+func (r *tokenRevocationRepository) Revoke(ctx context.Context, token *models.RevokedAccessToken) error {
+	query := `
+		INSERT INTO revoked_access_tokens (jti, user_id, expires_at, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (jti) DO NOTHING
+	`
+
+	_, err := r.pool.Exec(ctx, query, token.JTI, token.UserID, token.ExpiresAt, token.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to revoke access token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *tokenRevocationRepository) ListActive(ctx context.Context) ([]*models.RevokedAccessToken, error) {
+	query := `
+		SELECT jti, user_id, expires_at, created_at
+		FROM revoked_access_tokens
+		WHERE expires_at > NOW()
+	`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revoked access tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []*models.RevokedAccessToken
+	for rows.Next() {
+		token := &models.RevokedAccessToken{}
+		if err := rows.Scan(&token.JTI, &token.UserID, &token.ExpiresAt, &token.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan revoked access token: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate revoked access tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+func (r *tokenRevocationRepository) ListTokensValidAfter(ctx context.Context, since time.Time) (map[uuid.UUID]time.Time, error) {
+	query := `SELECT id, tokens_valid_after FROM users WHERE tokens_valid_after > $1`
+
+	rows, err := r.pool.Query(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list token cutoffs: %w", err)
+	}
+	defer rows.Close()
+
+	cutoffs := make(map[uuid.UUID]time.Time)
+	for rows.Next() {
+		var userID uuid.UUID
+		var validAfter time.Time
+		if err := rows.Scan(&userID, &validAfter); err != nil {
+			return nil, fmt.Errorf("failed to scan token cutoff: %w", err)
+		}
+		cutoffs[userID] = validAfter
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate token cutoffs: %w", err)
+	}
+
+	return cutoffs, nil
+}
+
+func (r *tokenRevocationRepository) SetTokensValidAfter(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	query := `UPDATE users SET tokens_valid_after = GREATEST(tokens_valid_after, $2) WHERE id = $1`
+
+	tag, err := r.pool.Exec(ctx, query, userID, at)
+	if err != nil {
+		return fmt.Errorf("failed to set token cutoff: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *tokenRevocationRepository) DeleteExpired(ctx context.Context) error {
+	query := `DELETE FROM revoked_access_tokens WHERE expires_at <= NOW()`
+
+	_, err := r.pool.Exec(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to delete expired revoked access tokens: %w", err)
+	}
+
+	return nil
+}
@@ -2,10 +2,14 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/aleksandr/strive-api/internal/models"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -15,6 +19,13 @@ type UserRepository interface {
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
 	Update(ctx context.Context, user *models.User) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	// List returns users matching filter, newest first.
+	List(ctx context.Context, filter models.UserFilter) ([]*models.User, error)
+	// Disable marks the user disabled at at and rejects their access tokens
+	// issued before it. It returns ErrNotFound if the user is already disabled.
+	Disable(ctx context.Context, id uuid.UUID, at time.Time) error
+	// Enable clears a disable, returning ErrNotFound if the user is not disabled.
+	Enable(ctx context.Context, id uuid.UUID) error
 }
 
 type userRepository struct {
@@ -27,13 +38,52 @@ func NewUserRepository(pool *pgxpool.Pool) UserRepository {
 	}
 }
 
+const userColumns = `
+	id, email, password_hash, display_name, to_char(birth_date, 'YYYY-MM-DD'), sex, height_cm::float8,
+	weight_unit, timezone, locale, week_start, role,
+	ARRAY(SELECT permission FROM role_permissions WHERE role_permissions.role = users.role ORDER BY permission),
+	email_verified_at, tokens_valid_after, disabled_at, created_at, updated_at
+`
+
+func scanUser(row pgx.Row) (*models.User, error) {
+	user := &models.User{}
+	err := row.Scan(
+		&user.ID,
+		&user.Email,
+		&user.PasswordHash,
+		&user.DisplayName,
+		&user.BirthDate,
+		&user.Sex,
+		&user.HeightCm,
+		&user.WeightUnit,
+		&user.Timezone,
+		&user.Locale,
+		&user.WeekStart,
+		&user.Role,
+		&user.Permissions,
+		&user.EmailVerifiedAt,
+		&user.TokensValidAfter,
+		&user.DisabledAt,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	return user, err
+}
+
 func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 	query := `
-		INSERT INTO users (id, email, password_hash, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO users (
+			id, email, password_hash, display_name, birth_date, sex, height_cm,
+			weight_unit, timezone, locale, week_start, role, email_verified_at, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5::date, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 	`
 
-	_, err := r.pool.Exec(ctx, query, user.ID, user.Email, user.PasswordHash, user.CreatedAt, user.UpdatedAt)
+	_, err := r.pool.Exec(ctx, query,
+		user.ID, user.Email, user.PasswordHash, user.DisplayName, user.BirthDate, user.Sex, user.HeightCm,
+		user.WeightUnit, user.Timezone, user.Locale, user.WeekStart, user.Role, user.EmailVerifiedAt,
+		user.CreatedAt, user.UpdatedAt,
+	)
 	if err != nil {
 		return fmt.Errorf("failed to create user: %w", err)
 	}
@@ -42,20 +92,15 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 }
 
 func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
-	query := `
-		SELECT id, email, password_hash, created_at, updated_at
+	query := `SELECT` + userColumns + `
 		FROM users
 		WHERE id = $1
 	`
 
-	user := &models.User{}
-	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&user.ID,
-		&user.Email,
-		&user.PasswordHash,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	user, err := scanUser(r.pool.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user by id: %w", err)
 	}
@@ -64,20 +109,15 @@ func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Use
 }
 
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
-	query := `
-		SELECT id, email, password_hash, created_at, updated_at
+	query := `SELECT` + userColumns + `
 		FROM users
 		WHERE email = $1
 	`
 
-	user := &models.User{}
-	err := r.pool.QueryRow(ctx, query, email).Scan(
-		&user.ID,
-		&user.Email,
-		&user.PasswordHash,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	user, err := scanUser(r.pool.QueryRow(ctx, query, email))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user by email: %w", err)
 	}
@@ -88,14 +128,26 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.
 func (r *userRepository) Update(ctx context.Context, user *models.User) error {
 	query := `
 		UPDATE users
-		SET email = $2, password_hash = $3, updated_at = $4
+		SET email = $2, password_hash = $3, display_name = $4, birth_date = $5::date, sex = $6,
+			height_cm = $7, weight_unit = $8, timezone = $9, locale = $10, week_start = $11,
+			email_verified_at = $12, updated_at = $13,
+			tokens_valid_after = GREATEST(tokens_valid_after, $14)
 		WHERE id = $1
 	`
 
-	_, err := r.pool.Exec(ctx, query, user.ID, user.Email, user.PasswordHash, user.UpdatedAt)
+	// GREATEST skips NULLs and keeps the later cutoff, so an update based on a
+	// stale read cannot undo a revocation.
+	tag, err := r.pool.Exec(ctx, query,
+		user.ID, user.Email, user.PasswordHash, user.DisplayName, user.BirthDate, user.Sex, user.HeightCm,
+		user.WeightUnit, user.Timezone, user.Locale, user.WeekStart, user.EmailVerifiedAt, user.UpdatedAt,
+		user.TokensValidAfter,
+	)
 	if err != nil {
 		return fmt.Errorf("failed to update user: %w", err)
 	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
 
 	return nil
 }
@@ -110,3 +162,88 @@ func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
 
 	return nil
 }
+
+func (r *userRepository) List(ctx context.Context, filter models.UserFilter) ([]*models.User, error) {
+	conditions := []string{"TRUE"}
+	var args []interface{}
+
+	addCondition := func(format string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if filter.Query != "" {
+		addCondition("(email ILIKE '%%' || $%[1]d || '%%' OR display_name ILIKE '%%' || $%[1]d || '%%')", filter.Query)
+	}
+	if filter.Role != "" {
+		addCondition("role = $%d", filter.Role)
+	}
+	if filter.Disabled != nil {
+		addCondition("(disabled_at IS NOT NULL) = $%d", *filter.Disabled)
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`SELECT`+userColumns+`
+		FROM users
+		WHERE %s
+		ORDER BY created_at DESC, id
+		LIMIT $%d OFFSET $%d
+	`, strings.Join(conditions, " AND "), len(args)-1, len(args))
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]*models.User, 0)
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *userRepository) Disable(ctx context.Context, id uuid.UUID, at time.Time) error {
+	query := `
+		UPDATE users
+		SET disabled_at = $2, tokens_valid_after = GREATEST(tokens_valid_after, $2), updated_at = $2
+		WHERE id = $1 AND disabled_at IS NULL
+	`
+
+	tag, err := r.pool.Exec(ctx, query, id, at)
+	if err != nil {
+		return fmt.Errorf("failed to disable user: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepository) Enable(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE users
+		SET disabled_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND disabled_at IS NOT NULL
+	`
+
+	tag, err := r.pool.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to enable user: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WorkoutExerciseRepository interface {
+	Create(ctx context.Context, exercise *models.WorkoutExercise) error
+	GetByID(ctx context.Context, id, workoutID uuid.UUID) (*models.WorkoutExercise, error)
+	ListByWorkoutID(ctx context.Context, workoutID uuid.UUID) ([]*models.WorkoutExercise, error)
+	Update(ctx context.Context, exercise *models.WorkoutExercise) error
+	Delete(ctx context.Context, id, workoutID uuid.UUID) error
+}
+
+type workoutExerciseRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewWorkoutExerciseRepository(pool *pgxpool.Pool) WorkoutExerciseRepository {
+	return &workoutExerciseRepository{
+		pool: pool,
+	}
+}
+
+func (r *workoutExerciseRepository) Create(ctx context.Context, exercise *models.WorkoutExercise) error {
+	query := `
+		INSERT INTO workout_exercises (id, workout_id, exercise_id, name, position, notes, created_at, updated_at)
+		VALUES ($1, $2, $3, $4,
+			(SELECT COALESCE(MAX(position), 0) + 1 FROM workout_exercises WHERE workout_id = $2),
+			$5, $6, $7)
+		RETURNING position
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		exercise.ID, exercise.WorkoutID, exercise.ExerciseID, exercise.Name,
+		exercise.Notes, exercise.CreatedAt, exercise.UpdatedAt,
+	).Scan(&exercise.Position)
+	if err != nil {
+		return fmt.Errorf("failed to create workout exercise: %w", err)
+	}
+
+	return nil
+}
+
+func (r *workoutExerciseRepository) GetByID(ctx context.Context, id, workoutID uuid.UUID) (*models.WorkoutExercise, error) {
+	query := `
+		SELECT id, workout_id, exercise_id, name, position, notes, created_at, updated_at
+		FROM workout_exercises
+		WHERE id = $1 AND workout_id = $2
+	`
+
+	exercise := &models.WorkoutExercise{}
+	err := r.pool.QueryRow(ctx, query, id, workoutID).Scan(
+		&exercise.ID,
+		&exercise.WorkoutID,
+		&exercise.ExerciseID,
+		&exercise.Name,
+		&exercise.Position,
+		&exercise.Notes,
+		&exercise.CreatedAt,
+		&exercise.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workout exercise by id: %w", err)
+	}
+
+	return exercise, nil
+}
+
+func (r *workoutExerciseRepository) ListByWorkoutID(ctx context.Context, workoutID uuid.UUID) ([]*models.WorkoutExercise, error) {
+	query := `
+		SELECT id, workout_id, exercise_id, name, position, notes, created_at, updated_at
+		FROM workout_exercises
+		WHERE workout_id = $1
+		ORDER BY position, created_at
+	`
+
+	rows, err := r.pool.Query(ctx, query, workoutID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workout exercises: %w", err)
+	}
+	defer rows.Close()
+
+	exercises := make([]*models.WorkoutExercise, 0)
+	for rows.Next() {
+		exercise := &models.WorkoutExercise{}
+		err := rows.Scan(
+			&exercise.ID,
+			&exercise.WorkoutID,
+			&exercise.ExerciseID,
+			&exercise.Name,
+			&exercise.Position,
+			&exercise.Notes,
+			&exercise.CreatedAt,
+			&exercise.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan workout exercise: %w", err)
+		}
+		exercises = append(exercises, exercise)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate workout exercises: %w", err)
+	}
+
+	return exercises, nil
+}
+
+func (r *workoutExerciseRepository) Update(ctx context.Context, exercise *models.WorkoutExercise) error {
+	query := `
+		UPDATE workout_exercises
+		SET exercise_id = $3, name = $4, position = $5, notes = $6, updated_at = $7
+		WHERE id = $1 AND workout_id = $2
+	`
+
+	tag, err := r.pool.Exec(ctx, query,
+		exercise.ID, exercise.WorkoutID, exercise.ExerciseID, exercise.Name,
+		exercise.Position, exercise.Notes, exercise.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update workout exercise: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *workoutExerciseRepository) Delete(ctx context.Context, id, workoutID uuid.UUID) error {
+	query := `DELETE FROM workout_exercises WHERE id = $1 AND workout_id = $2`
+
+	tag, err := r.pool.Exec(ctx, query, id, workoutID)
+	if err != nil {
+		return fmt.Errorf("failed to delete workout exercise: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
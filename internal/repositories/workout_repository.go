@@ -0,0 +1,205 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WorkoutRepository interface {
+	Create(ctx context.Context, workout *models.Workout) error
+	CreateWithExercises(ctx context.Context, workout *models.Workout) error
+	GetByID(ctx context.Context, id, userID uuid.UUID) (*models.Workout, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Workout, error)
+	Update(ctx context.Context, workout *models.Workout) error
+	Delete(ctx context.Context, id, userID uuid.UUID) error
+}
+
+type workoutRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewWorkoutRepository(pool *pgxpool.Pool) WorkoutRepository {
+	return &workoutRepository{
+		pool: pool,
+	}
+}
+
+func (r *workoutRepository) Create(ctx context.Context, workout *models.Workout) error {
+	query := `
+		INSERT INTO workouts (id, user_id, title, started_at, finished_at, notes, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		workout.ID, workout.UserID, workout.Title, workout.StartedAt,
+		workout.FinishedAt, workout.Notes, workout.CreatedAt, workout.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create workout: %w", err)
+	}
+
+	return nil
+}
+
+// CreateWithExercises inserts the workout together with its exercises and
+// their sets in a single transaction. Positions are taken from the models.
+func (r *workoutRepository) CreateWithExercises(ctx context.Context, workout *models.Workout) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO workouts (id, user_id, title, started_at, finished_at, notes, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`,
+		workout.ID, workout.UserID, workout.Title, workout.StartedAt,
+		workout.FinishedAt, workout.Notes, workout.CreatedAt, workout.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create workout: %w", err)
+	}
+
+	batch := &pgx.Batch{}
+	for _, exercise := range workout.Exercises {
+		batch.Queue(`
+			INSERT INTO workout_exercises (id, workout_id, exercise_id, name, position, notes, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		`,
+			exercise.ID, exercise.WorkoutID, exercise.ExerciseID, exercise.Name,
+			exercise.Position, exercise.Notes, exercise.CreatedAt, exercise.UpdatedAt,
+		)
+		for _, set := range exercise.Sets {
+			batch.Queue(`
+				INSERT INTO exercise_sets (
+					id, workout_exercise_id, position, reps, weight, weight_unit,
+					rpe, rest_seconds, is_warmup, created_at, updated_at
+				)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			`,
+				set.ID, set.WorkoutExerciseID, set.Position, set.Reps, set.Weight, set.WeightUnit,
+				set.RPE, set.RestSeconds, set.IsWarmup, set.CreatedAt, set.UpdatedAt,
+			)
+		}
+	}
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to create workout exercises: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit workout: %w", err)
+	}
+
+	return nil
+}
+
+func (r *workoutRepository) GetByID(ctx context.Context, id, userID uuid.UUID) (*models.Workout, error) {
+	query := `
+		SELECT id, user_id, title, started_at, finished_at, notes, created_at, updated_at
+		FROM workouts
+		WHERE id = $1 AND user_id = $2
+	`
+
+	workout := &models.Workout{}
+	err := r.pool.QueryRow(ctx, query, id, userID).Scan(
+		&workout.ID,
+		&workout.UserID,
+		&workout.Title,
+		&workout.StartedAt,
+		&workout.FinishedAt,
+		&workout.Notes,
+		&workout.CreatedAt,
+		&workout.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workout by id: %w", err)
+	}
+
+	return workout, nil
+}
+
+func (r *workoutRepository) ListByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Workout, error) {
+	query := `
+		SELECT id, user_id, title, started_at, finished_at, notes, created_at, updated_at
+		FROM workouts
+		WHERE user_id = $1
+		ORDER BY started_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workouts: %w", err)
+	}
+	defer rows.Close()
+
+	workouts := make([]*models.Workout, 0)
+	for rows.Next() {
+		workout := &models.Workout{}
+		err := rows.Scan(
+			&workout.ID,
+			&workout.UserID,
+			&workout.Title,
+			&workout.StartedAt,
+			&workout.FinishedAt,
+			&workout.Notes,
+			&workout.CreatedAt,
+			&workout.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan workout: %w", err)
+		}
+		workouts = append(workouts, workout)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate workouts: %w", err)
+	}
+
+	return workouts, nil
+}
+
+func (r *workoutRepository) Update(ctx context.Context, workout *models.Workout) error {
+	query := `
+		UPDATE workouts
+		SET title = $3, started_at = $4, finished_at = $5, notes = $6, updated_at = $7
+		WHERE id = $1 AND user_id = $2
+	`
+
+	tag, err := r.pool.Exec(ctx, query,
+		workout.ID, workout.UserID, workout.Title, workout.StartedAt,
+		workout.FinishedAt, workout.Notes, workout.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update workout: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *workoutRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	query := `DELETE FROM workouts WHERE id = $1 AND user_id = $2`
+
+	tag, err := r.pool.Exec(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete workout: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
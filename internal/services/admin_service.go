@@ -0,0 +1,271 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAccountAlreadyDisabled = errors.New("account is already disabled")
+	ErrAccountNotDisabled     = errors.New("account is not disabled")
+	// ErrCannotModifySelf stops admins from disabling or deleting their own account.
+	ErrCannotModifySelf = errors.New("admins cannot disable or delete their own account")
+)
+
+// AdminService lets support staff manage other users' accounts. Every call is
+// recorded as an audit event attributed to actor; a call whose event cannot be
+// recorded fails. Changes are recorded before they are made, so none is left
+// without its event.
+type AdminService interface {
+	ListUsers(ctx context.Context, actor models.AuditActor, filter models.UserFilter) ([]*models.User, error)
+	GetUser(ctx context.Context, actor models.AuditActor, userID uuid.UUID) (*models.User, error)
+	ListSessions(ctx context.Context, actor models.AuditActor, userID uuid.UUID) ([]*models.Session, error)
+	// ForceLogout ends every session of the user, including outstanding access tokens.
+	ForceLogout(ctx context.Context, actor models.AuditActor, userID uuid.UUID) error
+	// DisableUser blocks sign-in and signs the user out everywhere.
+	DisableUser(ctx context.Context, actor models.AuditActor, userID uuid.UUID) error
+	EnableUser(ctx context.Context, actor models.AuditActor, userID uuid.UUID) error
+	// SendPasswordReset emails the user a password reset link.
+	SendPasswordReset(ctx context.Context, actor models.AuditActor, userID uuid.UUID) error
+	// DeleteUser deletes the account and all of its data.
+	DeleteUser(ctx context.Context, actor models.AuditActor, userID uuid.UUID) error
+}
+
+type adminService struct {
+	userRepo         repositories.UserRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+	audit            AuditService
+	revocations      TokenRevocationService
+	sessions         SessionService
+	passwordReset    PasswordResetService
+	now              func() time.Time
+}
+
+func NewAdminService(
+	userRepo repositories.UserRepository,
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	audit AuditService,
+	revocations TokenRevocationService,
+	sessions SessionService,
+	passwordReset PasswordResetService,
+) AdminService {
+	return &adminService{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		audit:            audit,
+		revocations:      revocations,
+		sessions:         sessions,
+		passwordReset:    passwordReset,
+		now:              time.Now,
+	}
+}
+
+func (s *adminService) ListUsers(ctx context.Context, actor models.AuditActor, filter models.UserFilter) ([]*models.User, error) {
+	users, err := s.userRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	details := map[string]any{"limit": filter.Limit, "offset": filter.Offset}
+	if filter.Query != "" {
+		details["query"] = filter.Query
+	}
+	if filter.Role != "" {
+		details["role"] = filter.Role
+	}
+	if filter.Disabled != nil {
+		details["disabled"] = *filter.Disabled
+	}
+	if err := s.record(ctx, actor, models.AuditEventAdminUsersListed, nil, details); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (s *adminService) GetUser(ctx context.Context, actor models.AuditActor, userID uuid.UUID) (*models.User, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.record(ctx, actor, models.AuditEventAdminUserViewed, &user.ID, nil); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *adminService) ListSessions(ctx context.Context, actor models.AuditActor, userID uuid.UUID) ([]*models.Session, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.sessions.List(ctx, user.ID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.record(ctx, actor, models.AuditEventAdminSessionsViewed, &user.ID, nil); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (s *adminService) ForceLogout(ctx context.Context, actor models.AuditActor, userID uuid.UUID) error {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.record(ctx, actor, models.AuditEventAdminUserLoggedOut, &user.ID, nil); err != nil {
+		return err
+	}
+
+	// Unlike after a password change, nobody is handed a fresh token, so the
+	// cutoff is not truncated: every token issued up to now stops working.
+	// Only the cutoff is written, so concurrent profile changes are kept.
+	if err := s.revocations.RevokeUser(ctx, user.ID, s.now()); err != nil {
+		return fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+
+	if err := s.refreshTokenRepo.DeleteByUserID(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return nil
+}
+
+func (s *adminService) DisableUser(ctx context.Context, actor models.AuditActor, userID uuid.UUID) error {
+	if userID == actor.UserID {
+		return ErrCannotModifySelf
+	}
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.IsDisabled() {
+		return ErrAccountAlreadyDisabled
+	}
+
+	if err := s.record(ctx, actor, models.AuditEventAdminUserDisabled, &user.ID, nil); err != nil {
+		return err
+	}
+
+	disabledAt := s.now()
+	err = s.userRepo.Disable(ctx, user.ID, disabledAt)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrAccountAlreadyDisabled
+	}
+	if err != nil {
+		return fmt.Errorf("failed to disable user: %w", err)
+	}
+
+	// Disable already stored the cutoff together with the flag; this makes it
+	// apply on this instance at once instead of after the next sync.
+	if err := s.revocations.RevokeUser(ctx, user.ID, disabledAt); err != nil {
+		return fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+
+	if err := s.refreshTokenRepo.DeleteByUserID(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return nil
+}
+
+func (s *adminService) EnableUser(ctx context.Context, actor models.AuditActor, userID uuid.UUID) error {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.IsDisabled() {
+		return ErrAccountNotDisabled
+	}
+
+	if err := s.record(ctx, actor, models.AuditEventAdminUserEnabled, &user.ID, nil); err != nil {
+		return err
+	}
+
+	err = s.userRepo.Enable(ctx, user.ID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrAccountNotDisabled
+	}
+	if err != nil {
+		return fmt.Errorf("failed to enable user: %w", err)
+	}
+
+	return nil
+}
+
+func (s *adminService) SendPasswordReset(ctx context.Context, actor models.AuditActor, userID uuid.UUID) error {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.record(ctx, actor, models.AuditEventAdminPasswordResetSent, &user.ID, nil); err != nil {
+		return err
+	}
+
+	return s.passwordReset.RequestReset(ctx, user.Email)
+}
+
+func (s *adminService) DeleteUser(ctx context.Context, actor models.AuditActor, userID uuid.UUID) error {
+	if userID == actor.UserID {
+		return ErrCannotModifySelf
+	}
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// The event keeps the address, since the account it belongs to is about to go.
+	if err := s.record(ctx, actor, models.AuditEventAdminUserDeleted, &user.ID, map[string]any{"email": user.Email}); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.Delete(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	return nil
+}
+
+func (s *adminService) getUser(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
+
+func (s *adminService) record(
+	ctx context.Context,
+	actor models.AuditActor,
+	eventType string,
+	targetUserID *uuid.UUID,
+	details map[string]any,
+) error {
+	actorID := actor.UserID
+	return s.audit.Record(ctx, &models.AuditEvent{
+		Type:         eventType,
+		ActorID:      &actorID,
+		TargetUserID: targetUserID,
+		IPAddress:    actor.IPAddress,
+		UserAgent:    actor.UserAgent,
+		RequestID:    actor.RequestID,
+		Details:      details,
+	})
+}
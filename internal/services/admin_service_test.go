@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/config"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type adminFixture struct {
+	service     AdminService
+	authService AuthService
+	userRepo    *mockUserRepository
+	refreshRepo *mockRefreshTokenRepository
+	auditRepo   *mockAuditRepository
+	revocations TokenRevocationService
+	mailer      *recordingSender
+	actor       models.AuditActor
+	user        *models.User
+}
+
+func newAdminFixture(t *testing.T) *adminFixture {
+	t.Helper()
+	f := &adminFixture{
+		userRepo:    &mockUserRepository{users: make(map[string]*models.User)},
+		refreshRepo: &mockRefreshTokenRepository{tokens: make(map[string]*models.RefreshToken)},
+		auditRepo:   &mockAuditRepository{},
+		revocations: newTestTokenRevocations(),
+		mailer:      &recordingSender{},
+		actor: models.AuditActor{
+			UserID: uuid.New(),
+			AuditRequest: models.AuditRequest{
+				IPAddress: "203.0.113.7",
+				UserAgent: "curl/8.0",
+				RequestID: "req-1",
+			},
+		},
+	}
+	jwtConfig := &config.JWTConfig{Secret: "test-secret", Issuer: "test-issuer", Audience: "test-audience"}
+	authConfig := &config.AuthConfig{AppBaseURL: "https://app.example.com", PasswordResetTTL: time.Hour}
+
+	f.authService = NewAuthService(
+		f.userRepo, f.refreshRepo, newMockMFARepository(), noLoginThrottle{},
+		noAudit{}, f.revocations, jwtConfig, authConfig,
+	)
+	passwordReset := NewPasswordResetService(
+		f.userRepo, f.refreshRepo, newMockPasswordResetRepository(), f.mailer, noAudit{}, f.revocations, authConfig,
+	)
+	f.service = NewAdminService(
+		f.userRepo, f.refreshRepo, NewAuditService(f.auditRepo), f.revocations, NewSessionService(f.refreshRepo), passwordReset,
+	)
+
+	user, err := f.authService.Register(context.Background(), &models.CreateUserRequest{
+		Email:    "user@example.com",
+		Password: "Password1!",
+	})
+	require.NoError(t, err)
+	f.user = user
+	return f
+}
+
+func (f *adminFixture) login() error {
+	_, _, err := f.authService.Login(context.Background(), "user@example.com", "Password1!", models.ClientInfo{})
+	return err
+}
+
+func (f *adminFixture) lastEvent(t *testing.T) *models.AuditEvent {
+	t.Helper()
+	require.NotEmpty(t, f.auditRepo.events)
+	return f.auditRepo.events[len(f.auditRepo.events)-1]
+}
+
+func TestAdminService_DisableUser(t *testing.T) {
+	f := newAdminFixture(t)
+	require.NoError(t, f.login())
+	require.Len(t, f.refreshRepo.tokens, 1)
+
+	require.NoError(t, f.service.DisableUser(context.Background(), f.actor, f.user.ID))
+	assert.Empty(t, f.refreshRepo.tokens, "disabling signs the user out")
+	assertTokensCutOff(t, f.revocations, f.user.ID)
+	assert.ErrorIs(t, f.login(), ErrAccountDisabled)
+
+	event := f.lastEvent(t)
+	assert.Equal(t, models.AuditEventAdminUserDisabled, event.Type)
+	assert.Equal(t, f.actor.UserID, *event.ActorID)
+	assert.Equal(t, f.user.ID, *event.TargetUserID)
+	assert.Equal(t, "203.0.113.7", event.IPAddress)
+	assert.Equal(t, "curl/8.0", event.UserAgent)
+	assert.Equal(t, "req-1", event.RequestID)
+
+	assert.ErrorIs(t, f.service.DisableUser(context.Background(), f.actor, f.user.ID), ErrAccountAlreadyDisabled)
+
+	require.NoError(t, f.service.EnableUser(context.Background(), f.actor, f.user.ID))
+	assert.Equal(t, models.AuditEventAdminUserEnabled, f.lastEvent(t).Type)
+	assert.NoError(t, f.login())
+	assert.ErrorIs(t, f.service.EnableUser(context.Background(), f.actor, f.user.ID), ErrAccountNotDisabled)
+}
+
+func TestAdminService_CannotModifySelf(t *testing.T) {
+	f := newAdminFixture(t)
+	self := f.actor
+	self.UserID = f.user.ID
+
+	assert.ErrorIs(t, f.service.DisableUser(context.Background(), self, f.user.ID), ErrCannotModifySelf)
+	assert.ErrorIs(t, f.service.DeleteUser(context.Background(), self, f.user.ID), ErrCannotModifySelf)
+	assert.Empty(t, f.auditRepo.events)
+}
+
+func TestAdminService_ForceLogout(t *testing.T) {
+	f := newAdminFixture(t)
+	require.NoError(t, f.login())
+	require.NoError(t, f.login())
+
+	require.NoError(t, f.service.ForceLogout(context.Background(), f.actor, f.user.ID))
+
+	assert.Empty(t, f.refreshRepo.tokens)
+	assertTokensCutOff(t, f.revocations, f.user.ID)
+	assert.Equal(t, models.AuditEventAdminUserLoggedOut, f.lastEvent(t).Type)
+	assert.NoError(t, f.login(), "the user can sign in again")
+}
+
+func TestAdminService_SendPasswordReset(t *testing.T) {
+	f := newAdminFixture(t)
+
+	require.NoError(t, f.service.SendPasswordReset(context.Background(), f.actor, f.user.ID))
+
+	require.Len(t, f.mailer.messages, 1)
+	assert.Equal(t, "user@example.com", f.mailer.messages[0].To)
+	assert.Equal(t, models.AuditEventAdminPasswordResetSent, f.lastEvent(t).Type)
+}
+
+func TestAdminService_DeleteUser(t *testing.T) {
+	f := newAdminFixture(t)
+
+	require.NoError(t, f.service.DeleteUser(context.Background(), f.actor, f.user.ID))
+
+	assert.Empty(t, f.userRepo.users)
+	event := f.lastEvent(t)
+	assert.Equal(t, models.AuditEventAdminUserDeleted, event.Type)
+	assert.Equal(t, "user@example.com", event.Details["email"])
+
+	_, err := f.service.GetUser(context.Background(), f.actor, f.user.ID)
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+func TestAdminService_ChangesFailWithoutAudit(t *testing.T) {
+	f := newAdminFixture(t)
+	require.NoError(t, f.login())
+	f.auditRepo.err = errors.New("database is down")
+
+	assert.Error(t, f.service.DeleteUser(context.Background(), f.actor, f.user.ID))
+	assert.Len(t, f.userRepo.users, 1, "the user still exists when the deletion could not be recorded")
+
+	assert.Error(t, f.service.DisableUser(context.Background(), f.actor, f.user.ID))
+	assert.Error(t, f.service.ForceLogout(context.Background(), f.actor, f.user.ID))
+	assert.Error(t, f.service.SendPasswordReset(context.Background(), f.actor, f.user.ID))
+	assert.Len(t, f.refreshRepo.tokens, 1, "the user is still signed in")
+	assert.Empty(t, f.mailer.messages)
+	assert.NoError(t, f.login(), "the account is still enabled")
+}
+
+func TestAdminService_ReadsAreAudited(t *testing.T) {
+	f := newAdminFixture(t)
+	require.NoError(t, f.login())
+
+	disabled := false
+	users, err := f.service.ListUsers(context.Background(), f.actor, models.UserFilter{
+		Query:      "user@",
+		Disabled:   &disabled,
+		Pagination: models.Pagination{Limit: 20},
+	})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	event := f.lastEvent(t)
+	assert.Equal(t, models.AuditEventAdminUsersListed, event.Type)
+	assert.Nil(t, event.TargetUserID)
+	assert.Equal(t, "user@", event.Details["query"])
+
+	sessions, err := f.service.ListSessions(context.Background(), f.actor, f.user.ID)
+	require.NoError(t, err)
+	assert.Len(t, sessions, 1)
+	assert.Equal(t, models.AuditEventAdminSessionsViewed, f.lastEvent(t).Type)
+
+	f.auditRepo.err = errors.New("database is down")
+	_, err = f.service.GetUser(context.Background(), f.actor, f.user.ID)
+	assert.Error(t, err, "nothing is returned unless the access could be recorded")
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/logger"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+)
+
+// AccountActivityEvents are the event types a user is shown about their own
+// account. Routine token refreshes, unconfirmed two-factor set-ups and admins
+// merely looking are left out.
+var AccountActivityEvents = []string{
+	models.AuditEventLogin,
+	models.AuditEventLoginFailed,
+	models.AuditEventRefreshTokenReused,
+	models.AuditEventLogout,
+	models.AuditEventPasswordChanged,
+	models.AuditEventPasswordReset,
+	models.AuditEventEmailChanged,
+	models.AuditEventMFAEnabled,
+	models.AuditEventMFADisabled,
+	models.AuditEventRecoveryCodeUsed,
+	models.AuditEventAdminUserLoggedOut,
+	models.AuditEventAdminUserDisabled,
+	models.AuditEventAdminUserEnabled,
+	models.AuditEventAdminPasswordResetSent,
+}
+
+// AuditService keeps the append-only audit log of security events.
+type AuditService interface {
+	// Record appends event, filling in its ID and time. Where it came from is
+	// taken from the request in ctx unless the event already says.
+	Record(ctx context.Context, event *models.AuditEvent) error
+	// ListForUser returns the user's recent account activity, newest first.
+	ListForUser(ctx context.Context, userID uuid.UUID, page models.Pagination) ([]*models.AuditEvent, error)
+	// Search returns the events matching filter, newest first. The search is
+	// itself recorded, attributed to actor, and fails if it cannot be.
+	Search(ctx context.Context, actor models.AuditActor, filter models.AuditFilter) ([]*models.AuditEvent, error)
+}
+
+type auditRequestKeyType string
+
+const auditRequestKey auditRequestKeyType = "audit_request"
+
+// WithAuditRequest stores the request audit events recorded with ctx came from.
+func WithAuditRequest(ctx context.Context, request models.AuditRequest) context.Context {
+	return context.WithValue(ctx, auditRequestKey, request)
+}
+
+// AuditRequestFromContext returns the request stored by WithAuditRequest.
+func AuditRequestFromContext(ctx context.Context) models.AuditRequest {
+	request, _ := ctx.Value(auditRequestKey).(models.AuditRequest)
+	return request
+}
+
+type auditService struct {
+	auditRepo repositories.AuditRepository
+	now       func() time.Time
+}
+
+func NewAuditService(auditRepo repositories.AuditRepository) AuditService {
+	return &auditService{
+		auditRepo: auditRepo,
+		now:       time.Now,
+	}
+}
+
+func (s *auditService) Record(ctx context.Context, event *models.AuditEvent) error {
+	request := AuditRequestFromContext(ctx)
+	if event.IPAddress == "" {
+		event.IPAddress = request.IPAddress
+	}
+	if event.UserAgent == "" {
+		event.UserAgent = request.UserAgent
+	}
+	if event.RequestID == "" {
+		event.RequestID = request.RequestID
+	}
+	event.ID = uuid.New()
+	event.CreatedAt = s.now()
+
+	if err := s.auditRepo.Create(ctx, event); err != nil {
+		return fmt.Errorf("failed to record audit event: %w", err)
+	}
+	return nil
+}
+
+func (s *auditService) ListForUser(ctx context.Context, userID uuid.UUID, page models.Pagination) ([]*models.AuditEvent, error) {
+	events, err := s.auditRepo.List(ctx, models.AuditFilter{
+		UserID:     &userID,
+		Types:      AccountActivityEvents,
+		Pagination: page,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list account activity: %w", err)
+	}
+	return events, nil
+}
+
+func (s *auditService) Search(ctx context.Context, actor models.AuditActor, filter models.AuditFilter) ([]*models.AuditEvent, error) {
+	events, err := s.auditRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search audit events: %w", err)
+	}
+
+	details := map[string]any{"limit": filter.Limit, "offset": filter.Offset}
+	if filter.UserID != nil {
+		details["user_id"] = filter.UserID.String()
+	}
+	if filter.ActorID != nil {
+		details["actor_id"] = filter.ActorID.String()
+	}
+	if len(filter.Types) > 0 {
+		details["types"] = filter.Types
+	}
+	if filter.From != nil {
+		details["from"] = filter.From.Format(time.RFC3339)
+	}
+	if filter.To != nil {
+		details["to"] = filter.To.Format(time.RFC3339)
+	}
+	actorID := actor.UserID
+	err = s.Record(ctx, &models.AuditEvent{
+		Type:      models.AuditEventAdminAuditSearched,
+		ActorID:   &actorID,
+		IPAddress: actor.IPAddress,
+		UserAgent: actor.UserAgent,
+		RequestID: actor.RequestID,
+		Details:   details,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// accountEvent returns an event about something users did to their own account.
+func accountEvent(eventType string, userID uuid.UUID) *models.AuditEvent {
+	return &models.AuditEvent{
+		Type:         eventType,
+		ActorID:      &userID,
+		TargetUserID: &userID,
+	}
+}
+
+// recordSecurityEvent records event without failing the caller: nobody should
+// be kept from signing in because the audit log is unavailable.
+func recordSecurityEvent(ctx context.Context, audit AuditService, event *models.AuditEvent) {
+	if err := audit.Record(ctx, event); err != nil {
+		logger.FromContext(ctx).Error("Failed to record security event", "type", event.Type, "error", err)
+	}
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/aleksandr/strive-api/internal/config"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noAudit discards every event, for tests about other behaviour.
+type noAudit struct{}
+
+func (noAudit) Record(ctx context.Context, event *models.AuditEvent) error { return nil }
+
+func (noAudit) ListForUser(ctx context.Context, userID uuid.UUID, page models.Pagination) ([]*models.AuditEvent, error) {
+	return nil, nil
+}
+
+func (noAudit) Search(ctx context.Context, actor models.AuditActor, filter models.AuditFilter) ([]*models.AuditEvent, error) {
+	return nil, nil
+}
+
+type mockAuditRepository struct {
+	events []*models.AuditEvent
+	// err fails Create, so only recording is affected.
+	err error
+}
+
+func (m *mockAuditRepository) Create(ctx context.Context, event *models.AuditEvent) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *mockAuditRepository) List(ctx context.Context, filter models.AuditFilter) ([]*models.AuditEvent, error) {
+	events := make([]*models.AuditEvent, 0)
+	for i := len(m.events) - 1; i >= 0; i-- {
+		event := m.events[i]
+		if filter.UserID != nil && (event.TargetUserID == nil || *event.TargetUserID != *filter.UserID) {
+			continue
+		}
+		if filter.ActorID != nil && (event.ActorID == nil || *event.ActorID != *filter.ActorID) {
+			continue
+		}
+		if len(filter.Types) > 0 && !slices.Contains(filter.Types, event.Type) {
+			continue
+		}
+		events = append(events, event)
+	}
+	events = events[min(filter.Offset, len(events)):]
+	return events[:min(filter.Limit, len(events))], nil
+}
+
+func (m *mockAuditRepository) types() []string {
+	types := make([]string, 0, len(m.events))
+	for _, event := range m.events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func TestAuditService_Record(t *testing.T) {
+	repo := &mockAuditRepository{}
+	service := NewAuditService(repo)
+	ctx := WithAuditRequest(context.Background(), models.AuditRequest{
+		IPAddress: "203.0.113.7",
+		UserAgent: "curl/8.0",
+		RequestID: "req-1",
+	})
+
+	require.NoError(t, service.Record(ctx, &models.AuditEvent{Type: models.AuditEventLogout}))
+	require.NoError(t, service.Record(ctx, &models.AuditEvent{Type: models.AuditEventLogin, IPAddress: "198.51.100.1"}))
+
+	require.Len(t, repo.events, 2)
+	assert.NotEqual(t, uuid.Nil, repo.events[0].ID)
+	assert.False(t, repo.events[0].CreatedAt.IsZero())
+	assert.Equal(t, "203.0.113.7", repo.events[0].IPAddress, "the request fills in where the event came from")
+	assert.Equal(t, "curl/8.0", repo.events[0].UserAgent)
+	assert.Equal(t, "req-1", repo.events[0].RequestID)
+	assert.Equal(t, "198.51.100.1", repo.events[1].IPAddress, "what the event says is kept")
+	assert.Equal(t, "req-1", repo.events[1].RequestID)
+}
+
+func TestAuditService_ListForUser(t *testing.T) {
+	repo := &mockAuditRepository{}
+	service := NewAuditService(repo)
+	userID := uuid.New()
+
+	require.NoError(t, service.Record(context.Background(), accountEvent(models.AuditEventLogin, userID)))
+	require.NoError(t, service.Record(context.Background(), accountEvent(models.AuditEventTokenRefreshed, userID)))
+	require.NoError(t, service.Record(context.Background(), &models.AuditEvent{
+		Type:         models.AuditEventAdminUserViewed,
+		TargetUserID: &userID,
+	}))
+	require.NoError(t, service.Record(context.Background(), accountEvent(models.AuditEventLogin, uuid.New())))
+	require.NoError(t, service.Record(context.Background(), accountEvent(models.AuditEventPasswordChanged, userID)))
+
+	events, err := service.ListForUser(context.Background(), userID, models.Pagination{Limit: 20})
+	require.NoError(t, err)
+	require.Len(t, events, 2, "refreshes, admin reads and other users' events are left out")
+	assert.Equal(t, models.AuditEventPasswordChanged, events[0].Type, "newest first")
+	assert.Equal(t, models.AuditEventLogin, events[1].Type)
+}
+
+func TestAuditService_Search(t *testing.T) {
+	repo := &mockAuditRepository{}
+	service := NewAuditService(repo)
+	userID := uuid.New()
+	actor := models.AuditActor{UserID: uuid.New(), AuditRequest: models.AuditRequest{IPAddress: "203.0.113.7"}}
+
+	require.NoError(t, service.Record(context.Background(), accountEvent(models.AuditEventLogin, userID)))
+	require.NoError(t, service.Record(context.Background(), accountEvent(models.AuditEventLogout, userID)))
+
+	events, err := service.Search(context.Background(), actor, models.AuditFilter{
+		UserID:     &userID,
+		Types:      []string{models.AuditEventLogin},
+		Pagination: models.Pagination{Limit: 20},
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, models.AuditEventLogin, events[0].Type)
+
+	searched := repo.events[len(repo.events)-1]
+	assert.Equal(t, models.AuditEventAdminAuditSearched, searched.Type)
+	assert.Equal(t, actor.UserID, *searched.ActorID)
+	assert.Equal(t, "203.0.113.7", searched.IPAddress)
+	assert.Equal(t, userID.String(), searched.Details["user_id"])
+
+	repo.err = errors.New("database is down")
+	_, err = service.Search(context.Background(), actor, models.AuditFilter{Pagination: models.Pagination{Limit: 20}})
+	assert.Error(t, err, "nothing is returned unless the search could be recorded")
+}
+
+func TestAuthService_SecurityEvents(t *testing.T) {
+	userRepo := &mockUserRepository{users: make(map[string]*models.User)}
+	refreshRepo := &mockRefreshTokenRepository{tokens: make(map[string]*models.RefreshToken)}
+	auditRepo := &mockAuditRepository{}
+	jwtConfig := &config.JWTConfig{Secret: "test-secret", Issuer: "test-issuer", Audience: "test-audience"}
+	authService := NewAuthService(
+		userRepo, refreshRepo, newMockMFARepository(), noLoginThrottle{},
+		NewAuditService(auditRepo), newTestTokenRevocations(), jwtConfig, &config.AuthConfig{},
+	)
+	client := models.ClientInfo{IPAddress: "203.0.113.7", UserAgent: "curl/8.0", DeviceName: "Laptop"}
+
+	user, err := authService.Register(context.Background(), &models.CreateUserRequest{Email: "user@example.com", Password: "Password1!"})
+	require.NoError(t, err)
+
+	_, _, err = authService.Login(context.Background(), "user@example.com", "wrong", client)
+	require.Error(t, err)
+	_, _, err = authService.Login(context.Background(), "nobody@example.com", "wrong", client)
+	require.Error(t, err)
+
+	failed, unknown := auditRepo.events[0], auditRepo.events[1]
+	assert.Equal(t, models.AuditEventLoginFailed, failed.Type)
+	assert.Nil(t, failed.ActorID, "nobody is known to have acted")
+	assert.Equal(t, user.ID, *failed.TargetUserID)
+	assert.Equal(t, "203.0.113.7", failed.IPAddress)
+	assert.Equal(t, "invalid_password", failed.Details["reason"])
+	assert.Nil(t, unknown.TargetUserID)
+	assert.Equal(t, "nobody@example.com", unknown.Details["email"])
+
+	_, refreshToken, err := authService.Login(context.Background(), "user@example.com", "Password1!", client)
+	require.NoError(t, err)
+	login := auditRepo.events[2]
+	assert.Equal(t, models.AuditEventLogin, login.Type)
+	assert.Equal(t, user.ID, *login.ActorID)
+	assert.Equal(t, "curl/8.0", login.UserAgent)
+	assert.Equal(t, "Laptop", login.Details["device_name"])
+
+	_, rotated, err := authService.RefreshToken(context.Background(), refreshToken, client)
+	require.NoError(t, err)
+	_, _, err = authService.RefreshToken(context.Background(), refreshToken, client)
+	require.ErrorIs(t, err, ErrRefreshTokenReused)
+
+	_, current, err := authService.Login(context.Background(), "user@example.com", "Password1!", client)
+	require.NoError(t, err)
+	require.NoError(t, authService.ChangePassword(context.Background(), user.ID, current, &models.ChangePasswordRequest{
+		CurrentPassword: "Password1!",
+		NewPassword:     "Password2!",
+	}))
+	require.NoError(t, authService.Logout(context.Background(), current))
+	require.NoError(t, authService.Logout(context.Background(), rotated), "a revoked session logs out without an event")
+
+	assert.Equal(t, []string{
+		models.AuditEventLoginFailed,
+		models.AuditEventLoginFailed,
+		models.AuditEventLogin,
+		models.AuditEventTokenRefreshed,
+		models.AuditEventRefreshTokenReused,
+		models.AuditEventLogin,
+		models.AuditEventPasswordChanged,
+		models.AuditEventLogout,
+	}, auditRepo.types())
+
+	auditRepo.err = errors.New("database is down")
+	_, _, err = authService.Login(context.Background(), "user@example.com", "Password2!", client)
+	assert.NoError(t, err, "signing in does not depend on the audit log")
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aleksandr/strive-api/internal/config"
@@ -14,7 +15,6 @@ import (
 	"github.com/aleksandr/strive-api/internal/repositories"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
 )
 
 var (
@@ -23,28 +23,101 @@ var (
 	ErrTokenNotBefore   = errors.New("token used before valid")
 	ErrInvalidAudience  = errors.New("invalid token audience")
 	ErrInvalidIssuer    = errors.New("invalid token issuer")
+
+	// ErrRefreshTokenReused means an already rotated refresh token was presented
+	// again. Its whole token family has been revoked.
+	ErrRefreshTokenReused = errors.New("refresh token reuse detected")
+
+	errInvalidCredentials = errors.New("invalid credentials")
+	// ErrAccountDisabled is returned once the password checks out for an
+	// account an admin has disabled.
+	ErrAccountDisabled = errors.New("account is disabled")
+
+	ErrInvalidCurrentPassword = errors.New("current password is incorrect")
+	ErrPasswordUnchanged      = errors.New("new password must differ from the current password")
+
+	// ErrMFARequired is returned by Login, as an *MFAChallengeError, when the
+	// password was correct but the account has two-factor authentication on.
+	ErrMFARequired         = errors.New("two-factor authentication required")
+	ErrInvalidMFAChallenge = errors.New("two-factor challenge is invalid or expired")
 )
 
+// MFAChallengeError carries the challenge token that CompleteMFALogin accepts,
+// together with a code, in place of the password.
+type MFAChallengeError struct {
+	Token     string
+	ExpiresIn time.Duration
+}
+
+func (e *MFAChallengeError) Error() string {
+	return ErrMFARequired.Error()
+}
+
+func (e *MFAChallengeError) Unwrap() error {
+	return ErrMFARequired
+}
+
 type AuthService interface {
 	Register(ctx context.Context, req *models.CreateUserRequest) (*models.User, error)
-	Login(ctx context.Context, email, password string) (string, string, error)
-	RefreshToken(ctx context.Context, refreshToken string) (string, string, error)
+	// Login returns an access and refresh token; the refresh token is recorded
+	// as a session for the client's device. With two-factor authentication on
+	// it returns an *MFAChallengeError instead.
+	Login(ctx context.Context, email, password string, client models.ClientInfo) (string, string, error)
+	// CompleteMFALogin finishes a Login that returned an *MFAChallengeError,
+	// given a TOTP code or a recovery code.
+	CompleteMFALogin(ctx context.Context, challengeToken, code string, client models.ClientInfo) (string, string, error)
+	RefreshToken(ctx context.Context, refreshToken string, client models.ClientInfo) (string, string, error)
 	ValidateToken(tokenString string) (*Claims, error)
 	HashPassword(password string) (string, error)
 	VerifyPassword(hashedPassword, password string) error
 	Logout(ctx context.Context, refreshToken string) error
+	// IssueAccessToken signs a new access token for user, e.g. after claims such as the email changed.
+	IssueAccessToken(user *models.User) (string, error)
+	// JWKS returns the public keys access tokens can be verified with; empty
+	// when tokens are signed with a shared HS256 secret.
+	JWKS() *models.JWKS
+	// ChangePassword replaces the user's password and revokes every refresh token
+	// except currentRefreshToken, so only the calling session stays signed in.
+	// Access tokens issued before the change stop working; the caller refreshes.
+	ChangePassword(ctx context.Context, userID uuid.UUID, currentRefreshToken string, req *models.ChangePasswordRequest) error
 }
 
+// accessTokenTTL is how long access tokens live, and so how long a revocation
+// has to be remembered.
+const accessTokenTTL = 15 * time.Minute
+
+// dummyPasswordHash is compared against when the email is unknown.
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hash, _ := hashPassword("not-a-real-password")
+	return hash
+})
+
+// mfaChallengeTTL is how long a user has to enter their code after the password.
+const mfaChallengeTTL = 5 * time.Minute
+
+// mfaChallengeAudience replaces the configured audience in challenge tokens, so
+// they are never accepted as access tokens.
+const mfaChallengeAudience = "mfa-challenge"
+
 type Claims struct {
-	UserID uuid.UUID `json:"user_id"`
-	Email  string    `json:"email"`
+	UserID uuid.UUID   `json:"user_id"`
+	Email  string      `json:"email"`
+	Role   models.Role `json:"role,omitempty"`
+	// Permissions are those Role granted when the token was issued.
+	Permissions []string `json:"permissions,omitempty"`
 	jwt.RegisteredClaims
 }
 
 type authService struct {
 	userRepo         repositories.UserRepository
 	refreshTokenRepo repositories.RefreshTokenRepository
+	mfaRepo          repositories.MFARepository
+	throttle         LoginThrottleService
+	audit            AuditService
+	revocations      TokenRevocationService
 	config           *config.JWTConfig
+	keys             *jwtKeyring
+	authConfig       *config.AuthConfig
 	accessTTL        time.Duration
 	refreshTTL       time.Duration
 }
@@ -52,13 +125,24 @@ type authService struct {
 func NewAuthService(
 	userRepo repositories.UserRepository,
 	refreshTokenRepo repositories.RefreshTokenRepository,
+	mfaRepo repositories.MFARepository,
+	throttle LoginThrottleService,
+	audit AuditService,
+	revocations TokenRevocationService,
 	jwtConfig *config.JWTConfig,
+	authConfig *config.AuthConfig,
 ) AuthService {
 	return &authService{
 		userRepo:         userRepo,
 		refreshTokenRepo: refreshTokenRepo,
+		mfaRepo:          mfaRepo,
+		throttle:         throttle,
+		audit:            audit,
+		revocations:      revocations,
 		config:           jwtConfig,
-		accessTTL:        15 * time.Minute,
+		keys:             newJWTKeyring(jwtConfig),
+		authConfig:       authConfig,
+		accessTTL:        accessTokenTTL,
 		refreshTTL:       7 * 24 * time.Hour,
 	}
 }
@@ -83,6 +167,11 @@ func (s *authService) Register(ctx context.Context, req *models.CreateUserReques
 		ID:           uuid.New(),
 		Email:        normalizedEmail,
 		PasswordHash: hashedPassword,
+		WeightUnit:   models.WeightUnitKg,
+		Timezone:     models.DefaultTimezone,
+		Locale:       models.DefaultLocale,
+		WeekStart:    models.WeekStartMonday,
+		Role:         models.RoleUser,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
@@ -94,19 +183,172 @@ func (s *authService) Register(ctx context.Context, req *models.CreateUserReques
 	return user, nil
 }
 
-func (s *authService) Login(ctx context.Context, email, password string) (string, string, error) {
+func (s *authService) Login(
+	ctx context.Context,
+	email, password string,
+	client models.ClientInfo,
+) (accessToken, refreshToken string, err error) {
 	normalizedEmail := normalizeEmail(email)
+	if err := s.throttle.Reserve(ctx, normalizedEmail, client.IPAddress); err != nil {
+		return "", "", err
+	}
+	// The attempt stays counted only as a failed guess, and signing in clears
+	// it; any other outcome, errors included, uncounts it.
+	settled := false
+	defer func() {
+		if !settled {
+			err = s.releaseAttempt(ctx, normalizedEmail, client, err)
+		}
+	}()
+
 	user, err := s.userRepo.GetByEmail(ctx, normalizedEmail)
 	if err != nil {
-		s.addLoginDelay()
-		return "", "", fmt.Errorf("invalid credentials")
+		// Spend the same bcrypt time as for a wrong password, so response times
+		// do not tell unknown emails apart.
+		_ = verifyPassword(dummyPasswordHash(), password)
+		settled = true
+		return "", "", s.loginFailed(ctx, nil, normalizedEmail, "unknown_email", client)
 	}
 
 	if err := s.VerifyPassword(user.PasswordHash, password); err != nil {
-		s.addLoginDelay()
-		return "", "", fmt.Errorf("invalid credentials")
+		settled = true
+		return "", "", s.loginFailed(ctx, user, normalizedEmail, "invalid_password", client)
 	}
 
+	// Only reported once the password has been checked, so it reveals nothing to a guesser.
+	if user.IsDisabled() {
+		s.recordLoginFailure(ctx, user, normalizedEmail, "account_disabled", client)
+		return "", "", ErrAccountDisabled
+	}
+	if s.authConfig.EmailVerificationPolicy == config.EmailVerificationPolicyBlock && !user.IsEmailVerified() {
+		s.recordLoginFailure(ctx, user, normalizedEmail, "email_not_verified", client)
+		return "", "", ErrEmailNotVerified
+	}
+
+	credential, err := s.mfaRepo.GetTOTP(ctx, user.ID)
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		return "", "", fmt.Errorf("failed to get totp credential: %w", err)
+	}
+	if err == nil && credential.IsConfirmed() {
+		// The code is checked as an attempt of its own.
+		challengeToken, err := s.generateMFAChallenge(user)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to generate mfa challenge: %w", err)
+		}
+		return "", "", &MFAChallengeError{Token: challengeToken, ExpiresIn: mfaChallengeTTL}
+	}
+
+	if err := s.throttle.RecordSuccess(ctx, user.Email, client.IPAddress); err != nil {
+		return "", "", fmt.Errorf("failed to reset login failures: %w", err)
+	}
+	settled = true
+	return s.startSession(ctx, user, client)
+}
+
+func (s *authService) CompleteMFALogin(
+	ctx context.Context,
+	challengeToken, code string,
+	client models.ClientInfo,
+) (accessToken, refreshToken string, err error) {
+	userID, err := s.parseMFAChallenge(challengeToken)
+	if err != nil {
+		return "", "", ErrInvalidMFAChallenge
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return "", "", ErrInvalidMFAChallenge
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.IsDisabled() {
+		return "", "", ErrAccountDisabled
+	}
+
+	// Two-factor authentication may have been turned off since the challenge was issued.
+	credential, err := s.mfaRepo.GetTOTP(ctx, user.ID)
+	if errors.Is(err, repositories.ErrNotFound) || (err == nil && !credential.IsConfirmed()) {
+		return "", "", ErrInvalidMFAChallenge
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get totp credential: %w", err)
+	}
+
+	if err := s.throttle.Reserve(ctx, user.Email, client.IPAddress); err != nil {
+		return "", "", err
+	}
+	settled := false
+	defer func() {
+		if !settled {
+			err = s.releaseAttempt(ctx, user.Email, client, err)
+		}
+	}()
+
+	if err := verifySecondFactor(ctx, s.mfaRepo, credential, code, time.Now()); err != nil {
+		if errors.Is(err, ErrInvalidMFACode) {
+			settled = true
+			s.recordLoginFailure(ctx, user, user.Email, "invalid_mfa_code", client)
+			if err := s.throttle.RecordFailure(ctx, user.Email); err != nil {
+				return "", "", fmt.Errorf("failed to record login failure: %w", err)
+			}
+		}
+		return "", "", err
+	}
+	if !isTOTPCode(code) {
+		recordSecurityEvent(ctx, s.audit, accountEvent(models.AuditEventRecoveryCodeUsed, user.ID))
+	}
+
+	if err := s.throttle.RecordSuccess(ctx, user.Email, client.IPAddress); err != nil {
+		return "", "", fmt.Errorf("failed to reset login failures: %w", err)
+	}
+	settled = true
+	return s.startSession(ctx, user, client)
+}
+
+// loginFailed records a failed sign-in and returns the error reported for it,
+// the same whether or not the email belongs to an account.
+func (s *authService) loginFailed(ctx context.Context, user *models.User, email, reason string, client models.ClientInfo) error {
+	s.recordLoginFailure(ctx, user, email, reason, client)
+	if err := s.throttle.RecordFailure(ctx, email); err != nil {
+		return fmt.Errorf("failed to record login failure: %w", err)
+	}
+	return errInvalidCredentials
+}
+
+// releaseAttempt uncounts a reserved sign-in attempt that neither failed on
+// its credentials nor signed in, and returns err, the outcome reported for it.
+func (s *authService) releaseAttempt(ctx context.Context, email string, client models.ClientInfo, err error) error {
+	if releaseErr := s.throttle.Release(ctx, email, client.IPAddress); releaseErr != nil {
+		return fmt.Errorf("failed to release login attempt: %w", releaseErr)
+	}
+	return err
+}
+
+// recordLoginFailure adds a failed sign-in to the audit log; user is nil when
+// the email belongs to no account. Nobody is known to have acted, so the event
+// has no actor.
+func (s *authService) recordLoginFailure(
+	ctx context.Context,
+	user *models.User,
+	email, reason string,
+	client models.ClientInfo,
+) {
+	event := &models.AuditEvent{
+		Type:      models.AuditEventLoginFailed,
+		IPAddress: client.IPAddress,
+		UserAgent: client.UserAgent,
+		Details:   map[string]any{"email": email, "reason": reason},
+	}
+	if user != nil {
+		event.TargetUserID = &user.ID
+	}
+	recordSecurityEvent(ctx, s.audit, event)
+}
+
+// startSession issues an access token and a refresh token in a new token
+// family for a user who has fully signed in.
+func (s *authService) startSession(ctx context.Context, user *models.User, client models.ClientInfo) (string, string, error) {
 	accessToken, err := s.generateToken(user, s.accessTTL)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to generate access token: %w", err)
@@ -117,32 +359,64 @@ func (s *authService) Login(ctx context.Context, email, password string) (string
 		return "", "", fmt.Errorf("failed to generate refresh token: %w", err)
 	}
 
+	now := time.Now()
 	refreshTokenModel := &models.RefreshToken{
-		ID:        uuid.New(),
-		UserID:    user.ID,
-		Token:     refreshToken,
-		ExpiresAt: time.Now().Add(s.refreshTTL),
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:         uuid.New(),
+		UserID:     user.ID,
+		FamilyID:   uuid.New(),
+		TokenHash:  hashOpaqueToken(refreshToken),
+		UserAgent:  client.UserAgent,
+		IPAddress:  client.IPAddress,
+		DeviceName: client.DeviceName,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(s.refreshTTL),
+		CreatedAt:  now,
+		UpdatedAt:  now,
 	}
 
 	if err := s.refreshTokenRepo.Create(ctx, refreshTokenModel); err != nil {
 		return "", "", fmt.Errorf("failed to save refresh token: %w", err)
 	}
 
+	event := accountEvent(models.AuditEventLogin, user.ID)
+	event.IPAddress = client.IPAddress
+	event.UserAgent = client.UserAgent
+	event.Details = map[string]any{"session_id": refreshTokenModel.FamilyID.String(), "device_name": client.DeviceName}
+	recordSecurityEvent(ctx, s.audit, event)
+
 	return accessToken, refreshToken, nil
 }
 
-func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (string, string, error) {
-	refreshTokenModel, err := s.refreshTokenRepo.GetByToken(ctx, refreshToken)
+func (s *authService) RefreshToken(ctx context.Context, refreshToken string, client models.ClientInfo) (string, string, error) {
+	refreshTokenModel, err := s.refreshTokenRepo.GetByToken(ctx, hashOpaqueToken(refreshToken))
 	if err != nil {
 		return "", "", fmt.Errorf("invalid refresh token")
 	}
 
+	// Only the client that rotated a token ever sees its successor, so a rotated
+	// token coming back means it was copied: end the whole session.
+	if refreshTokenModel.RevokedAt != nil {
+		err := s.refreshTokenRepo.RevokeFamily(ctx, refreshTokenModel.UserID, refreshTokenModel.FamilyID)
+		if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+			return "", "", fmt.Errorf("failed to revoke token family: %w", err)
+		}
+		recordSecurityEvent(ctx, s.audit, &models.AuditEvent{
+			Type:         models.AuditEventRefreshTokenReused,
+			TargetUserID: &refreshTokenModel.UserID,
+			IPAddress:    client.IPAddress,
+			UserAgent:    client.UserAgent,
+			Details:      map[string]any{"session_id": refreshTokenModel.FamilyID.String()},
+		})
+		return "", "", fmt.Errorf("%w: family %s of user %s", ErrRefreshTokenReused, refreshTokenModel.FamilyID, refreshTokenModel.UserID)
+	}
+
 	user, err := s.userRepo.GetByID(ctx, refreshTokenModel.UserID)
 	if err != nil {
 		return "", "", fmt.Errorf("user not found")
 	}
+	if user.IsDisabled() {
+		return "", "", ErrAccountDisabled
+	}
 
 	accessToken, err := s.generateToken(user, s.accessTTL)
 	if err != nil {
@@ -154,33 +428,47 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (st
 		return "", "", fmt.Errorf("failed to generate refresh token: %w", err)
 	}
 
-	if err := s.refreshTokenRepo.Delete(ctx, refreshToken); err != nil {
-		return "", "", fmt.Errorf("failed to delete old refresh token: %w", err)
+	// The rotated token continues the same session: it keeps the device name and
+	// sign-in time, and records where it was last used from.
+	deviceName := refreshTokenModel.DeviceName
+	if deviceName == "" {
+		deviceName = client.DeviceName
 	}
-
+	now := time.Now()
 	newRefreshTokenModel := &models.RefreshToken{
-		ID:        uuid.New(),
-		UserID:    user.ID,
-		Token:     newRefreshToken,
-		ExpiresAt: time.Now().Add(s.refreshTTL),
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:         uuid.New(),
+		UserID:     user.ID,
+		FamilyID:   refreshTokenModel.FamilyID,
+		TokenHash:  hashOpaqueToken(newRefreshToken),
+		UserAgent:  client.UserAgent,
+		IPAddress:  client.IPAddress,
+		DeviceName: deviceName,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(s.refreshTTL),
+		CreatedAt:  refreshTokenModel.CreatedAt,
+		UpdatedAt:  now,
 	}
 
-	if err := s.refreshTokenRepo.Create(ctx, newRefreshTokenModel); err != nil {
-		return "", "", fmt.Errorf("failed to save new refresh token: %w", err)
+	// Losing a race with a concurrent rotation of the same token is not reuse.
+	err = s.refreshTokenRepo.Rotate(ctx, refreshTokenModel.ID, newRefreshTokenModel)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return "", "", fmt.Errorf("invalid refresh token")
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to rotate refresh token: %w", err)
 	}
 
+	event := accountEvent(models.AuditEventTokenRefreshed, user.ID)
+	event.IPAddress = client.IPAddress
+	event.UserAgent = client.UserAgent
+	event.Details = map[string]any{"session_id": refreshTokenModel.FamilyID.String()}
+	recordSecurityEvent(ctx, s.audit, event)
+
 	return accessToken, newRefreshToken, nil
 }
 
 func (s *authService) ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, ErrInvalidSignature
-		}
-		return []byte(s.config.Secret), nil
-	}, jwt.WithoutClaimsValidation())
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, s.keys.verificationKey, jwt.WithoutClaimsValidation())
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
 	}
@@ -225,23 +513,22 @@ func (s *authService) ValidateToken(tokenString string) (*Claims, error) {
 }
 
 func (s *authService) HashPassword(password string) (string, error) {
-	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	if err != nil {
-		return "", fmt.Errorf("failed to hash password: %w", err)
-	}
-	return string(hashedBytes), nil
+	return hashPassword(password)
 }
 
 func (s *authService) VerifyPassword(hashedPassword, password string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+	return verifyPassword(hashedPassword, password)
 }
 
 func (s *authService) generateToken(user *models.User, ttl time.Duration) (string, error) {
 	now := time.Now()
 	claims := &Claims{
-		UserID: user.ID,
-		Email:  user.Email,
+		UserID:      user.ID,
+		Email:       user.Email,
+		Role:        user.Role,
+		Permissions: user.Permissions,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			Issuer:    s.config.Issuer,
 			Audience:  jwt.ClaimStrings{s.config.Audience},
 			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
@@ -250,8 +537,61 @@ func (s *authService) generateToken(user *models.User, ttl time.Duration) (strin
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.config.Secret))
+	signing := s.keys.signing
+	token := jwt.NewWithClaims(signing.method, claims)
+	token.Header["kid"] = signing.id
+	return token.SignedString(signing.signKey)
+}
+
+func (s *authService) generateMFAChallenge(user *models.User) (string, error) {
+	now := time.Now()
+	claims := &jwt.RegisteredClaims{
+		ID:        uuid.NewString(),
+		Subject:   user.ID.String(),
+		Issuer:    s.config.Issuer,
+		Audience:  jwt.ClaimStrings{mfaChallengeAudience},
+		ExpiresAt: jwt.NewNumericDate(now.Add(mfaChallengeTTL)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+	}
+
+	signing := s.keys.signing
+	token := jwt.NewWithClaims(signing.method, claims)
+	token.Header["kid"] = signing.id
+	return token.SignedString(signing.signKey)
+}
+
+// parseMFAChallenge returns the user a challenge token was issued to.
+func (s *authService) parseMFAChallenge(tokenString string) (uuid.UUID, error) {
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, s.keys.verificationKey,
+		jwt.WithIssuer(s.config.Issuer),
+		jwt.WithAudience(mfaChallengeAudience),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(s.config.ClockSkew),
+	)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return uuid.Parse(claims.Subject)
+}
+
+func (s *authService) JWKS() *models.JWKS {
+	jwks := &models.JWKS{Keys: []models.JWK{}}
+	for _, key := range s.keys.ordered {
+		if key.jwk != nil {
+			jwks.Keys = append(jwks.Keys, *key.jwk)
+		}
+	}
+	return jwks
+}
+
+func (s *authService) IssueAccessToken(user *models.User) (string, error) {
+	accessToken, err := s.generateToken(user, s.accessTTL)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate access token: %w", err)
+	}
+	return accessToken, nil
 }
 
 func (s *authService) generateRefreshToken() (string, error) {
@@ -263,9 +603,65 @@ func (s *authService) generateRefreshToken() (string, error) {
 }
 
 func (s *authService) Logout(ctx context.Context, refreshToken string) error {
-	if err := s.refreshTokenRepo.Delete(ctx, refreshToken); err != nil {
+	tokenHash := hashOpaqueToken(refreshToken)
+	// Only looked up to tell whose session ended; an unknown token is still deleted.
+	refreshTokenModel, lookupErr := s.refreshTokenRepo.GetByToken(ctx, tokenHash)
+
+	if err := s.refreshTokenRepo.Delete(ctx, tokenHash); err != nil {
 		return fmt.Errorf("failed to delete refresh token: %w", err)
 	}
+
+	if lookupErr == nil {
+		event := accountEvent(models.AuditEventLogout, refreshTokenModel.UserID)
+		event.Details = map[string]any{"session_id": refreshTokenModel.FamilyID.String()}
+		recordSecurityEvent(ctx, s.audit, event)
+	}
+	return nil
+}
+
+func (s *authService) ChangePassword(
+	ctx context.Context,
+	userID uuid.UUID,
+	currentRefreshToken string,
+	req *models.ChangePasswordRequest,
+) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := s.VerifyPassword(user.PasswordHash, req.CurrentPassword); err != nil {
+		s.addLoginDelay()
+		return ErrInvalidCurrentPassword
+	}
+	if req.NewPassword == req.CurrentPassword {
+		return ErrPasswordUnchanged
+	}
+
+	hashedPassword, err := s.HashPassword(req.NewPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	now := time.Now()
+	user.PasswordHash = hashedPassword
+	user.UpdatedAt = now
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	if err := s.revocations.RevokeUser(ctx, user.ID, now.Truncate(time.Second)); err != nil {
+		return fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+
+	if err := s.refreshTokenRepo.DeleteByUserIDExcept(ctx, user.ID, hashOpaqueToken(currentRefreshToken)); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	recordSecurityEvent(ctx, s.audit, accountEvent(models.AuditEventPasswordChanged, user.ID))
 	return nil
 }
 
@@ -2,13 +2,17 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/aleksandr/strive-api/internal/config"
 	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
 	"github.com/google/uuid"
 )
 
@@ -28,7 +32,7 @@ func (m *mockUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models
 			return user, nil
 		}
 	}
-	return nil, fmt.Errorf("user not found")
+	return nil, repositories.ErrNotFound
 }
 
 func (m *mockUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
@@ -36,7 +40,7 @@ func (m *mockUserRepository) GetByEmail(ctx context.Context, email string) (*mod
 	if user, exists := m.users[normalizedEmail]; exists {
 		return user, nil
 	}
-	return nil, fmt.Errorf("user not found")
+	return nil, repositories.ErrNotFound
 }
 
 func (m *mockUserRepository) Update(ctx context.Context, user *models.User) error {
@@ -55,39 +59,118 @@ func (m *mockUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return fmt.Errorf("user not found")
 }
 
+func (m *mockUserRepository) List(ctx context.Context, filter models.UserFilter) ([]*models.User, error) {
+	users := make([]*models.User, 0)
+	for _, user := range m.users {
+		if filter.Query != "" && !strings.Contains(user.Email, filter.Query) {
+			continue
+		}
+		if filter.Role != "" && user.Role != filter.Role {
+			continue
+		}
+		if filter.Disabled != nil && user.IsDisabled() != *filter.Disabled {
+			continue
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+func (m *mockUserRepository) Disable(ctx context.Context, id uuid.UUID, at time.Time) error {
+	user, err := m.GetByID(ctx, id)
+	if err != nil || user.IsDisabled() {
+		return repositories.ErrNotFound
+	}
+	user.DisabledAt = &at
+	user.TokensValidAfter = &at
+	return nil
+}
+
+func (m *mockUserRepository) Enable(ctx context.Context, id uuid.UUID) error {
+	user, err := m.GetByID(ctx, id)
+	if err != nil || !user.IsDisabled() {
+		return repositories.ErrNotFound
+	}
+	user.DisabledAt = nil
+	return nil
+}
+
 type mockRefreshTokenRepository struct {
+	mu     sync.Mutex
 	tokens map[string]*models.RefreshToken
 }
 
 func (m *mockRefreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
-	m.tokens[token.Token] = token
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens[token.TokenHash] = token
 	return nil
 }
 
-func (m *mockRefreshTokenRepository) GetByToken(ctx context.Context, token string) (*models.RefreshToken, error) {
-	refreshToken, exists := m.tokens[token]
+func (m *mockRefreshTokenRepository) GetByToken(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	refreshToken, exists := m.tokens[tokenHash]
 	if !exists {
 		return nil, fmt.Errorf("refresh token not found")
 	}
-	return refreshToken, nil
+	stored := *refreshToken
+	return &stored, nil
 }
 
 func (m *mockRefreshTokenRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.RefreshToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	var tokens []*models.RefreshToken
 	for _, token := range m.tokens {
-		if token.UserID == userID {
+		if token.UserID == userID && token.RevokedAt == nil {
 			tokens = append(tokens, token)
 		}
 	}
 	return tokens, nil
 }
 
-func (m *mockRefreshTokenRepository) Delete(ctx context.Context, token string) error {
-	delete(m.tokens, token)
+func (m *mockRefreshTokenRepository) Delete(ctx context.Context, tokenHash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.tokens, tokenHash)
+	return nil
+}
+
+func (m *mockRefreshTokenRepository) Rotate(ctx context.Context, oldID uuid.UUID, next *models.RefreshToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, refreshToken := range m.tokens {
+		if refreshToken.ID == oldID && refreshToken.RevokedAt == nil {
+			now := time.Now()
+			refreshToken.RevokedAt = &now
+			m.tokens[next.TokenHash] = next
+			return nil
+		}
+	}
+	return repositories.ErrNotFound
+}
+
+func (m *mockRefreshTokenRepository) RevokeFamily(ctx context.Context, userID, familyID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	revoked := false
+	for _, refreshToken := range m.tokens {
+		if refreshToken.UserID == userID && refreshToken.FamilyID == familyID && refreshToken.RevokedAt == nil {
+			now := time.Now()
+			refreshToken.RevokedAt = &now
+			revoked = true
+		}
+	}
+	if !revoked {
+		return repositories.ErrNotFound
+	}
 	return nil
 }
 
 func (m *mockRefreshTokenRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	for token, refreshToken := range m.tokens {
 		if refreshToken.UserID == userID {
 			delete(m.tokens, token)
@@ -96,7 +179,24 @@ func (m *mockRefreshTokenRepository) DeleteByUserID(ctx context.Context, userID
 	return nil
 }
 
+func (m *mockRefreshTokenRepository) DeleteByUserIDExcept(ctx context.Context, userID uuid.UUID, keepTokenHash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var keepFamily *uuid.UUID
+	if kept, exists := m.tokens[keepTokenHash]; exists && kept.UserID == userID {
+		keepFamily = &kept.FamilyID
+	}
+	for token, refreshToken := range m.tokens {
+		if refreshToken.UserID == userID && (keepFamily == nil || refreshToken.FamilyID != *keepFamily) {
+			delete(m.tokens, token)
+		}
+	}
+	return nil
+}
+
 func (m *mockRefreshTokenRepository) DeleteExpired(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	now := time.Now()
 	for token, refreshToken := range m.tokens {
 		if refreshToken.ExpiresAt.Before(now) {
@@ -119,7 +219,10 @@ func TestAuthService_Register(t *testing.T) {
 		Audience:  "test-audience",
 		ClockSkew: 1 * time.Minute,
 	}
-	authService := NewAuthService(mockRepo, mockRefreshRepo, jwtConfig)
+	authService := NewAuthService(
+		mockRepo, mockRefreshRepo, newMockMFARepository(), noLoginThrottle{},
+		noAudit{}, newTestTokenRevocations(), jwtConfig, &config.AuthConfig{},
+	)
 
 	req := &models.CreateUserRequest{
 		Email:    "test@example.com",
@@ -157,7 +260,10 @@ func TestAuthService_Login(t *testing.T) {
 		Audience:  "test-audience",
 		ClockSkew: 1 * time.Minute,
 	}
-	authService := NewAuthService(mockRepo, mockRefreshRepo, jwtConfig)
+	authService := NewAuthService(
+		mockRepo, mockRefreshRepo, newMockMFARepository(), noLoginThrottle{},
+		noAudit{}, newTestTokenRevocations(), jwtConfig, &config.AuthConfig{},
+	)
 
 	// First register a user
 	req := &models.CreateUserRequest{
@@ -170,7 +276,7 @@ func TestAuthService_Login(t *testing.T) {
 	}
 
 	// Now try to login
-	accessToken, refreshToken, err := authService.Login(context.Background(), req.Email, req.Password)
+	accessToken, refreshToken, err := authService.Login(context.Background(), req.Email, req.Password, models.ClientInfo{})
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -197,7 +303,10 @@ func TestAuthService_LoginCaseInsensitive(t *testing.T) {
 		Audience:  "test-audience",
 		ClockSkew: 1 * time.Minute,
 	}
-	authService := NewAuthService(mockRepo, mockRefreshRepo, jwtConfig)
+	authService := NewAuthService(
+		mockRepo, mockRefreshRepo, newMockMFARepository(), noLoginThrottle{},
+		noAudit{}, newTestTokenRevocations(), jwtConfig, &config.AuthConfig{},
+	)
 
 	// Register user with lowercase email
 	req := &models.CreateUserRequest{
@@ -210,7 +319,7 @@ func TestAuthService_LoginCaseInsensitive(t *testing.T) {
 	}
 
 	// Try to login with different case email
-	accessToken, refreshToken, err := authService.Login(context.Background(), "Test@Example.com", req.Password)
+	accessToken, refreshToken, err := authService.Login(context.Background(), "Test@Example.com", req.Password, models.ClientInfo{})
 	if err != nil {
 		t.Fatalf("Expected no error with different case email, got %v", err)
 	}
@@ -224,6 +333,63 @@ func TestAuthService_LoginCaseInsensitive(t *testing.T) {
 	}
 }
 
+func TestAuthService_LoginUnverifiedEmail(t *testing.T) {
+	jwtConfig := &config.JWTConfig{
+		Secret:    "test-secret",
+		Issuer:    "test-issuer",
+		Audience:  "test-audience",
+		ClockSkew: 1 * time.Minute,
+	}
+
+	tests := []struct {
+		policy  string
+		wantErr error
+	}{
+		{config.EmailVerificationPolicyNone, nil},
+		{config.EmailVerificationPolicyLimit, nil},
+		{config.EmailVerificationPolicyBlock, ErrEmailNotVerified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			mockRepo := &mockUserRepository{
+				users: make(map[string]*models.User),
+			}
+			mockRefreshRepo := &mockRefreshTokenRepository{
+				tokens: make(map[string]*models.RefreshToken),
+			}
+			authService := NewAuthService(
+				mockRepo, mockRefreshRepo, newMockMFARepository(), noLoginThrottle{}, noAudit{}, newTestTokenRevocations(), jwtConfig,
+				&config.AuthConfig{EmailVerificationPolicy: tt.policy},
+			)
+
+			req := &models.CreateUserRequest{
+				Email:    "test@example.com",
+				Password: "password123",
+			}
+			if _, err := authService.Register(context.Background(), req); err != nil {
+				t.Fatalf("Failed to register user: %v", err)
+			}
+
+			_, _, err := authService.Login(context.Background(), req.Email, req.Password, models.ClientInfo{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+
+			if tt.wantErr == nil {
+				return
+			}
+
+			// Verified accounts sign in normally under every policy.
+			verifiedAt := time.Now()
+			mockRepo.users[req.Email].EmailVerifiedAt = &verifiedAt
+			if _, _, err := authService.Login(context.Background(), req.Email, req.Password, models.ClientInfo{}); err != nil {
+				t.Errorf("Expected verified user to log in, got %v", err)
+			}
+		})
+	}
+}
+
 func TestAuthService_HashPassword(t *testing.T) {
 	mockRepo := &mockUserRepository{
 		users: make(map[string]*models.User),
@@ -237,7 +403,10 @@ func TestAuthService_HashPassword(t *testing.T) {
 		Audience:  "test-audience",
 		ClockSkew: 1 * time.Minute,
 	}
-	authService := NewAuthService(mockRepo, mockRefreshRepo, jwtConfig)
+	authService := NewAuthService(
+		mockRepo, mockRefreshRepo, newMockMFARepository(), noLoginThrottle{},
+		noAudit{}, newTestTokenRevocations(), jwtConfig, &config.AuthConfig{},
+	)
 
 	password := "testpassword123"
 	hashed, err := authService.HashPassword(password)
@@ -261,3 +430,293 @@ func TestAuthService_HashPassword(t *testing.T) {
 		t.Error("Password verification should fail for wrong password")
 	}
 }
+
+func TestAuthService_ChangePassword(t *testing.T) {
+	jwtConfig := &config.JWTConfig{
+		Secret:    "test-secret",
+		Issuer:    "test-issuer",
+		Audience:  "test-audience",
+		ClockSkew: 1 * time.Minute,
+	}
+
+	var revocations TokenRevocationService
+	setup := func(t *testing.T) (AuthService, *mockRefreshTokenRepository, *models.User, string) {
+		t.Helper()
+		mockRepo := &mockUserRepository{users: make(map[string]*models.User)}
+		mockRefreshRepo := &mockRefreshTokenRepository{tokens: make(map[string]*models.RefreshToken)}
+		revocations = newTestTokenRevocations()
+		authService := NewAuthService(
+			mockRepo, mockRefreshRepo, newMockMFARepository(), noLoginThrottle{},
+			noAudit{}, revocations, jwtConfig, &config.AuthConfig{},
+		)
+
+		user, err := authService.Register(context.Background(), &models.CreateUserRequest{
+			Email:    "test@example.com",
+			Password: "OldPassword1!",
+		})
+		if err != nil {
+			t.Fatalf("Failed to register user: %v", err)
+		}
+
+		_, currentToken, err := authService.Login(context.Background(), "test@example.com", "OldPassword1!", models.ClientInfo{})
+		if err != nil {
+			t.Fatalf("Failed to login: %v", err)
+		}
+		if _, _, err := authService.Login(context.Background(), "test@example.com", "OldPassword1!", models.ClientInfo{}); err != nil {
+			t.Fatalf("Failed to login: %v", err)
+		}
+
+		return authService, mockRefreshRepo, user, currentToken
+	}
+
+	t.Run("RevokesOtherSessions", func(t *testing.T) {
+		authService, mockRefreshRepo, user, currentToken := setup(t)
+
+		err := authService.ChangePassword(context.Background(), user.ID, currentToken, &models.ChangePasswordRequest{
+			CurrentPassword: "OldPassword1!",
+			NewPassword:     "NewPassword2@",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(mockRefreshRepo.tokens) != 1 {
+			t.Fatalf("Expected only the current session to remain, got %d", len(mockRefreshRepo.tokens))
+		}
+		if _, exists := mockRefreshRepo.tokens[hashOpaqueToken(currentToken)]; !exists {
+			t.Error("Expected current refresh token to stay valid")
+		}
+		assertTokensCutOff(t, revocations, user.ID)
+
+		if _, _, err := authService.Login(context.Background(), "test@example.com", "OldPassword1!", models.ClientInfo{}); err == nil {
+			t.Error("Expected old password to be rejected")
+		}
+		if _, _, err := authService.Login(context.Background(), "test@example.com", "NewPassword2@", models.ClientInfo{}); err != nil {
+			t.Errorf("Expected new password to work, got %v", err)
+		}
+	})
+
+	t.Run("RevokesAllWithoutCurrentSession", func(t *testing.T) {
+		authService, mockRefreshRepo, user, _ := setup(t)
+
+		err := authService.ChangePassword(context.Background(), user.ID, "", &models.ChangePasswordRequest{
+			CurrentPassword: "OldPassword1!",
+			NewPassword:     "NewPassword2@",
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(mockRefreshRepo.tokens) != 0 {
+			t.Errorf("Expected all sessions to be revoked, got %d", len(mockRefreshRepo.tokens))
+		}
+	})
+
+	t.Run("RejectsWrongCurrentPassword", func(t *testing.T) {
+		authService, mockRefreshRepo, user, currentToken := setup(t)
+
+		err := authService.ChangePassword(context.Background(), user.ID, currentToken, &models.ChangePasswordRequest{
+			CurrentPassword: "WrongPassword1!",
+			NewPassword:     "NewPassword2@",
+		})
+		if !errors.Is(err, ErrInvalidCurrentPassword) {
+			t.Fatalf("Expected ErrInvalidCurrentPassword, got %v", err)
+		}
+		if len(mockRefreshRepo.tokens) != 2 {
+			t.Errorf("Expected sessions to be untouched, got %d", len(mockRefreshRepo.tokens))
+		}
+	})
+
+	t.Run("RejectsUnchangedPassword", func(t *testing.T) {
+		authService, _, user, currentToken := setup(t)
+
+		err := authService.ChangePassword(context.Background(), user.ID, currentToken, &models.ChangePasswordRequest{
+			CurrentPassword: "OldPassword1!",
+			NewPassword:     "OldPassword1!",
+		})
+		if !errors.Is(err, ErrPasswordUnchanged) {
+			t.Fatalf("Expected ErrPasswordUnchanged, got %v", err)
+		}
+	})
+}
+
+func TestAuthService_RefreshTokenReuse(t *testing.T) {
+	mockRepo := &mockUserRepository{users: make(map[string]*models.User)}
+	mockRefreshRepo := &mockRefreshTokenRepository{tokens: make(map[string]*models.RefreshToken)}
+	jwtConfig := &config.JWTConfig{Secret: "test-secret", Issuer: "test-issuer", Audience: "test-audience"}
+	authService := NewAuthService(
+		mockRepo, mockRefreshRepo, newMockMFARepository(), noLoginThrottle{},
+		noAudit{}, newTestTokenRevocations(), jwtConfig, &config.AuthConfig{},
+	)
+
+	if _, err := authService.Register(context.Background(), &models.CreateUserRequest{
+		Email:    "test@example.com",
+		Password: "password123",
+	}); err != nil {
+		t.Fatalf("Failed to register user: %v", err)
+	}
+	_, stolenToken, err := authService.Login(context.Background(), "test@example.com", "password123", models.ClientInfo{})
+	if err != nil {
+		t.Fatalf("Failed to login: %v", err)
+	}
+	_, otherToken, err := authService.Login(context.Background(), "test@example.com", "password123", models.ClientInfo{})
+	if err != nil {
+		t.Fatalf("Failed to login: %v", err)
+	}
+
+	_, rotatedToken, err := authService.RefreshToken(context.Background(), stolenToken, models.ClientInfo{})
+	if err != nil {
+		t.Fatalf("Failed to refresh token: %v", err)
+	}
+	if mockRefreshRepo.tokens[hashOpaqueToken(stolenToken)].RevokedAt == nil {
+		t.Fatal("Expected rotated token to be kept as revoked")
+	}
+
+	if _, _, err := authService.RefreshToken(context.Background(), stolenToken, models.ClientInfo{}); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("Expected ErrRefreshTokenReused, got %v", err)
+	}
+	if mockRefreshRepo.tokens[hashOpaqueToken(rotatedToken)].RevokedAt == nil {
+		t.Error("Expected reuse to revoke the whole token family")
+	}
+	if mockRefreshRepo.tokens[hashOpaqueToken(otherToken)].RevokedAt != nil {
+		t.Error("Expected other sessions to stay valid")
+	}
+
+	if _, _, err := authService.RefreshToken(context.Background(), rotatedToken, models.ClientInfo{}); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Errorf("Expected revoked family token to be rejected, got %v", err)
+	}
+}
+
+func TestAuthService_StoresRefreshTokenHashed(t *testing.T) {
+	mockRepo := &mockUserRepository{users: make(map[string]*models.User)}
+	mockRefreshRepo := &mockRefreshTokenRepository{tokens: make(map[string]*models.RefreshToken)}
+	jwtConfig := &config.JWTConfig{Secret: "test-secret", Issuer: "test-issuer", Audience: "test-audience"}
+	authService := NewAuthService(
+		mockRepo, mockRefreshRepo, newMockMFARepository(), noLoginThrottle{},
+		noAudit{}, newTestTokenRevocations(), jwtConfig, &config.AuthConfig{},
+	)
+
+	if _, err := authService.Register(context.Background(), &models.CreateUserRequest{
+		Email:    "test@example.com",
+		Password: "password123",
+	}); err != nil {
+		t.Fatalf("Failed to register user: %v", err)
+	}
+	_, refreshToken, err := authService.Login(context.Background(), "test@example.com", "password123", models.ClientInfo{})
+	if err != nil {
+		t.Fatalf("Failed to login: %v", err)
+	}
+
+	for _, stored := range mockRefreshRepo.tokens {
+		if stored.TokenHash == refreshToken {
+			t.Fatal("Expected the raw refresh token not to be stored")
+		}
+		if stored.TokenHash != hashOpaqueToken(refreshToken) {
+			t.Errorf("Expected stored digest %s, got %s", hashOpaqueToken(refreshToken), stored.TokenHash)
+		}
+	}
+
+	if _, _, err := authService.RefreshToken(context.Background(), refreshToken, models.ClientInfo{}); err != nil {
+		t.Errorf("Expected raw token to be accepted, got %v", err)
+	}
+	if _, _, err := authService.RefreshToken(context.Background(), hashOpaqueToken(refreshToken), models.ClientInfo{}); err == nil {
+		t.Error("Expected the stored digest not to work as a refresh token")
+	}
+}
+
+func TestAuthService_RefreshTokenConcurrentRotation(t *testing.T) {
+	mockRepo := &mockUserRepository{users: make(map[string]*models.User)}
+	mockRefreshRepo := &mockRefreshTokenRepository{tokens: make(map[string]*models.RefreshToken)}
+	jwtConfig := &config.JWTConfig{Secret: "test-secret", Issuer: "test-issuer", Audience: "test-audience"}
+	authService := NewAuthService(
+		mockRepo, mockRefreshRepo, newMockMFARepository(), noLoginThrottle{},
+		noAudit{}, newTestTokenRevocations(), jwtConfig, &config.AuthConfig{},
+	)
+
+	if _, err := authService.Register(context.Background(), &models.CreateUserRequest{
+		Email:    "test@example.com",
+		Password: "password123",
+	}); err != nil {
+		t.Fatalf("Failed to register user: %v", err)
+	}
+	_, refreshToken, err := authService.Login(context.Background(), "test@example.com", "password123", models.ClientInfo{})
+	if err != nil {
+		t.Fatalf("Failed to login: %v", err)
+	}
+
+	const callers = 20
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		start     = make(chan struct{})
+		newTokens []string
+	)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, rotated, err := authService.RefreshToken(context.Background(), refreshToken, models.ClientInfo{})
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			newTokens = append(newTokens, rotated)
+			mu.Unlock()
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if len(newTokens) != 1 {
+		t.Fatalf("Expected exactly one concurrent refresh to succeed, got %d", len(newTokens))
+	}
+	// Callers arriving after the rotation see a revoked token and may end the
+	// family as reuse, but no caller other than the winner ever mints a token.
+	if len(mockRefreshRepo.tokens) != 2 {
+		t.Errorf("Expected the original and one rotated token, got %d", len(mockRefreshRepo.tokens))
+	}
+	if _, exists := mockRefreshRepo.tokens[hashOpaqueToken(newTokens[0])]; !exists {
+		t.Error("Expected the winner's token to be stored")
+	}
+}
+
+func TestAuthService_TokenCarriesRole(t *testing.T) {
+	mockRepo := &mockUserRepository{
+		users: make(map[string]*models.User),
+	}
+	jwtConfig := &config.JWTConfig{
+		Secret:   "test-secret",
+		Issuer:   "test-issuer",
+		Audience: "test-audience",
+	}
+	authService := NewAuthService(
+		mockRepo, &mockRefreshTokenRepository{}, newMockMFARepository(), noLoginThrottle{},
+		noAudit{}, newTestTokenRevocations(), jwtConfig, &config.AuthConfig{},
+	)
+
+	user, err := authService.Register(context.Background(), &models.CreateUserRequest{Email: "user@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Failed to register user: %v", err)
+	}
+	if user.Role != models.RoleUser {
+		t.Errorf("Expected new users to get role %q, got %q", models.RoleUser, user.Role)
+	}
+
+	user.Role = models.RoleAdmin
+	user.Permissions = []string{models.PermissionUsersRead, models.PermissionUsersManage}
+	accessToken, err := authService.IssueAccessToken(user)
+	if err != nil {
+		t.Fatalf("Failed to issue access token: %v", err)
+	}
+
+	claims, err := authService.ValidateToken(accessToken)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if claims.Role != models.RoleAdmin {
+		t.Errorf("Expected role %q, got %q", models.RoleAdmin, claims.Role)
+	}
+	if !slices.Equal(claims.Permissions, user.Permissions) {
+		t.Errorf("Expected the user's permissions, got %v", claims.Permissions)
+	}
+}
@@ -22,6 +22,7 @@ func TestAuthService_ValidateToken(t *testing.T) {
 
 	service := &authService{
 		config:     jwtConfig,
+		keys:       newJWTKeyring(jwtConfig),
 		accessTTL:  15 * time.Minute,
 		refreshTTL: 7 * 24 * time.Hour,
 	}
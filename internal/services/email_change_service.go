@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/config"
+	"github.com/aleksandr/strive-api/internal/mail"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrEmailTaken              = errors.New("email address is already in use")
+	ErrEmailUnchanged          = errors.New("new email must differ from the current email")
+	ErrInvalidEmailChangeToken = errors.New("email change token is invalid or expired")
+)
+
+type EmailChangeService interface {
+	// RequestChange checks the current password and emails a confirmation link
+	// to the new address. The account keeps its current email until confirmed.
+	RequestChange(ctx context.Context, userID uuid.UUID, req *models.ChangeEmailRequest) error
+	// ConfirmChange consumes token, tells the old address about the change and
+	// moves the user to the new, now verified, address.
+	ConfirmChange(ctx context.Context, userID uuid.UUID, token string) (*models.User, error)
+}
+
+type emailChangeService struct {
+	userRepo   repositories.UserRepository
+	changeRepo repositories.EmailChangeRepository
+	mailer     mail.Sender
+	audit      AuditService
+	config     *config.AuthConfig
+	now        func() time.Time
+}
+
+// NewEmailChangeService creates the email change flow. Confirmation links
+// expire after the email verification TTL.
+func NewEmailChangeService(
+	userRepo repositories.UserRepository,
+	changeRepo repositories.EmailChangeRepository,
+	mailer mail.Sender,
+	audit AuditService,
+	authConfig *config.AuthConfig,
+) EmailChangeService {
+	return &emailChangeService{
+		userRepo:   userRepo,
+		changeRepo: changeRepo,
+		mailer:     mailer,
+		audit:      audit,
+		config:     authConfig,
+		now:        time.Now,
+	}
+}
+
+func (s *emailChangeService) RequestChange(ctx context.Context, userID uuid.UUID, req *models.ChangeEmailRequest) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := verifyPassword(user.PasswordHash, req.CurrentPassword); err != nil {
+		return ErrInvalidCurrentPassword
+	}
+
+	newEmail := normalizeEmail(req.NewEmail)
+	if newEmail == user.Email {
+		return ErrEmailUnchanged
+	}
+	if err := s.ensureEmailAvailable(ctx, user.ID, newEmail); err != nil {
+		return err
+	}
+
+	// Only the most recent request should be confirmable.
+	if err := s.changeRepo.DeleteUnusedByUserID(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to invalidate previous email change tokens: %w", err)
+	}
+
+	token, err := newOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	changeToken := &models.EmailChangeToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		NewEmail:  newEmail,
+		TokenHash: hashOpaqueToken(token),
+		ExpiresAt: now.Add(s.config.EmailVerificationTTL),
+		CreatedAt: now,
+	}
+	if err := s.changeRepo.Create(ctx, changeToken); err != nil {
+		return fmt.Errorf("failed to create email change token: %w", err)
+	}
+
+	link := s.config.AppBaseURL + "/confirm-email-change?token=" + url.QueryEscape(token)
+	err = s.mailer.Send(ctx, mail.Message{
+		To:      newEmail,
+		Subject: "Confirm your new Strive email address",
+		Body: fmt.Sprintf(
+			"You asked to use this address for your Strive account.\n\n"+
+				"Open this link within %s while signed in to confirm the change:\n%s\n\n"+
+				"If this wasn't you, ignore this email; nothing will change.\n",
+			s.config.EmailVerificationTTL, link,
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send email change confirmation: %w", err)
+	}
+
+	return nil
+}
+
+func (s *emailChangeService) ConfirmChange(ctx context.Context, userID uuid.UUID, token string) (*models.User, error) {
+	changeToken, err := s.changeRepo.GetValidByHash(ctx, hashOpaqueToken(token))
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrInvalidEmailChangeToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email change token: %w", err)
+	}
+	if changeToken.UserID != userID {
+		return nil, ErrInvalidEmailChangeToken
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// Someone may have registered the address since the link was sent.
+	if err := s.ensureEmailAvailable(ctx, user.ID, changeToken.NewEmail); err != nil {
+		return nil, err
+	}
+
+	// Notify first: if the old address cannot be told, nothing changes and the link stays usable.
+	err = s.mailer.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "Your Strive email address was changed",
+		Body: fmt.Sprintf(
+			"The email address for your Strive account is being changed to %s.\n\n"+
+				"If this wasn't you, reset your password and contact support right away.\n",
+			changeToken.NewEmail,
+		),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to notify previous address: %w", err)
+	}
+
+	err = s.changeRepo.MarkUsed(ctx, changeToken.ID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrInvalidEmailChangeToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume email change token: %w", err)
+	}
+
+	now := s.now()
+	previousEmail := user.Email
+	user.Email = changeToken.NewEmail
+	user.EmailVerifiedAt = &now
+	user.UpdatedAt = now
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update email: %w", err)
+	}
+
+	event := accountEvent(models.AuditEventEmailChanged, user.ID)
+	event.Details = map[string]any{"previous_email": previousEmail, "email": user.Email}
+	recordSecurityEvent(ctx, s.audit, event)
+
+	return user, nil
+}
+
+func (s *emailChangeService) ensureEmailAvailable(ctx context.Context, userID uuid.UUID, email string) error {
+	existing, err := s.userRepo.GetByEmail(ctx, email)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check email availability: %w", err)
+	}
+	if existing.ID != userID {
+		return ErrEmailTaken
+	}
+	return nil
+}
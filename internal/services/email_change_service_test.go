@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/config"
+	"github.com/aleksandr/strive-api/internal/mail"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockEmailChangeRepository struct {
+	tokens map[uuid.UUID]*models.EmailChangeToken
+}
+
+func (m *mockEmailChangeRepository) Create(ctx context.Context, token *models.EmailChangeToken) error {
+	m.tokens[token.ID] = token
+	return nil
+}
+
+func (m *mockEmailChangeRepository) GetValidByHash(ctx context.Context, tokenHash string) (*models.EmailChangeToken, error) {
+	for _, token := range m.tokens {
+		if token.TokenHash == tokenHash && token.UsedAt == nil && token.ExpiresAt.After(time.Now()) {
+			copied := *token
+			return &copied, nil
+		}
+	}
+	return nil, repositories.ErrNotFound
+}
+
+func (m *mockEmailChangeRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	token, exists := m.tokens[id]
+	if !exists || token.UsedAt != nil {
+		return repositories.ErrNotFound
+	}
+	now := time.Now()
+	token.UsedAt = &now
+	return nil
+}
+
+func (m *mockEmailChangeRepository) DeleteUnusedByUserID(ctx context.Context, userID uuid.UUID) error {
+	for id, token := range m.tokens {
+		if token.UserID == userID && token.UsedAt == nil {
+			delete(m.tokens, id)
+		}
+	}
+	return nil
+}
+
+// failingSender fails every message sent to one address.
+type failingSender struct {
+	recordingSender
+	failTo string
+}
+
+func (s *failingSender) Send(ctx context.Context, msg mail.Message) error {
+	if msg.To == s.failTo {
+		return errors.New("mailbox unavailable")
+	}
+	return s.recordingSender.Send(ctx, msg)
+}
+
+type emailChangeFixture struct {
+	service    EmailChangeService
+	userRepo   *mockUserRepository
+	changeRepo *mockEmailChangeRepository
+	mailer     *failingSender
+	auditRepo  *mockAuditRepository
+	user       *models.User
+}
+
+func newEmailChangeFixture(t *testing.T) *emailChangeFixture {
+	t.Helper()
+
+	hash, err := hashPassword("Password1!")
+	require.NoError(t, err)
+	user := &models.User{ID: uuid.New(), Email: "old@example.com", PasswordHash: hash}
+	other := &models.User{ID: uuid.New(), Email: "taken@example.com"}
+
+	f := &emailChangeFixture{
+		userRepo: &mockUserRepository{users: map[string]*models.User{
+			user.Email:  user,
+			other.Email: other,
+		}},
+		changeRepo: &mockEmailChangeRepository{tokens: make(map[uuid.UUID]*models.EmailChangeToken)},
+		mailer:     &failingSender{},
+		auditRepo:  &mockAuditRepository{},
+		user:       user,
+	}
+	f.service = NewEmailChangeService(f.userRepo, f.changeRepo, f.mailer, NewAuditService(f.auditRepo), &config.AuthConfig{
+		AppBaseURL:           "https://app.example.com",
+		EmailVerificationTTL: 48 * time.Hour,
+	})
+	return f
+}
+
+func (f *emailChangeFixture) request(t *testing.T, newEmail string) string {
+	t.Helper()
+	require.NoError(t, f.service.RequestChange(context.Background(), f.user.ID, &models.ChangeEmailRequest{
+		NewEmail:        newEmail,
+		CurrentPassword: "Password1!",
+	}))
+	return f.mailer.linkToken(t)
+}
+
+func TestEmailChangeService_RequestChange(t *testing.T) {
+	t.Run("SendsConfirmationToNewAddress", func(t *testing.T) {
+		f := newEmailChangeFixture(t)
+
+		token := f.request(t, " New@Example.com ")
+
+		require.Len(t, f.mailer.messages, 1)
+		assert.Equal(t, "new@example.com", f.mailer.messages[0].To)
+		assert.Contains(t, f.mailer.messages[0].Body, "https://app.example.com/confirm-email-change?token=")
+		assert.Equal(t, "old@example.com", f.user.Email, "email changes only on confirmation")
+
+		require.Len(t, f.changeRepo.tokens, 1)
+		for _, stored := range f.changeRepo.tokens {
+			assert.Equal(t, hashOpaqueToken(token), stored.TokenHash)
+			assert.Equal(t, "new@example.com", stored.NewEmail)
+		}
+	})
+
+	t.Run("Rejections", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			req      models.ChangeEmailRequest
+			expected error
+		}{
+			{"WrongPassword", models.ChangeEmailRequest{NewEmail: "new@example.com", CurrentPassword: "wrong"}, ErrInvalidCurrentPassword},
+			{"SameEmail", models.ChangeEmailRequest{NewEmail: "OLD@example.com", CurrentPassword: "Password1!"}, ErrEmailUnchanged},
+			{"TakenEmail", models.ChangeEmailRequest{NewEmail: "taken@example.com", CurrentPassword: "Password1!"}, ErrEmailTaken},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				f := newEmailChangeFixture(t)
+
+				err := f.service.RequestChange(context.Background(), f.user.ID, &tt.req)
+				assert.ErrorIs(t, err, tt.expected)
+				assert.Empty(t, f.mailer.messages)
+				assert.Empty(t, f.changeRepo.tokens)
+			})
+		}
+	})
+}
+
+func TestEmailChangeService_ConfirmChange(t *testing.T) {
+	t.Run("SwapsEmailAndNotifiesOldAddress", func(t *testing.T) {
+		f := newEmailChangeFixture(t)
+		token := f.request(t, "new@example.com")
+
+		user, err := f.service.ConfirmChange(context.Background(), f.user.ID, token)
+		require.NoError(t, err)
+
+		assert.Equal(t, "new@example.com", user.Email)
+		assert.True(t, user.IsEmailVerified())
+		require.Len(t, f.mailer.messages, 2)
+		assert.Equal(t, "old@example.com", f.mailer.messages[1].To)
+		assert.Contains(t, f.mailer.messages[1].Body, "new@example.com")
+		require.Len(t, f.auditRepo.events, 1)
+		changed := f.auditRepo.events[0]
+		assert.Equal(t, models.AuditEventEmailChanged, changed.Type)
+		assert.Equal(t, f.user.ID, *changed.TargetUserID)
+		assert.Equal(t, "old@example.com", changed.Details["previous_email"])
+		assert.Equal(t, "new@example.com", changed.Details["email"])
+
+		_, err = f.service.ConfirmChange(context.Background(), f.user.ID, token)
+		assert.ErrorIs(t, err, ErrInvalidEmailChangeToken, "tokens are single-use")
+	})
+
+	t.Run("RejectsOtherUsersToken", func(t *testing.T) {
+		f := newEmailChangeFixture(t)
+		token := f.request(t, "new@example.com")
+
+		_, err := f.service.ConfirmChange(context.Background(), uuid.New(), token)
+		assert.ErrorIs(t, err, ErrInvalidEmailChangeToken)
+		assert.Equal(t, "old@example.com", f.user.Email)
+		assert.Empty(t, f.auditRepo.events)
+	})
+
+	t.Run("RejectsAddressTakenSinceRequest", func(t *testing.T) {
+		f := newEmailChangeFixture(t)
+		token := f.request(t, "new@example.com")
+		f.userRepo.users["new@example.com"] = &models.User{ID: uuid.New(), Email: "new@example.com"}
+
+		_, err := f.service.ConfirmChange(context.Background(), f.user.ID, token)
+		assert.ErrorIs(t, err, ErrEmailTaken)
+		assert.Equal(t, "old@example.com", f.user.Email)
+	})
+
+	t.Run("KeepsEmailWhenOldAddressCannotBeNotified", func(t *testing.T) {
+		f := newEmailChangeFixture(t)
+		token := f.request(t, "new@example.com")
+		f.mailer.failTo = "old@example.com"
+
+		_, err := f.service.ConfirmChange(context.Background(), f.user.ID, token)
+		require.Error(t, err)
+		assert.Equal(t, "old@example.com", f.user.Email)
+
+		f.mailer.failTo = ""
+		_, err = f.service.ConfirmChange(context.Background(), f.user.ID, token)
+		assert.NoError(t, err, "the link stays usable")
+	})
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/config"
+	"github.com/aleksandr/strive-api/internal/mail"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidVerificationToken = errors.New("email verification token is invalid or expired")
+	ErrEmailNotVerified         = errors.New("email address has not been verified")
+)
+
+type EmailVerificationService interface {
+	// SendVerification emails user a link that confirms their current address.
+	// Earlier links stop working.
+	SendVerification(ctx context.Context, user *models.User) error
+	// Resend emails a new link if email belongs to an unverified account. It
+	// reports success otherwise so callers cannot probe for users.
+	Resend(ctx context.Context, email string) error
+	// Verify consumes token and marks the address it was sent to as verified.
+	Verify(ctx context.Context, token string) error
+}
+
+type emailVerificationService struct {
+	userRepo         repositories.UserRepository
+	verificationRepo repositories.EmailVerificationRepository
+	mailer           mail.Sender
+	config           *config.AuthConfig
+	now              func() time.Time
+}
+
+func NewEmailVerificationService(
+	userRepo repositories.UserRepository,
+	verificationRepo repositories.EmailVerificationRepository,
+	mailer mail.Sender,
+	authConfig *config.AuthConfig,
+) EmailVerificationService {
+	return &emailVerificationService{
+		userRepo:         userRepo,
+		verificationRepo: verificationRepo,
+		mailer:           mailer,
+		config:           authConfig,
+		now:              time.Now,
+	}
+}
+
+func (s *emailVerificationService) SendVerification(ctx context.Context, user *models.User) error {
+	if err := s.verificationRepo.DeleteUnusedByUserID(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to invalidate previous verification tokens: %w", err)
+	}
+
+	token, err := newOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	verificationToken := &models.EmailVerificationToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		Email:     user.Email,
+		TokenHash: hashOpaqueToken(token),
+		ExpiresAt: now.Add(s.config.EmailVerificationTTL),
+		CreatedAt: now,
+	}
+	if err := s.verificationRepo.Create(ctx, verificationToken); err != nil {
+		return fmt.Errorf("failed to create verification token: %w", err)
+	}
+
+	link := s.config.AppBaseURL + "/verify-email?token=" + url.QueryEscape(token)
+	err = s.mailer.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "Confirm your Strive email address",
+		Body: fmt.Sprintf(
+			"Welcome to Strive!\n\n"+
+				"Open this link within %s to confirm your email address:\n%s\n\n"+
+				"If you didn't create an account, ignore this email.\n",
+			s.config.EmailVerificationTTL, link,
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+
+	return nil
+}
+
+func (s *emailVerificationService) Resend(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetByEmail(ctx, normalizeEmail(email))
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if user.IsEmailVerified() {
+		return nil
+	}
+
+	return s.SendVerification(ctx, user)
+}
+
+func (s *emailVerificationService) Verify(ctx context.Context, token string) error {
+	verificationToken, err := s.verificationRepo.GetValidByHash(ctx, hashOpaqueToken(token))
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrInvalidVerificationToken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get verification token: %w", err)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, verificationToken.UserID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrInvalidVerificationToken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// A link sent to a previous address must not verify the current one.
+	if user.Email != verificationToken.Email {
+		return ErrInvalidVerificationToken
+	}
+
+	err = s.verificationRepo.MarkUsed(ctx, verificationToken.ID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrInvalidVerificationToken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to consume verification token: %w", err)
+	}
+
+	if user.IsEmailVerified() {
+		return nil
+	}
+
+	now := s.now()
+	user.EmailVerifiedAt = &now
+	user.UpdatedAt = now
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to mark email verified: %w", err)
+	}
+
+	return nil
+}
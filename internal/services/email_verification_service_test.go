@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/config"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockEmailVerificationRepository struct {
+	tokens map[uuid.UUID]*models.EmailVerificationToken
+}
+
+func newMockEmailVerificationRepository() *mockEmailVerificationRepository {
+	return &mockEmailVerificationRepository{tokens: make(map[uuid.UUID]*models.EmailVerificationToken)}
+}
+
+func (m *mockEmailVerificationRepository) Create(ctx context.Context, token *models.EmailVerificationToken) error {
+	m.tokens[token.ID] = token
+	return nil
+}
+
+func (m *mockEmailVerificationRepository) GetValidByHash(ctx context.Context, tokenHash string) (*models.EmailVerificationToken, error) {
+	for _, token := range m.tokens {
+		if token.TokenHash == tokenHash && token.UsedAt == nil && token.ExpiresAt.After(time.Now()) {
+			copied := *token
+			return &copied, nil
+		}
+	}
+	return nil, repositories.ErrNotFound
+}
+
+func (m *mockEmailVerificationRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	token, exists := m.tokens[id]
+	if !exists || token.UsedAt != nil {
+		return repositories.ErrNotFound
+	}
+	now := time.Now()
+	token.UsedAt = &now
+	return nil
+}
+
+func (m *mockEmailVerificationRepository) DeleteUnusedByUserID(ctx context.Context, userID uuid.UUID) error {
+	for id, token := range m.tokens {
+		if token.UserID == userID && token.UsedAt == nil {
+			delete(m.tokens, id)
+		}
+	}
+	return nil
+}
+
+type emailVerificationFixture struct {
+	service          EmailVerificationService
+	userRepo         *mockUserRepository
+	verificationRepo *mockEmailVerificationRepository
+	mailer           *recordingSender
+	user             *models.User
+}
+
+func newEmailVerificationFixture() *emailVerificationFixture {
+	user := &models.User{ID: uuid.New(), Email: "user@example.com"}
+
+	f := &emailVerificationFixture{
+		userRepo:         &mockUserRepository{users: map[string]*models.User{user.Email: user}},
+		verificationRepo: newMockEmailVerificationRepository(),
+		mailer:           &recordingSender{},
+		user:             user,
+	}
+	f.service = NewEmailVerificationService(f.userRepo, f.verificationRepo, f.mailer, &config.AuthConfig{
+		AppBaseURL:           "https://app.example.com",
+		EmailVerificationTTL: 48 * time.Hour,
+	})
+	return f
+}
+
+func TestEmailVerificationService_SendVerification(t *testing.T) {
+	f := newEmailVerificationFixture()
+
+	require.NoError(t, f.service.SendVerification(context.Background(), f.user))
+	first := f.mailer.linkToken(t)
+	require.NoError(t, f.service.SendVerification(context.Background(), f.user))
+	second := f.mailer.linkToken(t)
+
+	require.Len(t, f.mailer.messages, 2)
+	assert.Equal(t, "user@example.com", f.mailer.messages[1].To)
+	assert.Contains(t, f.mailer.messages[1].Body, "https://app.example.com/verify-email?token=")
+
+	require.Len(t, f.verificationRepo.tokens, 1, "earlier links are invalidated")
+	for _, stored := range f.verificationRepo.tokens {
+		assert.Equal(t, hashOpaqueToken(second), stored.TokenHash)
+		assert.Equal(t, f.user.Email, stored.Email)
+	}
+
+	assert.ErrorIs(t, f.service.Verify(context.Background(), first), ErrInvalidVerificationToken)
+}
+
+func TestEmailVerificationService_Verify(t *testing.T) {
+	t.Run("MarksUserVerified", func(t *testing.T) {
+		f := newEmailVerificationFixture()
+		require.NoError(t, f.service.SendVerification(context.Background(), f.user))
+		token := f.mailer.linkToken(t)
+
+		require.NoError(t, f.service.Verify(context.Background(), token))
+		assert.True(t, f.user.IsEmailVerified())
+
+		assert.ErrorIs(t, f.service.Verify(context.Background(), token), ErrInvalidVerificationToken, "tokens are single-use")
+	})
+
+	t.Run("UnknownToken", func(t *testing.T) {
+		f := newEmailVerificationFixture()
+
+		assert.ErrorIs(t, f.service.Verify(context.Background(), "nope"), ErrInvalidVerificationToken)
+	})
+
+	t.Run("RejectsTokenForPreviousAddress", func(t *testing.T) {
+		f := newEmailVerificationFixture()
+		require.NoError(t, f.service.SendVerification(context.Background(), f.user))
+		token := f.mailer.linkToken(t)
+
+		f.user.Email = "new@example.com"
+
+		assert.ErrorIs(t, f.service.Verify(context.Background(), token), ErrInvalidVerificationToken)
+		assert.False(t, f.user.IsEmailVerified())
+	})
+}
+
+func TestEmailVerificationService_Resend(t *testing.T) {
+	t.Run("SendsToUnverifiedAccount", func(t *testing.T) {
+		f := newEmailVerificationFixture()
+
+		require.NoError(t, f.service.Resend(context.Background(), " User@Example.com "))
+		assert.Len(t, f.mailer.messages, 1)
+	})
+
+	t.Run("SilentForUnknownEmail", func(t *testing.T) {
+		f := newEmailVerificationFixture()
+
+		require.NoError(t, f.service.Resend(context.Background(), "nobody@example.com"))
+		assert.Empty(t, f.mailer.messages)
+	})
+
+	t.Run("SilentForVerifiedAccount", func(t *testing.T) {
+		f := newEmailVerificationFixture()
+		verifiedAt := time.Now()
+		f.user.EmailVerifiedAt = &verifiedAt
+
+		require.NoError(t, f.service.Resend(context.Background(), f.user.Email))
+		assert.Empty(t, f.mailer.messages)
+	})
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrExerciseNotFound    = errors.New("exercise not found")
+	ErrExerciseNameTaken   = errors.New("exercise with this name already exists")
+	ErrExerciseNotEditable = errors.New("catalog exercises cannot be modified")
+)
+
+type ExerciseService interface {
+	Create(ctx context.Context, userID uuid.UUID, req *models.CreateExerciseRequest) (*models.Exercise, error)
+	Get(ctx context.Context, userID, exerciseID uuid.UUID) (*models.Exercise, error)
+	Search(ctx context.Context, userID uuid.UUID, filter models.ExerciseFilter) ([]*models.Exercise, error)
+	Update(ctx context.Context, userID, exerciseID uuid.UUID, req *models.UpdateExerciseRequest) (*models.Exercise, error)
+	Delete(ctx context.Context, userID, exerciseID uuid.UUID) error
+}
+
+type exerciseService struct {
+	exerciseRepo repositories.ExerciseRepository
+}
+
+func NewExerciseService(exerciseRepo repositories.ExerciseRepository) ExerciseService {
+	return &exerciseService{
+		exerciseRepo: exerciseRepo,
+	}
+}
+
+func (s *exerciseService) Create(ctx context.Context, userID uuid.UUID, req *models.CreateExerciseRequest) (*models.Exercise, error) {
+	now := time.Now()
+	ownerID := userID
+	exercise := &models.Exercise{
+		ID:               uuid.New(),
+		OwnerID:          &ownerID,
+		Name:             req.Name,
+		PrimaryMuscles:   req.PrimaryMuscles,
+		SecondaryMuscles: req.SecondaryMuscles,
+		Equipment:        req.Equipment,
+		MovementPattern:  req.MovementPattern,
+		IsUnilateral:     req.IsUnilateral,
+		IsCustom:         true,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if exercise.SecondaryMuscles == nil {
+		exercise.SecondaryMuscles = []string{}
+	}
+
+	err := s.exerciseRepo.Create(ctx, exercise)
+	if errors.Is(err, repositories.ErrAlreadyExists) {
+		return nil, ErrExerciseNameTaken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create exercise: %w", err)
+	}
+
+	return exercise, nil
+}
+
+func (s *exerciseService) Get(ctx context.Context, userID, exerciseID uuid.UUID) (*models.Exercise, error) {
+	exercise, err := s.exerciseRepo.GetByID(ctx, exerciseID, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrExerciseNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exercise: %w", err)
+	}
+
+	return exercise, nil
+}
+
+func (s *exerciseService) Search(ctx context.Context, userID uuid.UUID, filter models.ExerciseFilter) ([]*models.Exercise, error) {
+	filter.Pagination = filter.Pagination.Normalize()
+
+	exercises, err := s.exerciseRepo.Search(ctx, userID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search exercises: %w", err)
+	}
+
+	return exercises, nil
+}
+
+func (s *exerciseService) getOwned(ctx context.Context, userID, exerciseID uuid.UUID) (*models.Exercise, error) {
+	exercise, err := s.Get(ctx, userID, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+	if exercise.OwnerID == nil || *exercise.OwnerID != userID {
+		return nil, ErrExerciseNotEditable
+	}
+	return exercise, nil
+}
+
+func (s *exerciseService) Update(
+	ctx context.Context,
+	userID, exerciseID uuid.UUID,
+	req *models.UpdateExerciseRequest,
+) (*models.Exercise, error) {
+	exercise, err := s.getOwned(ctx, userID, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		exercise.Name = *req.Name
+	}
+	if req.PrimaryMuscles != nil {
+		exercise.PrimaryMuscles = *req.PrimaryMuscles
+	}
+	if req.SecondaryMuscles != nil {
+		exercise.SecondaryMuscles = *req.SecondaryMuscles
+	}
+	if req.Equipment != nil {
+		exercise.Equipment = *req.Equipment
+	}
+	if req.MovementPattern != nil {
+		exercise.MovementPattern = *req.MovementPattern
+	}
+	if req.IsUnilateral != nil {
+		exercise.IsUnilateral = *req.IsUnilateral
+	}
+	exercise.UpdatedAt = time.Now()
+
+	err = s.exerciseRepo.Update(ctx, exercise)
+	switch {
+	case errors.Is(err, repositories.ErrAlreadyExists):
+		return nil, ErrExerciseNameTaken
+	case errors.Is(err, repositories.ErrNotFound):
+		return nil, ErrExerciseNotFound
+	case err != nil:
+		return nil, fmt.Errorf("failed to update exercise: %w", err)
+	}
+
+	return exercise, nil
+}
+
+func (s *exerciseService) Delete(ctx context.Context, userID, exerciseID uuid.UUID) error {
+	if _, err := s.getOwned(ctx, userID, exerciseID); err != nil {
+		return err
+	}
+
+	err := s.exerciseRepo.Delete(ctx, exerciseID, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrExerciseNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete exercise: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockExerciseRepository struct {
+	exercises map[uuid.UUID]*models.Exercise
+}
+
+func newMockExerciseRepository(seed ...*models.Exercise) *mockExerciseRepository {
+	repo := &mockExerciseRepository{exercises: make(map[uuid.UUID]*models.Exercise)}
+	for _, exercise := range seed {
+		repo.exercises[exercise.ID] = exercise
+	}
+	return repo
+}
+
+func visibleTo(exercise *models.Exercise, userID uuid.UUID) bool {
+	return exercise.OwnerID == nil || *exercise.OwnerID == userID
+}
+
+func (m *mockExerciseRepository) Create(ctx context.Context, exercise *models.Exercise) error {
+	for _, existing := range m.exercises {
+		sameOwner := (existing.OwnerID == nil && exercise.OwnerID == nil) ||
+			(existing.OwnerID != nil && exercise.OwnerID != nil && *existing.OwnerID == *exercise.OwnerID)
+		if sameOwner && strings.EqualFold(existing.Name, exercise.Name) {
+			return repositories.ErrAlreadyExists
+		}
+	}
+	m.exercises[exercise.ID] = exercise
+	return nil
+}
+
+func (m *mockExerciseRepository) GetByID(ctx context.Context, id, userID uuid.UUID) (*models.Exercise, error) {
+	exercise, exists := m.exercises[id]
+	if !exists || !visibleTo(exercise, userID) {
+		return nil, repositories.ErrNotFound
+	}
+	return exercise, nil
+}
+
+func (m *mockExerciseRepository) Search(ctx context.Context, userID uuid.UUID, filter models.ExerciseFilter) ([]*models.Exercise, error) {
+	var exercises []*models.Exercise
+	for _, exercise := range m.exercises {
+		if !visibleTo(exercise, userID) {
+			continue
+		}
+		if filter.Equipment != "" && exercise.Equipment != filter.Equipment {
+			continue
+		}
+		if filter.Query != "" && !strings.Contains(strings.ToLower(exercise.Name), strings.ToLower(filter.Query)) {
+			continue
+		}
+		exercises = append(exercises, exercise)
+	}
+	return exercises, nil
+}
+
+func (m *mockExerciseRepository) Update(ctx context.Context, exercise *models.Exercise) error {
+	m.exercises[exercise.ID] = exercise
+	return nil
+}
+
+func (m *mockExerciseRepository) Delete(ctx context.Context, id, ownerID uuid.UUID) error {
+	exercise, exists := m.exercises[id]
+	if !exists || exercise.OwnerID == nil || *exercise.OwnerID != ownerID {
+		return repositories.ErrNotFound
+	}
+	delete(m.exercises, id)
+	return nil
+}
+
+func TestExerciseService_CustomExercises(t *testing.T) {
+	catalogExercise := &models.Exercise{ID: uuid.New(), Name: "Back Squat", Equipment: "barbell"}
+	service := NewExerciseService(newMockExerciseRepository(catalogExercise))
+	ctx := context.Background()
+	userID := uuid.New()
+	otherID := uuid.New()
+
+	custom, err := service.Create(ctx, userID, &models.CreateExerciseRequest{
+		Name:            "Landmine Press",
+		PrimaryMuscles:  []string{"shoulders"},
+		Equipment:       "barbell",
+		MovementPattern: "vertical_push",
+	})
+	require.NoError(t, err)
+	assert.True(t, custom.IsCustom)
+	assert.NotNil(t, custom.SecondaryMuscles)
+
+	t.Run("DuplicateNameRejected", func(t *testing.T) {
+		_, err := service.Create(ctx, userID, &models.CreateExerciseRequest{Name: "landmine press"})
+		assert.ErrorIs(t, err, ErrExerciseNameTaken)
+	})
+
+	t.Run("SearchIncludesCatalogAndOwnCustom", func(t *testing.T) {
+		results, err := service.Search(ctx, userID, models.ExerciseFilter{Equipment: "barbell"})
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+
+		results, err = service.Search(ctx, otherID, models.ExerciseFilter{Equipment: "barbell"})
+		require.NoError(t, err)
+		assert.Len(t, results, 1)
+	})
+
+	t.Run("CustomHiddenFromOtherUsers", func(t *testing.T) {
+		_, err := service.Get(ctx, otherID, custom.ID)
+		assert.ErrorIs(t, err, ErrExerciseNotFound)
+	})
+
+	t.Run("CatalogIsReadOnly", func(t *testing.T) {
+		name := "Renamed"
+		_, err := service.Update(ctx, userID, catalogExercise.ID, &models.UpdateExerciseRequest{Name: &name})
+		assert.ErrorIs(t, err, ErrExerciseNotEditable)
+
+		err = service.Delete(ctx, userID, catalogExercise.ID)
+		assert.ErrorIs(t, err, ErrExerciseNotEditable)
+	})
+
+	t.Run("OwnerCanDelete", func(t *testing.T) {
+		require.NoError(t, service.Delete(ctx, userID, custom.ID))
+		_, err := service.Get(ctx, userID, custom.ID)
+		assert.ErrorIs(t, err, ErrExerciseNotFound)
+	})
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+
+	"github.com/aleksandr/strive-api/internal/config"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwtKeyring holds the key new access tokens are signed with and every key
+// tokens are still accepted from, indexed by kid.
+type jwtKeyring struct {
+	signing *jwtKey
+	keys    map[string]*jwtKey
+	// ordered keeps the configured order for the published JWKS.
+	ordered []*jwtKey
+}
+
+func newJWTKeyring(cfg *config.JWTConfig) *jwtKeyring {
+	ring := &jwtKeyring{keys: make(map[string]*jwtKey)}
+
+	if len(cfg.Keys) == 0 {
+		// A single key configured directly, as before keyrings existed.
+		key := newJWTKey(cfg.SingleKey())
+		ring.add(key)
+		ring.signing = key
+		return ring
+	}
+
+	for _, configured := range cfg.Keys {
+		key := newJWTKey(configured)
+		ring.add(key)
+		if key.id == cfg.SigningKeyID {
+			ring.signing = key
+		}
+	}
+	return ring
+}
+
+func (r *jwtKeyring) add(key *jwtKey) {
+	r.keys[key.id] = key
+	r.ordered = append(r.ordered, key)
+}
+
+// verificationKey picks the key for a token being parsed. Tokens issued before
+// kid headers were introduced carry none and can only match the signing key.
+func (r *jwtKeyring) verificationKey(token *jwt.Token) (interface{}, error) {
+	key := r.signing
+	if kid, ok := token.Header["kid"]; ok {
+		id, _ := kid.(string)
+		key = r.keys[id]
+	}
+	if key == nil || token.Method.Alg() != key.method.Alg() {
+		return nil, ErrInvalidSignature
+	}
+	return key.verifyKey, nil
+}
+
+// jwtKey is a key access tokens are signed or verified with.
+type jwtKey struct {
+	id     string
+	method jwt.SigningMethod
+	// signKey is nil for keys that only verify tokens.
+	signKey   interface{}
+	verifyKey interface{}
+	// jwk is the published public key; nil for HS256, whose key is secret.
+	jwk *models.JWK
+}
+
+func newJWTKey(cfg config.JWTKey) *jwtKey {
+	key := &jwtKey{id: cfg.ID}
+
+	switch cfg.Algorithm {
+	case config.JWTAlgorithmRS256:
+		public := cfg.Public().(*rsa.PublicKey)
+		key.method = jwt.SigningMethodRS256
+		key.signKey = cfg.PrivateKey
+		key.verifyKey = public
+		key.jwk = &models.JWK{
+			Kty: "RSA",
+			Alg: config.JWTAlgorithmRS256,
+			N:   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+		}
+	case config.JWTAlgorithmEdDSA:
+		public := cfg.Public().(ed25519.PublicKey)
+		key.method = jwt.SigningMethodEdDSA
+		key.signKey = cfg.PrivateKey
+		key.verifyKey = public
+		key.jwk = &models.JWK{
+			Kty: "OKP",
+			Alg: config.JWTAlgorithmEdDSA,
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(public),
+		}
+	default:
+		// HS256 stays the default for configs that predate the algorithm setting.
+		key.method = jwt.SigningMethodHS256
+		key.signKey = []byte(cfg.Secret)
+		key.verifyKey = []byte(cfg.Secret)
+	}
+
+	if key.id == "" {
+		key.id = key.thumbprint(cfg.Secret)
+	}
+	if key.jwk != nil {
+		key.jwk.Use = "sig"
+		key.jwk.Kid = key.id
+	}
+
+	return key
+}
+
+// thumbprint derives a stable kid: the RFC 7638 thumbprint of a public key, or
+// a truncated digest of an HMAC secret.
+func (k *jwtKey) thumbprint(secret string) string {
+	if k.jwk == nil {
+		sum := sha256.Sum256([]byte(secret))
+		return hex.EncodeToString(sum[:8])
+	}
+
+	// RFC 7638 hashes the required members in lexicographic order, which is
+	// the order encoding/json writes map keys in.
+	members := map[string]string{"kty": k.jwk.Kty}
+	switch k.jwk.Kty {
+	case "RSA":
+		members["e"] = k.jwk.E
+		members["n"] = k.jwk.N
+	case "OKP":
+		members["crv"] = k.jwk.Crv
+		members["x"] = k.jwk.X
+	}
+	encoded, _ := json.Marshal(members)
+	sum := sha256.Sum256(encoded)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
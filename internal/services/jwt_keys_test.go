@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/config"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAsymmetricAuthService(t *testing.T, algorithm string, key crypto.Signer) AuthService {
+	t.Helper()
+	jwtConfig := &config.JWTConfig{
+		Algorithm:  algorithm,
+		PrivateKey: key,
+		Issuer:     "test-issuer",
+		Audience:   "test-audience",
+	}
+	userRepo := &mockUserRepository{users: make(map[string]*models.User)}
+	refreshRepo := &mockRefreshTokenRepository{tokens: make(map[string]*models.RefreshToken)}
+	return NewAuthService(
+		userRepo, refreshRepo, newMockMFARepository(), noLoginThrottle{},
+		noAudit{}, newTestTokenRevocations(), jwtConfig, &config.AuthConfig{},
+	)
+}
+
+func TestAuthService_AsymmetricSigning(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		algorithm string
+		key       crypto.Signer
+		kty       string
+	}{
+		{config.JWTAlgorithmRS256, rsaKey, "RSA"},
+		{config.JWTAlgorithmEdDSA, edKey, "OKP"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			service := newAsymmetricAuthService(t, tt.algorithm, tt.key)
+			user := &models.User{ID: uuid.New(), Email: "test@example.com"}
+
+			token, err := service.IssueAccessToken(user)
+			require.NoError(t, err)
+
+			claims, err := service.ValidateToken(token)
+			require.NoError(t, err)
+			assert.Equal(t, user.ID, claims.UserID)
+
+			jwks := service.JWKS()
+			require.Len(t, jwks.Keys, 1)
+			assert.Equal(t, tt.kty, jwks.Keys[0].Kty)
+			assert.Equal(t, tt.algorithm, jwks.Keys[0].Alg)
+			assert.Equal(t, "sig", jwks.Keys[0].Use)
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &Claims{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.algorithm, parsed.Header["alg"])
+			assert.Equal(t, jwks.Keys[0].Kid, parsed.Header["kid"], "kid points at the published key")
+		})
+	}
+}
+
+func TestAuthService_RejectsTokensFromOtherKeys(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	service := newAsymmetricAuthService(t, config.JWTAlgorithmEdDSA, edKey)
+	other := newAsymmetricAuthService(t, config.JWTAlgorithmEdDSA, otherKey)
+	user := &models.User{ID: uuid.New(), Email: "test@example.com"}
+
+	t.Run("OtherKey", func(t *testing.T) {
+		token, err := other.IssueAccessToken(user)
+		require.NoError(t, err)
+		_, err = service.ValidateToken(token)
+		assert.Error(t, err)
+	})
+
+	t.Run("HS256Downgrade", func(t *testing.T) {
+		claims := &Claims{
+			UserID: user.ID,
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    "test-issuer",
+				Audience:  jwt.ClaimStrings{"test-audience"},
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+		}
+		// A public key must never be accepted as an HMAC secret.
+		public := edKey.Public().(ed25519.PublicKey)
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(public))
+		require.NoError(t, err)
+		_, err = service.ValidateToken(token)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+}
+
+func TestAuthService_HS256HasNoPublicKeys(t *testing.T) {
+	userRepo := &mockUserRepository{users: make(map[string]*models.User)}
+	refreshRepo := &mockRefreshTokenRepository{tokens: make(map[string]*models.RefreshToken)}
+	jwtConfig := &config.JWTConfig{Secret: "test-secret", Issuer: "test-issuer", Audience: "test-audience"}
+	service := NewAuthService(
+		userRepo, refreshRepo, newMockMFARepository(), noLoginThrottle{},
+		noAudit{}, newTestTokenRevocations(), jwtConfig, &config.AuthConfig{},
+	)
+
+	assert.Empty(t, service.JWKS().Keys)
+
+	_, err := service.Register(context.Background(), &models.CreateUserRequest{Email: "test@example.com", Password: "password123"})
+	require.NoError(t, err)
+	accessToken, _, err := service.Login(context.Background(), "test@example.com", "password123", models.ClientInfo{})
+	require.NoError(t, err)
+	_, err = service.ValidateToken(accessToken)
+	assert.NoError(t, err)
+}
+
+func TestAuthService_KeyRotation(t *testing.T) {
+	_, oldKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, newKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	user := &models.User{ID: uuid.New(), Email: "test@example.com"}
+
+	serviceWith := func(signingKeyID string, keys ...config.JWTKey) AuthService {
+		jwtConfig := &config.JWTConfig{
+			Keys:         keys,
+			SigningKeyID: signingKeyID,
+			Issuer:       "test-issuer",
+			Audience:     "test-audience",
+		}
+		userRepo := &mockUserRepository{users: make(map[string]*models.User)}
+		refreshRepo := &mockRefreshTokenRepository{tokens: make(map[string]*models.RefreshToken)}
+		return NewAuthService(
+			userRepo, refreshRepo, newMockMFARepository(), noLoginThrottle{},
+			noAudit{}, newTestTokenRevocations(), jwtConfig, &config.AuthConfig{},
+		)
+	}
+	old := config.JWTKey{ID: "old", Algorithm: config.JWTAlgorithmEdDSA, PrivateKey: oldKey}
+	next := config.JWTKey{ID: "new", Algorithm: config.JWTAlgorithmEdDSA, PrivateKey: newKey}
+
+	before := serviceWith("old", old)
+	oldToken, err := before.IssueAccessToken(user)
+	require.NoError(t, err)
+
+	promoted := serviceWith("new", next, old)
+	_, err = promoted.ValidateToken(oldToken)
+	assert.NoError(t, err, "tokens from the previous key stay valid")
+
+	newToken, err := promoted.IssueAccessToken(user)
+	require.NoError(t, err)
+	parsed, _, err := jwt.NewParser().ParseUnverified(newToken, &Claims{})
+	require.NoError(t, err)
+	assert.Equal(t, "new", parsed.Header["kid"], "new tokens are signed with the promoted key")
+
+	kids := []string{}
+	for _, key := range promoted.JWKS().Keys {
+		kids = append(kids, key.Kid)
+	}
+	assert.Equal(t, []string{"new", "old"}, kids, "both keys are published")
+
+	demoted := serviceWith("new", next, config.JWTKey{ID: "old", Algorithm: config.JWTAlgorithmEdDSA, PublicKey: oldKey.Public()})
+	_, err = demoted.ValidateToken(oldToken)
+	assert.NoError(t, err, "a key holding only its public half still verifies")
+
+	retired := serviceWith("new", next)
+	_, err = retired.ValidateToken(oldToken)
+	assert.ErrorIs(t, err, ErrInvalidSignature, "retired keys are no longer accepted")
+	_, err = retired.ValidateToken(newToken)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/config"
+	"github.com/aleksandr/strive-api/internal/mail"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+)
+
+// ErrLoginThrottled is returned, as a *LoginThrottledError, while an account
+// or IP address has to wait after failed sign-ins.
+var ErrLoginThrottled = errors.New("too many failed sign-in attempts")
+
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return ErrLoginThrottled.Error()
+}
+
+func (e *LoginThrottledError) Unwrap() error {
+	return ErrLoginThrottled
+}
+
+// LoginThrottleService slows down password and code guessing. Attempts are
+// counted per account email, whether or not an account uses it, so throttling
+// reveals nothing about which emails are registered.
+type LoginThrottleService interface {
+	// Reserve counts an attempt against ip and email before its credentials
+	// are checked, so concurrent guesses cannot all get past one check. While
+	// either has to wait it returns a *LoginThrottledError and counts nothing.
+	// An empty ip is not counted.
+	Reserve(ctx context.Context, email, ip string) error
+	// RecordFailure reports that a reserved attempt failed. The account owner
+	// is emailed when it locked the account.
+	RecordFailure(ctx context.Context, email string) error
+	// Release uncounts a reserved attempt that was not a failed guess but does
+	// not sign in either: a second factor is still needed, the account cannot
+	// be used, or checking it failed. The waits are as if it never happened.
+	Release(ctx context.Context, email, ip string) error
+	// RecordSuccess clears the account's count once it is fully signed in and
+	// uncounts the attempt reserved against ip. The IP address keeps its
+	// earlier failures, so signing in to an account of one's own does not
+	// reset them.
+	RecordSuccess(ctx context.Context, email, ip string) error
+	// ReserveStepUp counts a code entered from a signed-in session to confirm
+	// a sensitive change, like Reserve but against a count of the user's own,
+	// so whoever holds a stolen session cannot lock the owner out of signing in.
+	ReserveStepUp(ctx context.Context, userID uuid.UUID) error
+	// ReleaseStepUp uncounts a reserved step-up attempt that was not a wrong code.
+	ReleaseStepUp(ctx context.Context, userID uuid.UUID) error
+	// RecordStepUpSuccess clears the user's step-up count once a code is accepted.
+	RecordStepUpSuccess(ctx context.Context, userID uuid.UUID) error
+}
+
+type loginThrottleService struct {
+	repo     repositories.LoginFailureRepository
+	userRepo repositories.UserRepository
+	mailer   mail.Sender
+	config   *config.AuthConfig
+	now      func() time.Time
+}
+
+func NewLoginThrottleService(
+	repo repositories.LoginFailureRepository,
+	userRepo repositories.UserRepository,
+	mailer mail.Sender,
+	authConfig *config.AuthConfig,
+) LoginThrottleService {
+	return &loginThrottleService{
+		repo:     repo,
+		userRepo: userRepo,
+		mailer:   mailer,
+		config:   authConfig,
+		now:      time.Now,
+	}
+}
+
+func (s *loginThrottleService) Reserve(ctx context.Context, email, ip string) error {
+	return s.reserve(ctx, s.keys(email, ip))
+}
+
+func (s *loginThrottleService) reserve(ctx context.Context, keys []loginFailureKey) error {
+	now := s.now()
+	for i, key := range keys {
+		waits := s.waits(key.maxFailures)
+		failure, ok, err := s.repo.Reserve(ctx, key.scope, key.key, now, now.Add(-s.config.LoginFailureWindow), waits)
+		if err != nil {
+			// The attempt goes no further, so it must not stay counted against the keys before this one.
+			return errors.Join(err, s.release(ctx, keys[:i]))
+		}
+		if !ok {
+			// Nor when it is refused; the keys after this one are never touched.
+			if err := s.release(ctx, keys[:i]); err != nil {
+				return err
+			}
+			return &LoginThrottledError{RetryAfter: failure.LastFailureAt.Add(waits[min(failure.Failures, len(waits))-1]).Sub(now)}
+		}
+	}
+	return nil
+}
+
+func (s *loginThrottleService) RecordFailure(ctx context.Context, email string) error {
+	failure, err := s.repo.Get(ctx, models.LoginFailureScopeAccount, loginFailureKeyOf(email))
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check login failures: %w", err)
+	}
+	if failure.Failures == s.config.LoginMaxFailures {
+		s.notifyLocked(ctx, email, failure.Failures)
+	}
+	return nil
+}
+
+func (s *loginThrottleService) Release(ctx context.Context, email, ip string) error {
+	return s.release(ctx, s.keys(email, ip))
+}
+
+func (s *loginThrottleService) release(ctx context.Context, keys []loginFailureKey) error {
+	for _, key := range keys {
+		if err := s.repo.Release(ctx, key.scope, key.key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *loginThrottleService) RecordSuccess(ctx context.Context, email, ip string) error {
+	if err := s.repo.Delete(ctx, models.LoginFailureScopeAccount, loginFailureKeyOf(email)); err != nil {
+		return err
+	}
+	if ip != "" {
+		if err := s.repo.Release(ctx, models.LoginFailureScopeIP, loginFailureKeyOf(ip)); err != nil {
+			return err
+		}
+	}
+
+	// Counts older than the window no longer throttle anyone; pruning them is best effort.
+	_ = s.repo.DeleteStale(ctx, s.now().Add(-s.config.LoginFailureWindow))
+
+	return nil
+}
+
+func (s *loginThrottleService) ReserveStepUp(ctx context.Context, userID uuid.UUID) error {
+	return s.reserve(ctx, []loginFailureKey{s.stepUpKey(userID)})
+}
+
+func (s *loginThrottleService) ReleaseStepUp(ctx context.Context, userID uuid.UUID) error {
+	return s.release(ctx, []loginFailureKey{s.stepUpKey(userID)})
+}
+
+func (s *loginThrottleService) RecordStepUpSuccess(ctx context.Context, userID uuid.UUID) error {
+	return s.repo.Delete(ctx, models.LoginFailureScopeStepUp, userID.String())
+}
+
+type loginFailureKey struct {
+	scope       string
+	key         string
+	maxFailures int
+}
+
+// keys lists what an attempt is counted against. The IP address comes first,
+// so attempts refused for it never touch the account's count: a locked-out
+// address cannot keep the account's wait going.
+func (s *loginThrottleService) keys(email, ip string) []loginFailureKey {
+	var keys []loginFailureKey
+	if ip != "" {
+		keys = append(keys, loginFailureKey{models.LoginFailureScopeIP, loginFailureKeyOf(ip), s.config.LoginIPMaxFailures})
+	}
+	return append(keys, loginFailureKey{models.LoginFailureScopeAccount, loginFailureKeyOf(email), s.config.LoginMaxFailures})
+}
+
+func (s *loginThrottleService) stepUpKey(userID uuid.UUID) loginFailureKey {
+	return loginFailureKey{models.LoginFailureScopeStepUp, userID.String(), s.config.LoginMaxFailures}
+}
+
+// maxLoginFailureKeyLength is the width of login_failures.key.
+const maxLoginFailureKeyLength = 255
+
+// loginFailureKeyOf cuts an email or IP address to fit login_failures.key,
+// without splitting a character.
+func loginFailureKeyOf(value string) string {
+	if len(value) <= maxLoginFailureKeyLength {
+		return value
+	}
+	return strings.ToValidUTF8(value[:maxLoginFailureKeyLength], "")
+}
+
+// waits is how long to wait after each number of attempts up to maxFailures.
+func (s *loginThrottleService) waits(maxFailures int) []time.Duration {
+	waits := make([]time.Duration, maxFailures)
+	for i := range waits {
+		waits[i] = s.backoff(i+1, maxFailures)
+	}
+	return waits
+}
+
+// backoff is how long to wait after the last of failures: doubling from the
+// base with each failure, and the full lockout once maxFailures is reached.
+func (s *loginThrottleService) backoff(failures, maxFailures int) time.Duration {
+	if failures >= maxFailures {
+		return s.config.LoginLockoutDuration
+	}
+	backoff := s.config.LoginBackoffBase
+	for i := 1; i < failures && backoff < s.config.LoginLockoutDuration; i++ {
+		backoff *= 2
+	}
+	return min(backoff, s.config.LoginLockoutDuration)
+}
+
+// notifyLocked tells the account owner about the lockout. It is best effort:
+// the lockout applies either way, and unknown emails get no message.
+func (s *loginThrottleService) notifyLocked(ctx context.Context, email string, failures int) {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return
+	}
+
+	_ = s.mailer.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "Sign-in to your Strive account was locked",
+		Body: fmt.Sprintf(
+			"There were %d failed attempts to sign in to your Strive account, so signing in is blocked for %s.\n\n"+
+				"If this wasn't you, someone may be guessing your password. Consider resetting it and "+
+				"turning on two-factor authentication.\n",
+			failures, s.config.LoginLockoutDuration,
+		),
+	})
+}
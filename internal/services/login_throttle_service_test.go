@@ -0,0 +1,318 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/config"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noLoginThrottle lets every attempt through, for tests about other behaviour.
+type noLoginThrottle struct{}
+
+func (noLoginThrottle) Reserve(ctx context.Context, email, ip string) error             { return nil }
+func (noLoginThrottle) RecordFailure(ctx context.Context, email string) error           { return nil }
+func (noLoginThrottle) Release(ctx context.Context, email, ip string) error             { return nil }
+func (noLoginThrottle) RecordSuccess(ctx context.Context, email, ip string) error       { return nil }
+func (noLoginThrottle) ReserveStepUp(ctx context.Context, userID uuid.UUID) error       { return nil }
+func (noLoginThrottle) ReleaseStepUp(ctx context.Context, userID uuid.UUID) error       { return nil }
+func (noLoginThrottle) RecordStepUpSuccess(ctx context.Context, userID uuid.UUID) error { return nil }
+
+type mockLoginFailureRepository struct {
+	failures map[string]*models.LoginFailure
+	// reserveErrs fails reservations in the scopes it has an error for.
+	reserveErrs map[string]error
+}
+
+func newMockLoginFailureRepository() *mockLoginFailureRepository {
+	return &mockLoginFailureRepository{failures: make(map[string]*models.LoginFailure)}
+}
+
+func (m *mockLoginFailureRepository) Get(ctx context.Context, scope, key string) (*models.LoginFailure, error) {
+	failure, exists := m.failures[scope+"/"+key]
+	if !exists {
+		return nil, repositories.ErrNotFound
+	}
+	copied := *failure
+	return &copied, nil
+}
+
+func (m *mockLoginFailureRepository) Reserve(
+	ctx context.Context,
+	scope, key string,
+	now, resetBefore time.Time,
+	waits []time.Duration,
+) (*models.LoginFailure, bool, error) {
+	if err := m.reserveErrs[scope]; err != nil {
+		return nil, false, err
+	}
+	failure, exists := m.failures[scope+"/"+key]
+	switch {
+	case !exists:
+		failure = &models.LoginFailure{Scope: scope, Key: key, Failures: 1, LastFailureAt: now}
+		m.failures[scope+"/"+key] = failure
+	case failure.LastFailureAt.Before(resetBefore):
+		previous := failure.LastFailureAt
+		failure.Failures, failure.LastFailureAt, failure.PreviousFailureAt = 1, now, &previous
+	case failure.Failures > 0 && failure.LastFailureAt.Add(waits[min(failure.Failures, len(waits))-1]).After(now):
+		copied := *failure
+		return &copied, false, nil
+	default:
+		previous := failure.LastFailureAt
+		failure.Failures, failure.LastFailureAt, failure.PreviousFailureAt = failure.Failures+1, now, &previous
+	}
+	copied := *failure
+	return &copied, true, nil
+}
+
+func (m *mockLoginFailureRepository) Release(ctx context.Context, scope, key string) error {
+	if failure, exists := m.failures[scope+"/"+key]; exists && failure.Failures > 0 {
+		failure.Failures--
+		if failure.PreviousFailureAt != nil {
+			failure.LastFailureAt = *failure.PreviousFailureAt
+		}
+		failure.PreviousFailureAt = nil
+	}
+	return nil
+}
+
+func (m *mockLoginFailureRepository) Delete(ctx context.Context, scope, key string) error {
+	delete(m.failures, scope+"/"+key)
+	return nil
+}
+
+func (m *mockLoginFailureRepository) DeleteStale(ctx context.Context, before time.Time) error {
+	for id, failure := range m.failures {
+		if failure.LastFailureAt.Before(before) {
+			delete(m.failures, id)
+		}
+	}
+	return nil
+}
+
+type loginThrottleFixture struct {
+	service  *loginThrottleService
+	repo     *mockLoginFailureRepository
+	userRepo *mockUserRepository
+	mailer   *recordingSender
+	now      time.Time
+}
+
+func newLoginThrottleFixture() *loginThrottleFixture {
+	f := &loginThrottleFixture{
+		repo:     newMockLoginFailureRepository(),
+		userRepo: &mockUserRepository{users: make(map[string]*models.User)},
+		mailer:   &recordingSender{},
+		now:      time.Now(),
+	}
+	f.service = NewLoginThrottleService(f.repo, f.userRepo, f.mailer, &config.AuthConfig{
+		LoginBackoffBase:     time.Second,
+		LoginMaxFailures:     4,
+		LoginIPMaxFailures:   6,
+		LoginLockoutDuration: 15 * time.Minute,
+		LoginFailureWindow:   time.Hour,
+	}).(*loginThrottleService)
+	f.service.now = func() time.Time { return f.now }
+	return f
+}
+
+// retryAfter makes an attempt and returns how long it was told to wait, or
+// zero if it was let through and counted.
+func (f *loginThrottleFixture) retryAfter(t *testing.T, email, ip string) time.Duration {
+	t.Helper()
+	err := f.service.Reserve(context.Background(), email, ip)
+	if err == nil {
+		return 0
+	}
+	var throttled *LoginThrottledError
+	require.ErrorAs(t, err, &throttled)
+	assert.ErrorIs(t, err, ErrLoginThrottled)
+	return throttled.RetryAfter
+}
+
+// fail makes an attempt that is let through and fails.
+func (f *loginThrottleFixture) fail(t *testing.T, email, ip string) {
+	t.Helper()
+	require.NoError(t, f.service.Reserve(context.Background(), email, ip))
+	require.NoError(t, f.service.RecordFailure(context.Background(), email))
+}
+
+func (f *loginThrottleFixture) failures(scope, key string) int {
+	if failure, exists := f.repo.failures[scope+"/"+key]; exists {
+		return failure.Failures
+	}
+	return 0
+}
+
+func TestLoginThrottleService_Backoff(t *testing.T) {
+	f := newLoginThrottleFixture()
+	f.userRepo.users["user@example.com"] = &models.User{Email: "user@example.com"}
+
+	for _, wait := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+		f.fail(t, "user@example.com", "203.0.113.7")
+		assert.Equal(t, wait, f.retryAfter(t, "user@example.com", ""))
+		f.now = f.now.Add(wait)
+	}
+	assert.Empty(t, f.mailer.messages)
+
+	f.fail(t, "user@example.com", "203.0.113.7")
+	assert.Equal(t, 15*time.Minute, f.retryAfter(t, "user@example.com", ""), "the fourth failure locks the account")
+	require.Len(t, f.mailer.messages, 1)
+	assert.Equal(t, "user@example.com", f.mailer.messages[0].To)
+	assert.Equal(t, 4, f.failures(models.LoginFailureScopeAccount, "user@example.com"), "refused attempts are not counted")
+
+	f.now = f.now.Add(15 * time.Minute)
+	f.fail(t, "user@example.com", "203.0.113.7")
+	assert.Equal(t, 15*time.Minute, f.retryAfter(t, "user@example.com", ""), "failures within the window lock again at once")
+	assert.Len(t, f.mailer.messages, 1, "the owner is notified once per lockout streak")
+
+	f.now = f.now.Add(time.Hour + time.Second)
+	f.fail(t, "user@example.com", "203.0.113.7")
+	assert.Equal(t, time.Second, f.retryAfter(t, "user@example.com", ""), "failures are forgotten after the window")
+}
+
+func TestLoginThrottleService_Reserve(t *testing.T) {
+	f := newLoginThrottleFixture()
+
+	require.NoError(t, f.service.Reserve(context.Background(), "user@example.com", "203.0.113.7"))
+	assert.Equal(t, time.Second, f.retryAfter(t, "user@example.com", ""),
+		"an attempt still being checked holds off the next one, so concurrent guesses cannot all get through")
+
+	require.NoError(t, f.service.Release(context.Background(), "user@example.com", "203.0.113.7"))
+	assert.Zero(t, f.retryAfter(t, "user@example.com", ""), "a released attempt does not count")
+	assert.Zero(t, f.failures(models.LoginFailureScopeIP, "203.0.113.7"))
+
+	long := strings.Repeat("1", 300)
+	require.NoError(t, f.service.Reserve(context.Background(), "other@example.com", long))
+	assert.Equal(t, 1, f.failures(models.LoginFailureScopeIP, long[:maxLoginFailureKeyLength]), "keys are cut to fit the column")
+}
+
+func TestLoginThrottleService_RefusedAndReleasedAttemptsKeepTheWait(t *testing.T) {
+	f := newLoginThrottleFixture()
+	f.fail(t, "victim@example.com", "198.51.100.1")
+	lastFailure := f.repo.failures[models.LoginFailureScopeAccount+"/victim@example.com"].LastFailureAt
+
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com", "d@example.com", "e@example.com", "f@example.com"} {
+		f.now = f.now.Add(time.Minute)
+		f.fail(t, email, "203.0.113.7")
+	}
+	for range 3 {
+		f.now = f.now.Add(time.Minute)
+		assert.NotZero(t, f.retryAfter(t, "victim@example.com", "203.0.113.7"), "the IP address is locked")
+	}
+	failure := f.repo.failures[models.LoginFailureScopeAccount+"/victim@example.com"]
+	assert.Equal(t, 1, failure.Failures)
+	assert.Equal(t, lastFailure, failure.LastFailureAt, "attempts refused for a locked IP address do not move the account's window")
+
+	require.NoError(t, f.service.Reserve(context.Background(), "victim@example.com", "198.51.100.1"))
+	require.NoError(t, f.service.Release(context.Background(), "victim@example.com", "198.51.100.1"))
+	failure = f.repo.failures[models.LoginFailureScopeAccount+"/victim@example.com"]
+	assert.Equal(t, 1, failure.Failures)
+	assert.Equal(t, lastFailure, failure.LastFailureAt, "a released attempt does not move it either")
+
+	f.repo.reserveErrs = map[string]error{models.LoginFailureScopeAccount: errors.New("database is down")}
+	require.Error(t, f.service.Reserve(context.Background(), "victim@example.com", "198.51.100.1"))
+	assert.Equal(t, 1, f.failures(models.LoginFailureScopeIP, "198.51.100.1"),
+		"an attempt that could not be counted against the account is not left counted against the IP address")
+}
+
+func TestLoginThrottleService_UnknownEmail(t *testing.T) {
+	f := newLoginThrottleFixture()
+
+	for range 4 {
+		f.now = f.now.Add(8 * time.Second)
+		f.fail(t, "nobody@example.com", "")
+	}
+
+	assert.Equal(t, 15*time.Minute, f.retryAfter(t, "nobody@example.com", ""), "unknown emails lock like known ones")
+	assert.Empty(t, f.mailer.messages)
+}
+
+func TestLoginThrottleService_IPAddress(t *testing.T) {
+	f := newLoginThrottleFixture()
+
+	// Spraying one password over many accounts trips the per-IP limit.
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com", "d@example.com", "e@example.com", "f@example.com"} {
+		f.now = f.now.Add(time.Minute)
+		f.fail(t, email, "203.0.113.7")
+	}
+	assert.Equal(t, 15*time.Minute, f.retryAfter(t, "g@example.com", "203.0.113.7"))
+	assert.Zero(t, f.failures(models.LoginFailureScopeAccount, "g@example.com"),
+		"an attempt refused for the IP address is not counted against the account")
+	assert.Zero(t, f.retryAfter(t, "g@example.com", "198.51.100.1"))
+
+	require.NoError(t, f.service.RecordSuccess(context.Background(), "g@example.com", "198.51.100.1"))
+	assert.Equal(t, 15*time.Minute, f.retryAfter(t, "g@example.com", "203.0.113.7"),
+		"signing in to an account does not reset the IP address")
+	assert.Zero(t, f.failures(models.LoginFailureScopeIP, "198.51.100.1"), "the successful attempt is uncounted")
+
+	require.NoError(t, f.service.RecordSuccess(context.Background(), "f@example.com", ""))
+	_, err := f.repo.Get(context.Background(), models.LoginFailureScopeAccount, "f@example.com")
+	assert.ErrorIs(t, err, repositories.ErrNotFound, "success clears the account's failures")
+}
+
+func TestAuthService_LoginThrottling(t *testing.T) {
+	f := newLoginThrottleFixture()
+	refreshRepo := &mockRefreshTokenRepository{tokens: make(map[string]*models.RefreshToken)}
+	jwtConfig := &config.JWTConfig{Secret: "test-secret", Issuer: "test-issuer", Audience: "test-audience"}
+	authService := NewAuthService(
+		f.userRepo, refreshRepo, newMockMFARepository(), f.service,
+		noAudit{}, newTestTokenRevocations(), jwtConfig, &config.AuthConfig{},
+	)
+
+	_, err := authService.Register(context.Background(), &models.CreateUserRequest{Email: "user@example.com", Password: "Password1!"})
+	require.NoError(t, err)
+
+	attempt := func(email, password string) error {
+		_, _, err := authService.Login(context.Background(), email, password, models.ClientInfo{})
+		return err
+	}
+
+	for _, email := range []string{"user@example.com", "nobody@example.com"} {
+		err := attempt(email, "wrong")
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrLoginThrottled)
+
+		err = attempt(email, "Password1!")
+		assert.ErrorIs(t, err, ErrLoginThrottled, "the next attempt has to wait, whether or not the email is known")
+	}
+
+	f.now = f.now.Add(time.Second)
+	require.NoError(t, attempt("user@example.com", "Password1!"))
+	_, err = f.repo.Get(context.Background(), models.LoginFailureScopeAccount, "user@example.com")
+	assert.ErrorIs(t, err, repositories.ErrNotFound, "signing in clears the failures")
+}
+
+func TestAuthService_LoginReleasesAttemptOnError(t *testing.T) {
+	f := newLoginThrottleFixture()
+	refreshRepo := &mockRefreshTokenRepository{tokens: make(map[string]*models.RefreshToken)}
+	mfaRepo := newMockMFARepository()
+	jwtConfig := &config.JWTConfig{Secret: "test-secret", Issuer: "test-issuer", Audience: "test-audience"}
+	authService := NewAuthService(
+		f.userRepo, refreshRepo, mfaRepo, f.service,
+		noAudit{}, newTestTokenRevocations(), jwtConfig, &config.AuthConfig{},
+	)
+
+	_, err := authService.Register(context.Background(), &models.CreateUserRequest{Email: "user@example.com", Password: "Password1!"})
+	require.NoError(t, err)
+
+	client := models.ClientInfo{IPAddress: "203.0.113.7"}
+	mfaRepo.getErr = errors.New("database is down")
+	_, _, err = authService.Login(context.Background(), "user@example.com", "Password1!", client)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrLoginThrottled)
+	assert.Zero(t, f.failures(models.LoginFailureScopeAccount, "user@example.com"), "an error is not a failed guess")
+	assert.Zero(t, f.failures(models.LoginFailureScopeIP, "203.0.113.7"))
+
+	mfaRepo.getErr = nil
+	_, _, err = authService.Login(context.Background(), "user@example.com", "Password1!", client)
+	require.NoError(t, err, "the next attempt does not have to wait")
+}
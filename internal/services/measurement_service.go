@@ -0,0 +1,310 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultTrendWindowDays = 7
+	defaultTrendRangeDays  = 90
+	maxTrendRangeDays      = 5 * 366
+)
+
+var (
+	ErrMeasurementNotFound      = errors.New("measurement not found")
+	ErrEmptyMeasurement         = errors.New("measurement must include bodyweight, body fat or a circumference")
+	ErrInvalidMeasurementRange  = errors.New("measurement range must start before it ends and cover at most five years")
+	ErrUnknownMeasurementMetric = errors.New("unknown measurement metric")
+)
+
+type MeasurementService interface {
+	Create(ctx context.Context, userID uuid.UUID, req *models.CreateMeasurementRequest) (*models.Measurement, error)
+	Get(ctx context.Context, userID, measurementID uuid.UUID) (*models.Measurement, error)
+	List(ctx context.Context, userID uuid.UUID, filter models.MeasurementFilter) ([]*models.Measurement, error)
+	Update(
+		ctx context.Context,
+		userID, measurementID uuid.UUID,
+		req *models.UpdateMeasurementRequest,
+	) (*models.Measurement, error)
+	Delete(ctx context.Context, userID, measurementID uuid.UUID) error
+	// Trend returns metric between from and to with a trailing moving average over
+	// windowDays. Zero values select the defaults (last 90 days, 7-day window).
+	Trend(ctx context.Context, userID uuid.UUID, metric string, from, to time.Time, windowDays int) (*models.MeasurementTrend, error)
+}
+
+type measurementService struct {
+	measurementRepo repositories.MeasurementRepository
+	now             func() time.Time
+}
+
+func NewMeasurementService(measurementRepo repositories.MeasurementRepository) MeasurementService {
+	return &measurementService{
+		measurementRepo: measurementRepo,
+		now:             time.Now,
+	}
+}
+
+func hasMeasurementValue(measurement *models.Measurement) bool {
+	return measurement.Bodyweight != nil || measurement.BodyFatPercent != nil || len(measurement.Circumferences) > 0
+}
+
+func (s *measurementService) Create(
+	ctx context.Context,
+	userID uuid.UUID,
+	req *models.CreateMeasurementRequest,
+) (*models.Measurement, error) {
+	now := s.now()
+	measurement := &models.Measurement{
+		ID:             uuid.New(),
+		UserID:         userID,
+		MeasuredAt:     now,
+		Bodyweight:     req.Bodyweight,
+		WeightUnit:     req.WeightUnit,
+		BodyFatPercent: req.BodyFatPercent,
+		Circumferences: req.Circumferences,
+		LengthUnit:     req.LengthUnit,
+		Notes:          req.Notes,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if req.MeasuredAt != nil {
+		measurement.MeasuredAt = *req.MeasuredAt
+	}
+	if measurement.WeightUnit == "" {
+		measurement.WeightUnit = models.WeightUnitKg
+	}
+	if measurement.LengthUnit == "" {
+		measurement.LengthUnit = models.LengthUnitCm
+	}
+	if measurement.Circumferences == nil {
+		measurement.Circumferences = map[string]float64{}
+	}
+
+	if !hasMeasurementValue(measurement) {
+		return nil, ErrEmptyMeasurement
+	}
+
+	if err := s.measurementRepo.Create(ctx, measurement); err != nil {
+		return nil, fmt.Errorf("failed to create measurement: %w", err)
+	}
+
+	return measurement, nil
+}
+
+func (s *measurementService) Get(ctx context.Context, userID, measurementID uuid.UUID) (*models.Measurement, error) {
+	measurement, err := s.measurementRepo.GetByID(ctx, measurementID, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrMeasurementNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get measurement: %w", err)
+	}
+
+	return measurement, nil
+}
+
+func (s *measurementService) List(
+	ctx context.Context,
+	userID uuid.UUID,
+	filter models.MeasurementFilter,
+) ([]*models.Measurement, error) {
+	filter.Pagination = filter.Pagination.Normalize()
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, ErrInvalidMeasurementRange
+	}
+
+	measurements, err := s.measurementRepo.ListByUserID(ctx, userID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list measurements: %w", err)
+	}
+
+	return measurements, nil
+}
+
+func (s *measurementService) Update(
+	ctx context.Context,
+	userID, measurementID uuid.UUID,
+	req *models.UpdateMeasurementRequest,
+) (*models.Measurement, error) {
+	measurement, err := s.Get(ctx, userID, measurementID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.MeasuredAt != nil {
+		measurement.MeasuredAt = *req.MeasuredAt
+	}
+	if req.Bodyweight != nil {
+		measurement.Bodyweight = req.Bodyweight
+	}
+	if req.WeightUnit != nil {
+		measurement.WeightUnit = *req.WeightUnit
+	}
+	if req.BodyFatPercent != nil {
+		measurement.BodyFatPercent = req.BodyFatPercent
+	}
+	if req.Circumferences != nil {
+		measurement.Circumferences = *req.Circumferences
+		if measurement.Circumferences == nil {
+			measurement.Circumferences = map[string]float64{}
+		}
+	}
+	if req.LengthUnit != nil {
+		measurement.LengthUnit = *req.LengthUnit
+	}
+	if req.Notes != nil {
+		measurement.Notes = *req.Notes
+	}
+	measurement.UpdatedAt = s.now()
+
+	if !hasMeasurementValue(measurement) {
+		return nil, ErrEmptyMeasurement
+	}
+
+	err = s.measurementRepo.Update(ctx, measurement)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrMeasurementNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update measurement: %w", err)
+	}
+
+	return measurement, nil
+}
+
+func (s *measurementService) Delete(ctx context.Context, userID, measurementID uuid.UUID) error {
+	err := s.measurementRepo.Delete(ctx, measurementID, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrMeasurementNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete measurement: %w", err)
+	}
+
+	return nil
+}
+
+// metricValue extracts metric from a measurement in kg, percent or cm.
+func metricValue(measurement *models.Measurement, metric string) (float64, bool) {
+	switch metric {
+	case models.MeasurementMetricBodyweight:
+		if measurement.Bodyweight == nil {
+			return 0, false
+		}
+		return models.ConvertWeight(*measurement.Bodyweight, measurement.WeightUnit, models.WeightUnitKg), true
+	case models.MeasurementMetricBodyFat:
+		if measurement.BodyFatPercent == nil {
+			return 0, false
+		}
+		return *measurement.BodyFatPercent, true
+	default:
+		value, ok := measurement.Circumferences[metric]
+		if !ok {
+			return 0, false
+		}
+		return models.ConvertLength(value, measurement.LengthUnit, models.LengthUnitCm), true
+	}
+}
+
+func metricUnit(metric string) (string, error) {
+	switch metric {
+	case models.MeasurementMetricBodyweight:
+		return string(models.WeightUnitKg), nil
+	case models.MeasurementMetricBodyFat:
+		return "%", nil
+	}
+	for _, site := range models.CircumferenceSites {
+		if site == metric {
+			return string(models.LengthUnitCm), nil
+		}
+	}
+	return "", ErrUnknownMeasurementMetric
+}
+
+func roundTrend(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func (s *measurementService) Trend(
+	ctx context.Context,
+	userID uuid.UUID,
+	metric string,
+	from, to time.Time,
+	windowDays int,
+) (*models.MeasurementTrend, error) {
+	unit, err := metricUnit(metric)
+	if err != nil {
+		return nil, err
+	}
+	if windowDays <= 0 {
+		windowDays = defaultTrendWindowDays
+	}
+	if to.IsZero() {
+		to = s.now()
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -defaultTrendRangeDays)
+	}
+	if !from.Before(to) || to.Sub(from) > maxTrendRangeDays*24*time.Hour {
+		return nil, ErrInvalidMeasurementRange
+	}
+
+	// Load one window before from so the first averages are not cut short.
+	window := time.Duration(windowDays) * 24 * time.Hour
+	measurements, err := s.measurementRepo.ListInRange(ctx, userID, from.Add(-window), to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list measurements: %w", err)
+	}
+
+	type sample struct {
+		at    time.Time
+		value float64
+	}
+	samples := make([]sample, 0, len(measurements))
+	for _, measurement := range measurements {
+		if value, ok := metricValue(measurement, metric); ok {
+			samples = append(samples, sample{at: measurement.MeasuredAt, value: value})
+		}
+	}
+
+	trend := &models.MeasurementTrend{
+		Metric:     metric,
+		Unit:       unit,
+		WindowDays: windowDays,
+		Points:     []models.TrendPoint{},
+	}
+
+	// Samples are ordered by time, so the window is a sliding [start, i] range.
+	start := 0
+	sum := 0.0
+	for i, current := range samples {
+		sum += current.value
+		for !samples[start].at.After(current.at.Add(-window)) {
+			sum -= samples[start].value
+			start++
+		}
+		if current.at.Before(from) {
+			continue
+		}
+		trend.Points = append(trend.Points, models.TrendPoint{
+			MeasuredAt:    current.at,
+			Value:         roundTrend(current.value),
+			MovingAverage: roundTrend(sum / float64(i-start+1)),
+		})
+	}
+
+	if len(trend.Points) > 1 {
+		change := roundTrend(trend.Points[len(trend.Points)-1].MovingAverage - trend.Points[0].MovingAverage)
+		trend.Change = &change
+	}
+
+	return trend, nil
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockMeasurementRepository struct {
+	measurements map[uuid.UUID]*models.Measurement
+}
+
+func newMockMeasurementRepository() *mockMeasurementRepository {
+	return &mockMeasurementRepository{
+		measurements: make(map[uuid.UUID]*models.Measurement),
+	}
+}
+
+func (m *mockMeasurementRepository) Create(ctx context.Context, measurement *models.Measurement) error {
+	m.measurements[measurement.ID] = measurement
+	return nil
+}
+
+func (m *mockMeasurementRepository) GetByID(ctx context.Context, id, userID uuid.UUID) (*models.Measurement, error) {
+	measurement, exists := m.measurements[id]
+	if !exists || measurement.UserID != userID {
+		return nil, repositories.ErrNotFound
+	}
+	copied := *measurement
+	return &copied, nil
+}
+
+func (m *mockMeasurementRepository) ListByUserID(
+	ctx context.Context,
+	userID uuid.UUID,
+	filter models.MeasurementFilter,
+) ([]*models.Measurement, error) {
+	var measurements []*models.Measurement
+	for _, measurement := range m.measurements {
+		if measurement.UserID != userID ||
+			(filter.From != nil && measurement.MeasuredAt.Before(*filter.From)) ||
+			(filter.To != nil && !measurement.MeasuredAt.Before(*filter.To)) {
+			continue
+		}
+		measurements = append(measurements, measurement)
+	}
+	sort.Slice(measurements, func(i, j int) bool { return measurements[i].MeasuredAt.After(measurements[j].MeasuredAt) })
+	return measurements, nil
+}
+
+func (m *mockMeasurementRepository) ListInRange(
+	ctx context.Context,
+	userID uuid.UUID,
+	from, to time.Time,
+) ([]*models.Measurement, error) {
+	measurements, _ := m.ListByUserID(ctx, userID, models.MeasurementFilter{From: &from, To: &to})
+	sort.Slice(measurements, func(i, j int) bool { return measurements[i].MeasuredAt.Before(measurements[j].MeasuredAt) })
+	return measurements, nil
+}
+
+func (m *mockMeasurementRepository) Update(ctx context.Context, measurement *models.Measurement) error {
+	existing, exists := m.measurements[measurement.ID]
+	if !exists || existing.UserID != measurement.UserID {
+		return repositories.ErrNotFound
+	}
+	m.measurements[measurement.ID] = measurement
+	return nil
+}
+
+func (m *mockMeasurementRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	measurement, exists := m.measurements[id]
+	if !exists || measurement.UserID != userID {
+		return repositories.ErrNotFound
+	}
+	delete(m.measurements, id)
+	return nil
+}
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
+
+func TestMeasurementService_Create(t *testing.T) {
+	service := NewMeasurementService(newMockMeasurementRepository())
+	userID := uuid.New()
+
+	t.Run("DefaultsUnitsAndTime", func(t *testing.T) {
+		measurement, err := service.Create(context.Background(), userID, &models.CreateMeasurementRequest{
+			Bodyweight: floatPtr(80),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, models.WeightUnitKg, measurement.WeightUnit)
+		assert.Equal(t, models.LengthUnitCm, measurement.LengthUnit)
+		assert.NotNil(t, measurement.Circumferences)
+		assert.WithinDuration(t, time.Now(), measurement.MeasuredAt, time.Second)
+	})
+
+	t.Run("RejectsEmptyMeasurement", func(t *testing.T) {
+		_, err := service.Create(context.Background(), userID, &models.CreateMeasurementRequest{Notes: "nothing"})
+		assert.ErrorIs(t, err, ErrEmptyMeasurement)
+	})
+}
+
+func TestMeasurementService_Update(t *testing.T) {
+	service := NewMeasurementService(newMockMeasurementRepository())
+	userID := uuid.New()
+
+	measurement, err := service.Create(context.Background(), userID, &models.CreateMeasurementRequest{
+		Circumferences: map[string]float64{"waist": 84},
+	})
+	require.NoError(t, err)
+
+	_, err = service.Update(context.Background(), uuid.New(), measurement.ID, &models.UpdateMeasurementRequest{})
+	assert.ErrorIs(t, err, ErrMeasurementNotFound)
+
+	empty := map[string]float64{}
+	_, err = service.Update(context.Background(), userID, measurement.ID, &models.UpdateMeasurementRequest{Circumferences: &empty})
+	assert.ErrorIs(t, err, ErrEmptyMeasurement)
+
+	updated, err := service.Update(context.Background(), userID, measurement.ID, &models.UpdateMeasurementRequest{
+		BodyFatPercent: floatPtr(14.5),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 84.0, updated.Circumferences["waist"])
+	require.NotNil(t, updated.BodyFatPercent)
+	assert.Equal(t, 14.5, *updated.BodyFatPercent)
+}
+
+func TestMeasurementService_Trend(t *testing.T) {
+	repo := newMockMeasurementRepository()
+	service := NewMeasurementService(repo)
+	userID := uuid.New()
+	day := func(n int) time.Time { return time.Date(2025, 3, n, 7, 0, 0, 0, time.UTC) }
+
+	add := func(at time.Time, req models.CreateMeasurementRequest) {
+		req.MeasuredAt = &at
+		_, err := service.Create(context.Background(), userID, &req)
+		require.NoError(t, err)
+	}
+	add(day(1), models.CreateMeasurementRequest{Bodyweight: floatPtr(80)})
+	add(day(2), models.CreateMeasurementRequest{Bodyweight: floatPtr(82)})
+	add(day(3), models.CreateMeasurementRequest{BodyFatPercent: floatPtr(15)})
+	add(day(4), models.CreateMeasurementRequest{Bodyweight: floatPtr(176.37), WeightUnit: models.WeightUnitLb})
+	add(day(10), models.CreateMeasurementRequest{Bodyweight: floatPtr(78)})
+
+	t.Run("MovingAverage", func(t *testing.T) {
+		trend, err := service.Trend(context.Background(), userID, models.MeasurementMetricBodyweight, day(2), day(11), 3)
+		require.NoError(t, err)
+		assert.Equal(t, "kg", trend.Unit)
+
+		require.Len(t, trend.Points, 3)
+		// Day 1 falls before from but still feeds the first window.
+		assert.Equal(t, 82.0, trend.Points[0].Value)
+		assert.Equal(t, 81.0, trend.Points[0].MovingAverage)
+		// 176.37 lb is 80 kg; day 1 has left the three-day window.
+		assert.Equal(t, 80.0, trend.Points[1].Value)
+		assert.Equal(t, 81.0, trend.Points[1].MovingAverage)
+		assert.Equal(t, 78.0, trend.Points[2].MovingAverage)
+		require.NotNil(t, trend.Change)
+		assert.Equal(t, -3.0, *trend.Change)
+	})
+
+	t.Run("CircumferenceInCentimeters", func(t *testing.T) {
+		add(day(5), models.CreateMeasurementRequest{
+			Circumferences: map[string]float64{"waist": 33},
+			LengthUnit:     models.LengthUnitIn,
+		})
+
+		trend, err := service.Trend(context.Background(), userID, "waist", day(1), day(11), 0)
+		require.NoError(t, err)
+		assert.Equal(t, defaultTrendWindowDays, trend.WindowDays)
+		require.Len(t, trend.Points, 1)
+		assert.Equal(t, 83.82, trend.Points[0].Value)
+		assert.Nil(t, trend.Change)
+	})
+
+	t.Run("RejectsUnknownMetric", func(t *testing.T) {
+		_, err := service.Trend(context.Background(), userID, "ankle", time.Time{}, time.Time{}, 0)
+		assert.ErrorIs(t, err, ErrUnknownMeasurementMetric)
+	})
+
+	t.Run("RejectsInvertedRange", func(t *testing.T) {
+		_, err := service.Trend(context.Background(), userID, models.MeasurementMetricBodyFat, day(5), day(1), 0)
+		assert.ErrorIs(t, err, ErrInvalidMeasurementRange)
+	})
+}
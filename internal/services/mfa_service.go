@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/config"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTOTPAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTOTPNotEnrolled    = errors.New("two-factor authentication has not been set up")
+	ErrTOTPNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrInvalidMFACode     = errors.New("invalid two-factor code")
+)
+
+type MFAService interface {
+	// EnrollTOTP creates an authenticator secret for the user. It has no effect
+	// until confirmed; enrolling again replaces an unconfirmed secret.
+	EnrollTOTP(ctx context.Context, userID uuid.UUID) (*models.TOTPEnrollment, error)
+	// ConfirmTOTP turns two-factor authentication on with a first code from the
+	// authenticator. It returns the recovery codes, which are not shown again.
+	ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	// DisableTOTP turns two-factor authentication off. code is a current TOTP
+	// code or an unused recovery code; wrong codes are throttled.
+	DisableTOTP(ctx context.Context, userID uuid.UUID, code string) error
+}
+
+type mfaService struct {
+	userRepo repositories.UserRepository
+	mfaRepo  repositories.MFARepository
+	throttle LoginThrottleService
+	audit    AuditService
+	config   *config.AuthConfig
+	now      func() time.Time
+}
+
+func NewMFAService(
+	userRepo repositories.UserRepository,
+	mfaRepo repositories.MFARepository,
+	throttle LoginThrottleService,
+	audit AuditService,
+	authConfig *config.AuthConfig,
+) MFAService {
+	return &mfaService{
+		userRepo: userRepo,
+		mfaRepo:  mfaRepo,
+		throttle: throttle,
+		audit:    audit,
+		config:   authConfig,
+		now:      time.Now,
+	}
+}
+
+func (s *mfaService) EnrollTOTP(ctx context.Context, userID uuid.UUID) (*models.TOTPEnrollment, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	secret, err := newTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	err = s.mfaRepo.SaveTOTP(ctx, &models.TOTPCredential{
+		UserID:    user.ID,
+		Secret:    secret,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save totp credential: %w", err)
+	}
+	recordSecurityEvent(ctx, s.audit, accountEvent(models.AuditEventMFAEnrolled, user.ID))
+
+	return &models.TOTPEnrollment{
+		Secret: secret,
+		URI:    totpURI(s.config.TOTPIssuer, user.Email, secret),
+	}, nil
+}
+
+func (s *mfaService) ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	credential, err := s.mfaRepo.GetTOTP(ctx, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrTOTPNotEnrolled
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get totp credential: %w", err)
+	}
+	if credential.IsConfirmed() {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+
+	now := s.now()
+	step, ok := matchTOTP(credential.Secret, code, now)
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	recoveryCodes := make([]*models.RecoveryCode, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		recoveryCode, err := newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, recoveryCode)
+		recoveryCodes = append(recoveryCodes, &models.RecoveryCode{
+			ID:        uuid.New(),
+			UserID:    userID,
+			CodeHash:  hashRecoveryCode(recoveryCode),
+			CreatedAt: now,
+		})
+	}
+
+	// Losing a race with a concurrent confirmation leaves the other one's codes in place.
+	err = s.mfaRepo.ConfirmTOTP(ctx, userID, step, recoveryCodes)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to confirm totp credential: %w", err)
+	}
+	recordSecurityEvent(ctx, s.audit, accountEvent(models.AuditEventMFAEnabled, userID))
+
+	return codes, nil
+}
+
+func (s *mfaService) DisableTOTP(ctx context.Context, userID uuid.UUID, code string) error {
+	credential, err := s.mfaRepo.GetTOTP(ctx, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrTOTPNotEnabled
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get totp credential: %w", err)
+	}
+	if !credential.IsConfirmed() {
+		return ErrTOTPNotEnabled
+	}
+
+	// Guessing codes here is throttled like guessing them at sign-in, but in a
+	// count of its own: whoever holds the session is already signed in, and
+	// wrong codes must not lock the owner out of signing in.
+	if err := s.throttle.ReserveStepUp(ctx, userID); err != nil {
+		return err
+	}
+	if err := verifySecondFactor(ctx, s.mfaRepo, credential, code, s.now()); err != nil {
+		if !errors.Is(err, ErrInvalidMFACode) {
+			if err := s.throttle.ReleaseStepUp(ctx, userID); err != nil {
+				return fmt.Errorf("failed to release step-up attempt: %w", err)
+			}
+		}
+		return err
+	}
+	if err := s.throttle.RecordStepUpSuccess(ctx, userID); err != nil {
+		return fmt.Errorf("failed to reset step-up failures: %w", err)
+	}
+	if !isTOTPCode(code) {
+		recordSecurityEvent(ctx, s.audit, accountEvent(models.AuditEventRecoveryCodeUsed, userID))
+	}
+
+	if err := s.mfaRepo.DeleteTOTP(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete totp credential: %w", err)
+	}
+	recordSecurityEvent(ctx, s.audit, accountEvent(models.AuditEventMFADisabled, userID))
+
+	return nil
+}
+
+// verifySecondFactor checks code against a confirmed credential and consumes
+// it: a TOTP code cannot be used twice, nor can a recovery code.
+func verifySecondFactor(
+	ctx context.Context,
+	mfaRepo repositories.MFARepository,
+	credential *models.TOTPCredential,
+	code string,
+	now time.Time,
+) error {
+	if isTOTPCode(code) {
+		step, ok := matchTOTP(credential.Secret, code, now)
+		if !ok || step <= credential.LastUsedStep {
+			return ErrInvalidMFACode
+		}
+		err := mfaRepo.UseTOTPStep(ctx, credential.UserID, step)
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrInvalidMFACode
+		}
+		if err != nil {
+			return fmt.Errorf("failed to record totp code: %w", err)
+		}
+		return nil
+	}
+
+	err := mfaRepo.UseRecoveryCode(ctx, credential.UserID, hashRecoveryCode(code))
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrInvalidMFACode
+	}
+	if err != nil {
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	return nil
+}
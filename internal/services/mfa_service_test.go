@@ -0,0 +1,352 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/config"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockMFARepository struct {
+	credentials   map[uuid.UUID]*models.TOTPCredential
+	recoveryCodes map[uuid.UUID][]*models.RecoveryCode
+	// getErr, when set, is returned when the credential is read.
+	getErr error
+	// useErr, when set, is returned when a code is used.
+	useErr error
+}
+
+func newMockMFARepository() *mockMFARepository {
+	return &mockMFARepository{
+		credentials:   make(map[uuid.UUID]*models.TOTPCredential),
+		recoveryCodes: make(map[uuid.UUID][]*models.RecoveryCode),
+	}
+}
+
+func (m *mockMFARepository) GetTOTP(ctx context.Context, userID uuid.UUID) (*models.TOTPCredential, error) {
+	if m.getErr != nil {
+		return nil, m.getErr
+	}
+	credential, exists := m.credentials[userID]
+	if !exists {
+		return nil, repositories.ErrNotFound
+	}
+	copied := *credential
+	return &copied, nil
+}
+
+func (m *mockMFARepository) SaveTOTP(ctx context.Context, credential *models.TOTPCredential) error {
+	if existing, exists := m.credentials[credential.UserID]; exists && existing.IsConfirmed() {
+		return repositories.ErrNotFound
+	}
+	m.credentials[credential.UserID] = credential
+	return nil
+}
+
+func (m *mockMFARepository) ConfirmTOTP(ctx context.Context, userID uuid.UUID, step int64, codes []*models.RecoveryCode) error {
+	credential, exists := m.credentials[userID]
+	if !exists || credential.IsConfirmed() {
+		return repositories.ErrNotFound
+	}
+	now := time.Now()
+	credential.ConfirmedAt = &now
+	credential.LastUsedStep = step
+	m.recoveryCodes[userID] = codes
+	return nil
+}
+
+func (m *mockMFARepository) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	if m.useErr != nil {
+		return m.useErr
+	}
+	credential, exists := m.credentials[userID]
+	if !exists || credential.LastUsedStep >= step {
+		return repositories.ErrNotFound
+	}
+	credential.LastUsedStep = step
+	return nil
+}
+
+func (m *mockMFARepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	if m.useErr != nil {
+		return m.useErr
+	}
+	for _, code := range m.recoveryCodes[userID] {
+		if code.CodeHash == codeHash && code.UsedAt == nil {
+			now := time.Now()
+			code.UsedAt = &now
+			return nil
+		}
+	}
+	return repositories.ErrNotFound
+}
+
+func (m *mockMFARepository) DeleteTOTP(ctx context.Context, userID uuid.UUID) error {
+	delete(m.credentials, userID)
+	delete(m.recoveryCodes, userID)
+	return nil
+}
+
+func totpCodeAt(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+	key, err := totpEncoding.DecodeString(secret)
+	require.NoError(t, err)
+	return hotp(key, uint64(totpStep(at)), totpDigits)
+}
+
+func TestTOTP_RFC6238Vectors(t *testing.T) {
+	// RFC 6238 Appendix B, SHA1 mode.
+	key := []byte("12345678901234567890")
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.code, hotp(key, uint64(totpStep(time.Unix(tt.unix, 0))), 8), "T=%d", tt.unix)
+	}
+}
+
+func TestTOTP_Match(t *testing.T) {
+	secret := totpEncoding.EncodeToString([]byte("12345678901234567890"))
+	now := time.Unix(1111111111, 0)
+	code := totpCodeAt(t, secret, now)
+
+	step, ok := matchTOTP(secret, code, now)
+	assert.True(t, ok)
+	assert.Equal(t, totpStep(now), step)
+
+	_, ok = matchTOTP(secret, code, now.Add(totpPeriod*time.Second))
+	assert.True(t, ok, "a code from the previous step is still accepted")
+	_, ok = matchTOTP(secret, code, now.Add(2*totpPeriod*time.Second))
+	assert.False(t, ok, "codes outside the skew window are rejected")
+	_, ok = matchTOTP(secret, "000000", now)
+	assert.False(t, ok)
+}
+
+type mfaFixture struct {
+	authService AuthService
+	mfaService  *mfaService
+	mfaRepo     *mockMFARepository
+	auditRepo   *mockAuditRepository
+	user        *models.User
+}
+
+func newMFAFixture(t *testing.T) *mfaFixture {
+	t.Helper()
+	userRepo := &mockUserRepository{users: make(map[string]*models.User)}
+	refreshRepo := &mockRefreshTokenRepository{tokens: make(map[string]*models.RefreshToken)}
+	mfaRepo := newMockMFARepository()
+	auditRepo := &mockAuditRepository{}
+	jwtConfig := &config.JWTConfig{Secret: "test-secret", Issuer: "test-issuer", Audience: "test-audience"}
+	authConfig := &config.AuthConfig{TOTPIssuer: "Strive"}
+
+	authService := NewAuthService(
+		userRepo, refreshRepo, mfaRepo, noLoginThrottle{},
+		NewAuditService(auditRepo), newTestTokenRevocations(), jwtConfig, authConfig,
+	)
+	user, err := authService.Register(context.Background(), &models.CreateUserRequest{
+		Email:    "user@example.com",
+		Password: "Password1!",
+	})
+	require.NoError(t, err)
+
+	return &mfaFixture{
+		authService: authService,
+		mfaService:  NewMFAService(userRepo, mfaRepo, noLoginThrottle{}, NewAuditService(auditRepo), authConfig).(*mfaService),
+		mfaRepo:     mfaRepo,
+		auditRepo:   auditRepo,
+		user:        user,
+	}
+}
+
+// enable turns two-factor authentication on with a code from the previous time
+// step, so the current step's code is still unused afterwards.
+func (f *mfaFixture) enable(t *testing.T) (string, []string) {
+	t.Helper()
+	enrollment, err := f.mfaService.EnrollTOTP(context.Background(), f.user.ID)
+	require.NoError(t, err)
+
+	earlier := time.Now().Add(-totpPeriod * time.Second)
+	f.mfaService.now = func() time.Time { return earlier }
+	defer func() { f.mfaService.now = time.Now }()
+
+	codes, err := f.mfaService.ConfirmTOTP(context.Background(), f.user.ID, totpCodeAt(t, enrollment.Secret, earlier))
+	require.NoError(t, err)
+	return enrollment.Secret, codes
+}
+
+func TestMFAService_Enrollment(t *testing.T) {
+	f := newMFAFixture(t)
+
+	enrollment, err := f.mfaService.EnrollTOTP(context.Background(), f.user.ID)
+	require.NoError(t, err)
+
+	uri, err := url.Parse(enrollment.URI)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/Strive:user@example.com", uri.Path)
+	assert.Equal(t, enrollment.Secret, uri.Query().Get("secret"))
+	assert.Equal(t, "Strive", uri.Query().Get("issuer"))
+
+	_, _, err = f.authService.Login(context.Background(), "user@example.com", "Password1!", models.ClientInfo{})
+	assert.NoError(t, err, "an unconfirmed enrollment does not affect sign-in")
+
+	_, err = f.mfaService.ConfirmTOTP(context.Background(), f.user.ID, "000000")
+	assert.ErrorIs(t, err, ErrInvalidMFACode)
+
+	codes, err := f.mfaService.ConfirmTOTP(context.Background(), f.user.ID, totpCodeAt(t, enrollment.Secret, time.Now()))
+	require.NoError(t, err)
+	assert.Len(t, codes, recoveryCodeCount)
+	for _, code := range f.mfaRepo.recoveryCodes[f.user.ID] {
+		assert.Len(t, code.CodeHash, 64, "recovery codes are stored hashed")
+		assert.NotContains(t, codes, code.CodeHash)
+	}
+
+	_, err = f.mfaService.EnrollTOTP(context.Background(), f.user.ID)
+	assert.ErrorIs(t, err, ErrTOTPAlreadyEnabled, "an enabled secret cannot be replaced")
+	_, err = f.mfaService.ConfirmTOTP(context.Background(), f.user.ID, totpCodeAt(t, enrollment.Secret, time.Now()))
+	assert.ErrorIs(t, err, ErrTOTPAlreadyEnabled)
+
+	assert.Equal(t, []string{models.AuditEventMFAEnrolled, models.AuditEventLogin, models.AuditEventMFAEnabled}, f.auditRepo.types())
+}
+
+func TestMFAService_Disable(t *testing.T) {
+	t.Run("RequiresCode", func(t *testing.T) {
+		f := newMFAFixture(t)
+		secret, _ := f.enable(t)
+
+		err := f.mfaService.DisableTOTP(context.Background(), f.user.ID, "000000")
+		assert.ErrorIs(t, err, ErrInvalidMFACode)
+		err = f.mfaService.DisableTOTP(context.Background(), f.user.ID, "aaaaa-bbbbb")
+		assert.ErrorIs(t, err, ErrInvalidMFACode)
+
+		require.NoError(t, f.mfaService.DisableTOTP(context.Background(), f.user.ID, totpCodeAt(t, secret, time.Now())))
+		assert.Empty(t, f.mfaRepo.credentials)
+		assert.Empty(t, f.mfaRepo.recoveryCodes)
+		assert.Equal(t, []string{models.AuditEventMFAEnrolled, models.AuditEventMFAEnabled, models.AuditEventMFADisabled},
+			f.auditRepo.types())
+
+		err = f.mfaService.DisableTOTP(context.Background(), f.user.ID, totpCodeAt(t, secret, time.Now()))
+		assert.ErrorIs(t, err, ErrTOTPNotEnabled)
+	})
+
+	t.Run("AcceptsRecoveryCode", func(t *testing.T) {
+		f := newMFAFixture(t)
+		_, codes := f.enable(t)
+
+		require.NoError(t, f.mfaService.DisableTOTP(context.Background(), f.user.ID, " "+strings.ToUpper(codes[0])))
+		assert.Empty(t, f.mfaRepo.credentials)
+		assert.Contains(t, f.auditRepo.types(), models.AuditEventRecoveryCodeUsed)
+	})
+
+	t.Run("Throttled", func(t *testing.T) {
+		f := newMFAFixture(t)
+		throttle := newLoginThrottleFixture()
+		f.mfaService.throttle = throttle.service
+		_, codes := f.enable(t)
+
+		err := f.mfaService.DisableTOTP(context.Background(), f.user.ID, "aaaaa-bbbbb")
+		assert.ErrorIs(t, err, ErrInvalidMFACode)
+		err = f.mfaService.DisableTOTP(context.Background(), f.user.ID, codes[0])
+		assert.ErrorIs(t, err, ErrLoginThrottled, "wrong codes are throttled like failed sign-ins")
+		assert.Len(t, f.mfaRepo.credentials, 1)
+		assert.Equal(t, 1, throttle.failures(models.LoginFailureScopeStepUp, f.user.ID.String()))
+		assert.Zero(t, throttle.failures(models.LoginFailureScopeAccount, f.user.Email),
+			"wrong codes from a session do not count against signing in")
+
+		throttle.now = throttle.now.Add(time.Second)
+		require.NoError(t, f.mfaService.DisableTOTP(context.Background(), f.user.ID, codes[0]))
+		assert.Zero(t, throttle.failures(models.LoginFailureScopeStepUp, f.user.ID.String()), "the right code clears the count")
+	})
+
+	t.Run("ReleasedOnOtherErrors", func(t *testing.T) {
+		f := newMFAFixture(t)
+		throttle := newLoginThrottleFixture()
+		f.mfaService.throttle = throttle.service
+		_, codes := f.enable(t)
+		f.mfaRepo.useErr = errors.New("database is down")
+
+		err := f.mfaService.DisableTOTP(context.Background(), f.user.ID, codes[0])
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrInvalidMFACode)
+		assert.Zero(t, throttle.failures(models.LoginFailureScopeStepUp, f.user.ID.String()), "a failure to check the code is not a wrong code")
+	})
+}
+
+func TestAuthService_MFALogin(t *testing.T) {
+	login := func(t *testing.T, f *mfaFixture) string {
+		t.Helper()
+		_, _, err := f.authService.Login(context.Background(), "user@example.com", "Password1!", models.ClientInfo{})
+		var challenge *MFAChallengeError
+		require.ErrorAs(t, err, &challenge)
+		assert.ErrorIs(t, err, ErrMFARequired)
+		assert.Equal(t, mfaChallengeTTL, challenge.ExpiresIn)
+		return challenge.Token
+	}
+
+	t.Run("TOTPCode", func(t *testing.T) {
+		f := newMFAFixture(t)
+		secret, _ := f.enable(t)
+		challenge := login(t, f)
+
+		_, err := f.authService.ValidateToken(challenge)
+		assert.Error(t, err, "a challenge token is not an access token")
+
+		code := totpCodeAt(t, secret, time.Now())
+		accessToken, refreshToken, err := f.authService.CompleteMFALogin(context.Background(), challenge, code, models.ClientInfo{})
+		require.NoError(t, err)
+		assert.NotEmpty(t, refreshToken)
+		claims, err := f.authService.ValidateToken(accessToken)
+		require.NoError(t, err)
+		assert.Equal(t, f.user.ID, claims.UserID)
+
+		_, _, err = f.authService.CompleteMFALogin(context.Background(), login(t, f), code, models.ClientInfo{})
+		assert.ErrorIs(t, err, ErrInvalidMFACode, "a code cannot be replayed")
+	})
+
+	t.Run("RecoveryCode", func(t *testing.T) {
+		f := newMFAFixture(t)
+		_, codes := f.enable(t)
+
+		_, _, err := f.authService.CompleteMFALogin(context.Background(), login(t, f), codes[1], models.ClientInfo{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{models.AuditEventRecoveryCodeUsed, models.AuditEventLogin}, f.auditRepo.types()[2:])
+
+		_, _, err = f.authService.CompleteMFALogin(context.Background(), login(t, f), codes[1], models.ClientInfo{})
+		assert.ErrorIs(t, err, ErrInvalidMFACode, "recovery codes are single-use")
+	})
+
+	t.Run("InvalidChallenge", func(t *testing.T) {
+		f := newMFAFixture(t)
+		secret, _ := f.enable(t)
+		code := totpCodeAt(t, secret, time.Now())
+
+		accessToken, err := f.authService.IssueAccessToken(f.user)
+		require.NoError(t, err)
+		_, _, err = f.authService.CompleteMFALogin(context.Background(), accessToken, code, models.ClientInfo{})
+		assert.ErrorIs(t, err, ErrInvalidMFAChallenge, "an access token is not a challenge token")
+
+		challenge := login(t, f)
+		require.NoError(t, f.mfaRepo.DeleteTOTP(context.Background(), f.user.ID))
+		_, _, err = f.authService.CompleteMFALogin(context.Background(), challenge, code, models.ClientInfo{})
+		assert.ErrorIs(t, err, ErrInvalidMFAChallenge)
+	})
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/config"
+	"github.com/aleksandr/strive-api/internal/mail"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidResetToken = errors.New("password reset token is invalid or expired")
+
+type PasswordResetService interface {
+	// RequestReset emails a reset link if email belongs to an account. It
+	// reports success for unknown addresses so callers cannot probe for users.
+	RequestReset(ctx context.Context, email string) error
+	// ResetPassword consumes token, sets the new password and signs the user
+	// out of every session, including outstanding access tokens.
+	ResetPassword(ctx context.Context, token, newPassword string) error
+}
+
+type passwordResetService struct {
+	userRepo         repositories.UserRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+	resetRepo        repositories.PasswordResetRepository
+	mailer           mail.Sender
+	audit            AuditService
+	revocations      TokenRevocationService
+	config           *config.AuthConfig
+	now              func() time.Time
+}
+
+func NewPasswordResetService(
+	userRepo repositories.UserRepository,
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	resetRepo repositories.PasswordResetRepository,
+	mailer mail.Sender,
+	audit AuditService,
+	revocations TokenRevocationService,
+	authConfig *config.AuthConfig,
+) PasswordResetService {
+	return &passwordResetService{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		resetRepo:        resetRepo,
+		mailer:           mailer,
+		audit:            audit,
+		revocations:      revocations,
+		config:           authConfig,
+		now:              time.Now,
+	}
+}
+
+func (s *passwordResetService) RequestReset(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetByEmail(ctx, normalizeEmail(email))
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// Only the most recent link should work.
+	if err := s.resetRepo.DeleteUnusedByUserID(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to invalidate previous reset tokens: %w", err)
+	}
+
+	token, err := newOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	resetToken := &models.PasswordResetToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		TokenHash: hashOpaqueToken(token),
+		ExpiresAt: now.Add(s.config.PasswordResetTTL),
+		CreatedAt: now,
+	}
+	if err := s.resetRepo.Create(ctx, resetToken); err != nil {
+		return fmt.Errorf("failed to create reset token: %w", err)
+	}
+
+	link := s.config.AppBaseURL + "/reset-password?token=" + url.QueryEscape(token)
+	err = s.mailer.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "Reset your Strive password",
+		Body: fmt.Sprintf(
+			"Someone asked to reset the password for your Strive account.\n\n"+
+				"Open this link within %s to choose a new password:\n%s\n\n"+
+				"If this wasn't you, ignore this email; your password will not change.\n",
+			s.config.PasswordResetTTL, link,
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send reset email: %w", err)
+	}
+
+	return nil
+}
+
+func (s *passwordResetService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	resetToken, err := s.resetRepo.GetValidByHash(ctx, hashOpaqueToken(token))
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get reset token: %w", err)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, resetToken.UserID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	hashedPassword, err := hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	// Claim the token before changing anything so concurrent resets cannot both succeed.
+	err = s.resetRepo.MarkUsed(ctx, resetToken.ID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to consume reset token: %w", err)
+	}
+
+	user.PasswordHash = hashedPassword
+	user.UpdatedAt = s.now()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	// Access tokens carry whole-second iat claims, so the cutoff is truncated to
+	// avoid rejecting tokens issued in the same second after the reset.
+	if err := s.revocations.RevokeUser(ctx, user.ID, s.now().Truncate(time.Second)); err != nil {
+		return fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+
+	if err := s.refreshTokenRepo.DeleteByUserID(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	recordSecurityEvent(ctx, s.audit, accountEvent(models.AuditEventPasswordReset, user.ID))
+	return nil
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/config"
+	"github.com/aleksandr/strive-api/internal/mail"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPasswordResetRepository struct {
+	tokens map[uuid.UUID]*models.PasswordResetToken
+	now    func() time.Time
+}
+
+func newMockPasswordResetRepository() *mockPasswordResetRepository {
+	return &mockPasswordResetRepository{
+		tokens: make(map[uuid.UUID]*models.PasswordResetToken),
+		now:    time.Now,
+	}
+}
+
+func (m *mockPasswordResetRepository) Create(ctx context.Context, token *models.PasswordResetToken) error {
+	m.tokens[token.ID] = token
+	return nil
+}
+
+func (m *mockPasswordResetRepository) GetValidByHash(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	for _, token := range m.tokens {
+		if token.TokenHash == tokenHash && token.UsedAt == nil && token.ExpiresAt.After(m.now()) {
+			copied := *token
+			return &copied, nil
+		}
+	}
+	return nil, repositories.ErrNotFound
+}
+
+func (m *mockPasswordResetRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	token, exists := m.tokens[id]
+	if !exists || token.UsedAt != nil {
+		return repositories.ErrNotFound
+	}
+	now := m.now()
+	token.UsedAt = &now
+	return nil
+}
+
+func (m *mockPasswordResetRepository) DeleteUnusedByUserID(ctx context.Context, userID uuid.UUID) error {
+	for id, token := range m.tokens {
+		if token.UserID == userID && token.UsedAt == nil {
+			delete(m.tokens, id)
+		}
+	}
+	return nil
+}
+
+type recordingSender struct {
+	messages []mail.Message
+}
+
+func (s *recordingSender) Send(ctx context.Context, msg mail.Message) error {
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+var linkTokenPattern = regexp.MustCompile(`token=([0-9a-f]+)`)
+
+// linkToken extracts the token from the link in the most recent message.
+func (s *recordingSender) linkToken(t *testing.T) string {
+	t.Helper()
+	require.NotEmpty(t, s.messages)
+	match := linkTokenPattern.FindStringSubmatch(s.messages[len(s.messages)-1].Body)
+	require.Len(t, match, 2)
+	token, err := url.QueryUnescape(match[1])
+	require.NoError(t, err)
+	return token
+}
+
+type passwordResetFixture struct {
+	service      PasswordResetService
+	userRepo     *mockUserRepository
+	refreshRepo  *mockRefreshTokenRepository
+	resetRepo    *mockPasswordResetRepository
+	mailer       *recordingSender
+	auditRepo    *mockAuditRepository
+	revocations  TokenRevocationService
+	user         *models.User
+	originalHash string
+}
+
+func newPasswordResetFixture(t *testing.T) *passwordResetFixture {
+	t.Helper()
+
+	hash, err := hashPassword("OldPassword1!")
+	require.NoError(t, err)
+	user := &models.User{ID: uuid.New(), Email: "user@example.com", PasswordHash: hash}
+
+	f := &passwordResetFixture{
+		userRepo:     &mockUserRepository{users: map[string]*models.User{user.Email: user}},
+		refreshRepo:  &mockRefreshTokenRepository{tokens: make(map[string]*models.RefreshToken)},
+		resetRepo:    newMockPasswordResetRepository(),
+		mailer:       &recordingSender{},
+		auditRepo:    &mockAuditRepository{},
+		revocations:  newTestTokenRevocations(),
+		user:         user,
+		originalHash: hash,
+	}
+	f.refreshRepo.tokens[hashOpaqueToken("session")] = &models.RefreshToken{
+		ID: uuid.New(), UserID: user.ID, TokenHash: hashOpaqueToken("session"), ExpiresAt: time.Now().Add(time.Hour),
+	}
+	f.service = NewPasswordResetService(
+		f.userRepo, f.refreshRepo, f.resetRepo, f.mailer, NewAuditService(f.auditRepo), f.revocations, &config.AuthConfig{
+			AppBaseURL:       "https://app.example.com",
+			PasswordResetTTL: time.Hour,
+		},
+	)
+	return f
+}
+
+func TestPasswordResetService_RequestReset(t *testing.T) {
+	t.Run("SendsLinkAndStoresDigest", func(t *testing.T) {
+		f := newPasswordResetFixture(t)
+
+		require.NoError(t, f.service.RequestReset(context.Background(), " User@Example.com "))
+
+		require.Len(t, f.mailer.messages, 1)
+		assert.Equal(t, "user@example.com", f.mailer.messages[0].To)
+		assert.Contains(t, f.mailer.messages[0].Body, "https://app.example.com/reset-password?token=")
+
+		token := f.mailer.linkToken(t)
+		require.Len(t, f.resetRepo.tokens, 1)
+		for _, stored := range f.resetRepo.tokens {
+			assert.NotEqual(t, token, stored.TokenHash)
+			assert.Equal(t, hashOpaqueToken(token), stored.TokenHash)
+		}
+	})
+
+	t.Run("UnknownEmailIsSilent", func(t *testing.T) {
+		f := newPasswordResetFixture(t)
+
+		require.NoError(t, f.service.RequestReset(context.Background(), "nobody@example.com"))
+		assert.Empty(t, f.mailer.messages)
+		assert.Empty(t, f.resetRepo.tokens)
+	})
+
+	t.Run("NewRequestInvalidatesPreviousLink", func(t *testing.T) {
+		f := newPasswordResetFixture(t)
+
+		require.NoError(t, f.service.RequestReset(context.Background(), "user@example.com"))
+		first := f.mailer.linkToken(t)
+		require.NoError(t, f.service.RequestReset(context.Background(), "user@example.com"))
+
+		err := f.service.ResetPassword(context.Background(), first, "NewPassword2@")
+		assert.ErrorIs(t, err, ErrInvalidResetToken)
+	})
+}
+
+func TestPasswordResetService_ResetPassword(t *testing.T) {
+	t.Run("ChangesPasswordAndWipesSessions", func(t *testing.T) {
+		f := newPasswordResetFixture(t)
+		require.NoError(t, f.service.RequestReset(context.Background(), "user@example.com"))
+		token := f.mailer.linkToken(t)
+
+		require.NoError(t, f.service.ResetPassword(context.Background(), token, "NewPassword2@"))
+
+		assert.NotEqual(t, f.originalHash, f.user.PasswordHash)
+		assert.Empty(t, f.refreshRepo.tokens)
+		assertTokensCutOff(t, f.revocations, f.user.ID)
+		require.Len(t, f.auditRepo.events, 1)
+		assert.Equal(t, models.AuditEventPasswordReset, f.auditRepo.events[0].Type)
+		assert.Equal(t, f.user.ID, *f.auditRepo.events[0].TargetUserID)
+
+		err := f.service.ResetPassword(context.Background(), token, "AnotherPassword3#")
+		assert.ErrorIs(t, err, ErrInvalidResetToken, "tokens are single-use")
+	})
+
+	t.Run("RejectsExpiredToken", func(t *testing.T) {
+		f := newPasswordResetFixture(t)
+		require.NoError(t, f.service.RequestReset(context.Background(), "user@example.com"))
+		token := f.mailer.linkToken(t)
+		f.resetRepo.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+		err := f.service.ResetPassword(context.Background(), token, "NewPassword2@")
+		assert.ErrorIs(t, err, ErrInvalidResetToken)
+		assert.Equal(t, f.originalHash, f.user.PasswordHash)
+		assert.Len(t, f.refreshRepo.tokens, 1)
+	})
+
+	t.Run("RejectsUnknownToken", func(t *testing.T) {
+		f := newPasswordResetFixture(t)
+
+		err := f.service.ResetPassword(context.Background(), strings.Repeat("a", 64), "NewPassword2@")
+		assert.ErrorIs(t, err, ErrInvalidResetToken)
+	})
+}
@@ -0,0 +1,493 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultDeloadPercent = 60.0
+	defaultLoadRounding  = 2.5
+)
+
+var (
+	ErrProgramNotFound        = errors.New("program not found")
+	ErrProgramNotEditable     = errors.New("only the program author can modify it")
+	ErrInvalidProgramSchedule = errors.New("program days must fall within the program's weeks and days per week, without duplicates")
+	ErrEnrollmentNotFound     = errors.New("no active program enrollment")
+	ErrAlreadyEnrolled        = errors.New("already enrolled in a program")
+	ErrEnrollmentChanged      = errors.New("enrollment changed, please retry")
+)
+
+type ProgramService interface {
+	Create(ctx context.Context, userID uuid.UUID, req *models.CreateProgramRequest) (*models.Program, error)
+	Get(ctx context.Context, userID, programID uuid.UUID) (*models.Program, error)
+	List(ctx context.Context, userID uuid.UUID, page models.Pagination) ([]*models.Program, error)
+	Update(ctx context.Context, userID, programID uuid.UUID, req *models.UpdateProgramRequest) (*models.Program, error)
+	Delete(ctx context.Context, userID, programID uuid.UUID) error
+
+	Enroll(ctx context.Context, userID, programID uuid.UUID, req *models.EnrollRequest) (*models.Enrollment, error)
+	CurrentEnrollment(ctx context.Context, userID uuid.UUID) (*models.Enrollment, error)
+	Unenroll(ctx context.Context, userID uuid.UUID) error
+	Today(ctx context.Context, userID uuid.UUID) (*models.ScheduledWorkout, error)
+	StartToday(ctx context.Context, userID uuid.UUID) (*models.Workout, error)
+}
+
+type programService struct {
+	programRepo    repositories.ProgramRepository
+	enrollmentRepo repositories.EnrollmentRepository
+	templateRepo   repositories.TemplateRepository
+	workoutRepo    repositories.WorkoutRepository
+	catalogRepo    repositories.ExerciseRepository
+}
+
+func NewProgramService(
+	programRepo repositories.ProgramRepository,
+	enrollmentRepo repositories.EnrollmentRepository,
+	templateRepo repositories.TemplateRepository,
+	workoutRepo repositories.WorkoutRepository,
+	catalogRepo repositories.ExerciseRepository,
+) ProgramService {
+	return &programService{
+		programRepo:    programRepo,
+		enrollmentRepo: enrollmentRepo,
+		templateRepo:   templateRepo,
+		workoutRepo:    workoutRepo,
+		catalogRepo:    catalogRepo,
+	}
+}
+
+// buildDays checks that every day fits the program shape and references one of
+// the author's templates, and returns the days in schedule order.
+func (s *programService) buildDays(
+	ctx context.Context,
+	program *models.Program,
+	inputs []models.ProgramDayInput,
+) ([]*models.ProgramDay, error) {
+	type slot struct{ week, day int }
+	seen := make(map[slot]bool, len(inputs))
+	checked := make(map[uuid.UUID]bool)
+
+	days := make([]*models.ProgramDay, 0, len(inputs))
+	for _, input := range inputs {
+		key := slot{input.Week, input.Day}
+		if input.Week < 1 || input.Week > program.Weeks || input.Day < 1 || input.Day > program.DaysPerWeek || seen[key] {
+			return nil, ErrInvalidProgramSchedule
+		}
+		seen[key] = true
+
+		if !checked[input.TemplateID] {
+			_, err := s.templateRepo.GetByID(ctx, input.TemplateID, program.UserID)
+			if errors.Is(err, repositories.ErrNotFound) {
+				return nil, ErrTemplateNotFound
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get template: %w", err)
+			}
+			checked[input.TemplateID] = true
+		}
+
+		days = append(days, &models.ProgramDay{
+			ID:               uuid.New(),
+			ProgramID:        program.ID,
+			Week:             input.Week,
+			Day:              input.Day,
+			TemplateID:       input.TemplateID,
+			IntensityPercent: input.IntensityPercent,
+		})
+	}
+
+	sortProgramDays(days)
+	return days, nil
+}
+
+func sortProgramDays(days []*models.ProgramDay) {
+	sort.Slice(days, func(i, j int) bool {
+		if days[i].Week != days[j].Week {
+			return days[i].Week < days[j].Week
+		}
+		return days[i].Day < days[j].Day
+	})
+}
+
+func (s *programService) Create(ctx context.Context, userID uuid.UUID, req *models.CreateProgramRequest) (*models.Program, error) {
+	now := time.Now()
+	program := &models.Program{
+		ID:            uuid.New(),
+		UserID:        userID,
+		Name:          req.Name,
+		Description:   req.Description,
+		Weeks:         req.Weeks,
+		DaysPerWeek:   req.DaysPerWeek,
+		IsPublic:      req.IsPublic,
+		Progression:   req.Progression,
+		Increment:     req.Increment,
+		IncrementUnit: req.IncrementUnit,
+		DeloadWeeks:   req.DeloadWeeks,
+		DeloadPercent: defaultDeloadPercent,
+		LoadRounding:  defaultLoadRounding,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if program.IncrementUnit == "" {
+		program.IncrementUnit = models.WeightUnitKg
+	}
+	if program.Progression == "" {
+		program.Progression = models.ProgressionNone
+	}
+	if program.DeloadWeeks == nil {
+		program.DeloadWeeks = []int{}
+	}
+	if req.DeloadPercent != nil {
+		program.DeloadPercent = *req.DeloadPercent
+	}
+	if req.LoadRounding != nil {
+		program.LoadRounding = *req.LoadRounding
+	}
+
+	days, err := s.buildDays(ctx, program, req.Days)
+	if err != nil {
+		return nil, err
+	}
+	program.Days = days
+
+	if err := s.programRepo.Create(ctx, program); err != nil {
+		return nil, fmt.Errorf("failed to create program: %w", err)
+	}
+
+	return program, nil
+}
+
+func (s *programService) Get(ctx context.Context, userID, programID uuid.UUID) (*models.Program, error) {
+	program, err := s.programRepo.GetByID(ctx, programID, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrProgramNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get program: %w", err)
+	}
+
+	return program, nil
+}
+
+func (s *programService) List(ctx context.Context, userID uuid.UUID, page models.Pagination) ([]*models.Program, error) {
+	page = page.Normalize()
+
+	programs, err := s.programRepo.List(ctx, userID, page.Limit, page.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list programs: %w", err)
+	}
+
+	return programs, nil
+}
+
+func (s *programService) getOwned(ctx context.Context, userID, programID uuid.UUID) (*models.Program, error) {
+	program, err := s.Get(ctx, userID, programID)
+	if err != nil {
+		return nil, err
+	}
+	if program.UserID != userID {
+		return nil, ErrProgramNotEditable
+	}
+	return program, nil
+}
+
+func (s *programService) Update(
+	ctx context.Context,
+	userID, programID uuid.UUID,
+	req *models.UpdateProgramRequest,
+) (*models.Program, error) {
+	program, err := s.getOwned(ctx, userID, programID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		program.Name = *req.Name
+	}
+	if req.Description != nil {
+		program.Description = *req.Description
+	}
+	if req.Weeks != nil {
+		program.Weeks = *req.Weeks
+	}
+	if req.DaysPerWeek != nil {
+		program.DaysPerWeek = *req.DaysPerWeek
+	}
+	if req.IsPublic != nil {
+		program.IsPublic = *req.IsPublic
+	}
+	if req.Progression != nil {
+		program.Progression = *req.Progression
+	}
+	if req.Increment != nil {
+		program.Increment = *req.Increment
+	}
+	if req.IncrementUnit != nil && *req.IncrementUnit != "" {
+		program.IncrementUnit = *req.IncrementUnit
+	}
+	if req.DeloadWeeks != nil {
+		program.DeloadWeeks = *req.DeloadWeeks
+	}
+	if req.DeloadPercent != nil {
+		program.DeloadPercent = *req.DeloadPercent
+	}
+	if req.LoadRounding != nil {
+		program.LoadRounding = *req.LoadRounding
+	}
+
+	// Re-check the existing schedule too, since weeks or days per week may have shrunk.
+	inputs := make([]models.ProgramDayInput, 0, len(program.Days))
+	if req.Days != nil {
+		inputs = *req.Days
+	} else {
+		for _, day := range program.Days {
+			inputs = append(inputs, models.ProgramDayInput{
+				Week:             day.Week,
+				Day:              day.Day,
+				TemplateID:       day.TemplateID,
+				IntensityPercent: day.IntensityPercent,
+			})
+		}
+	}
+	days, err := s.buildDays(ctx, program, inputs)
+	if err != nil {
+		return nil, err
+	}
+	program.Days = days
+	program.UpdatedAt = time.Now()
+
+	err = s.programRepo.Update(ctx, program)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrProgramNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update program: %w", err)
+	}
+
+	return program, nil
+}
+
+func (s *programService) Delete(ctx context.Context, userID, programID uuid.UUID) error {
+	if _, err := s.getOwned(ctx, userID, programID); err != nil {
+		return err
+	}
+
+	err := s.programRepo.Delete(ctx, programID, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrProgramNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete program: %w", err)
+	}
+
+	return nil
+}
+
+func (s *programService) Enroll(
+	ctx context.Context,
+	userID, programID uuid.UUID,
+	req *models.EnrollRequest,
+) (*models.Enrollment, error) {
+	if _, err := s.Get(ctx, userID, programID); err != nil {
+		return nil, err
+	}
+
+	maxes := make([]models.TrainingMax, 0, len(req.TrainingMaxes))
+	for _, trainingMax := range req.TrainingMaxes {
+		_, err := s.catalogRepo.GetByID(ctx, trainingMax.ExerciseID, userID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrExerciseNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get catalog exercise: %w", err)
+		}
+		if trainingMax.WeightUnit == "" {
+			trainingMax.WeightUnit = models.WeightUnitKg
+		}
+		maxes = append(maxes, trainingMax)
+	}
+
+	now := time.Now()
+	enrollment := &models.Enrollment{
+		ID:            uuid.New(),
+		UserID:        userID,
+		ProgramID:     programID,
+		Status:        models.EnrollmentStatusActive,
+		TrainingMaxes: maxes,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	err := s.enrollmentRepo.Create(ctx, enrollment)
+	if errors.Is(err, repositories.ErrAlreadyExists) {
+		return nil, ErrAlreadyEnrolled
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to enroll: %w", err)
+	}
+
+	return enrollment, nil
+}
+
+func (s *programService) CurrentEnrollment(ctx context.Context, userID uuid.UUID) (*models.Enrollment, error) {
+	enrollment, err := s.enrollmentRepo.GetActiveByUserID(ctx, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrEnrollmentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enrollment: %w", err)
+	}
+
+	return enrollment, nil
+}
+
+func (s *programService) Unenroll(ctx context.Context, userID uuid.UUID) error {
+	enrollment, err := s.CurrentEnrollment(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	err = s.enrollmentRepo.UpdateStatus(ctx, enrollment.ID, userID, models.EnrollmentStatusCancelled)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrEnrollmentNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to unenroll: %w", err)
+	}
+
+	return nil
+}
+
+func (s *programService) Today(ctx context.Context, userID uuid.UUID) (*models.ScheduledWorkout, error) {
+	enrollment, err := s.CurrentEnrollment(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	program, err := s.Get(ctx, userID, enrollment.ProgramID)
+	if err != nil {
+		return nil, err
+	}
+	if enrollment.CompletedDays >= len(program.Days) {
+		return nil, ErrEnrollmentNotFound
+	}
+	day := program.Days[enrollment.CompletedDays]
+
+	// Program days reference the author's templates, which may not be the enrolled user.
+	template, err := s.templateRepo.GetByID(ctx, day.TemplateID, program.UserID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrTemplateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+
+	scheduled := &models.ScheduledWorkout{
+		EnrollmentID: enrollment.ID,
+		ProgramID:    program.ID,
+		ProgramName:  program.Name,
+		Week:         day.Week,
+		Day:          day.Day,
+		DayNumber:    enrollment.CompletedDays + 1,
+		TotalDays:    len(program.Days),
+		IsDeload:     program.IsDeloadWeek(day.Week),
+		TemplateID:   template.ID,
+		Name:         template.Name,
+		Notes:        template.Notes,
+		Exercises:    make([]*models.TemplateExercise, 0, len(template.Exercises)),
+	}
+	for _, exercise := range template.Exercises {
+		planned := *exercise
+		planned.TargetWeight = targetLoad(program, day, exercise, enrollment.TrainingMaxes)
+		scheduled.Exercises = append(scheduled.Exercises, &planned)
+	}
+
+	return scheduled, nil
+}
+
+func (s *programService) StartToday(ctx context.Context, userID uuid.UUID) (*models.Workout, error) {
+	scheduled, err := s.Today(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := models.EnrollmentStatusActive
+	if scheduled.DayNumber == scheduled.TotalDays {
+		status = models.EnrollmentStatusCompleted
+	}
+
+	// Advancing first means two concurrent starts cannot both claim the same day.
+	err = s.enrollmentRepo.Advance(ctx, scheduled.EnrollmentID, scheduled.DayNumber-1, status)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrEnrollmentChanged
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to advance enrollment: %w", err)
+	}
+
+	workout := workoutFromTemplate(userID, &models.WorkoutTemplate{
+		Name:      fmt.Sprintf("%s - week %d day %d", scheduled.Name, scheduled.Week, scheduled.Day),
+		Notes:     scheduled.Notes,
+		Exercises: scheduled.Exercises,
+	}, time.Now())
+	if err := s.workoutRepo.CreateWithExercises(ctx, workout); err != nil {
+		return nil, fmt.Errorf("failed to start workout: %w", err)
+	}
+
+	return workout, nil
+}
+
+// targetLoad applies the program's progression rule to a template exercise.
+// It returns nil when the exercise has no load to prescribe.
+func targetLoad(
+	program *models.Program,
+	day *models.ProgramDay,
+	exercise *models.TemplateExercise,
+	maxes []models.TrainingMax,
+) *float64 {
+	var load *float64
+	if exercise.TargetWeight != nil {
+		weight := *exercise.TargetWeight
+		load = &weight
+	}
+
+	switch program.Progression {
+	case models.ProgressionFixedIncrement:
+		if load != nil {
+			increment := models.ConvertWeight(program.Increment, program.IncrementUnit, exercise.WeightUnit)
+			*load += increment * float64(day.Week-1)
+		}
+	case models.ProgressionPercentOfMax:
+		if day.IntensityPercent != nil && exercise.ExerciseID != nil {
+			for _, trainingMax := range maxes {
+				if trainingMax.ExerciseID == *exercise.ExerciseID {
+					weight := models.ConvertWeight(trainingMax.Weight, trainingMax.WeightUnit, exercise.WeightUnit) *
+						*day.IntensityPercent / 100
+					load = &weight
+					break
+				}
+			}
+		}
+	case models.ProgressionNone:
+	}
+
+	if load == nil {
+		return nil
+	}
+	if program.IsDeloadWeek(day.Week) {
+		*load *= program.DeloadPercent / 100
+	}
+	if program.LoadRounding > 0 {
+		*load = math.Round(*load/program.LoadRounding) * program.LoadRounding
+	}
+	return load
+}
@@ -0,0 +1,317 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockProgramRepository struct {
+	programs map[uuid.UUID]*models.Program
+}
+
+func newMockProgramRepository() *mockProgramRepository {
+	return &mockProgramRepository{programs: make(map[uuid.UUID]*models.Program)}
+}
+
+func (m *mockProgramRepository) Create(ctx context.Context, program *models.Program) error {
+	m.programs[program.ID] = program
+	return nil
+}
+
+func (m *mockProgramRepository) GetByID(ctx context.Context, id, userID uuid.UUID) (*models.Program, error) {
+	program, exists := m.programs[id]
+	if !exists || (program.UserID != userID && !program.IsPublic) {
+		return nil, repositories.ErrNotFound
+	}
+	copied := *program
+	return &copied, nil
+}
+
+func (m *mockProgramRepository) List(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Program, error) {
+	var programs []*models.Program
+	for _, program := range m.programs {
+		if program.UserID == userID || program.IsPublic {
+			programs = append(programs, program)
+		}
+	}
+	return programs, nil
+}
+
+func (m *mockProgramRepository) Update(ctx context.Context, program *models.Program) error {
+	existing, exists := m.programs[program.ID]
+	if !exists || existing.UserID != program.UserID {
+		return repositories.ErrNotFound
+	}
+	m.programs[program.ID] = program
+	return nil
+}
+
+func (m *mockProgramRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	program, exists := m.programs[id]
+	if !exists || program.UserID != userID {
+		return repositories.ErrNotFound
+	}
+	delete(m.programs, id)
+	return nil
+}
+
+type mockEnrollmentRepository struct {
+	enrollments map[uuid.UUID]*models.Enrollment
+}
+
+func newMockEnrollmentRepository() *mockEnrollmentRepository {
+	return &mockEnrollmentRepository{enrollments: make(map[uuid.UUID]*models.Enrollment)}
+}
+
+func (m *mockEnrollmentRepository) Create(ctx context.Context, enrollment *models.Enrollment) error {
+	for _, existing := range m.enrollments {
+		if existing.UserID == enrollment.UserID && existing.Status == models.EnrollmentStatusActive {
+			return repositories.ErrAlreadyExists
+		}
+	}
+	m.enrollments[enrollment.ID] = enrollment
+	return nil
+}
+
+func (m *mockEnrollmentRepository) GetActiveByUserID(ctx context.Context, userID uuid.UUID) (*models.Enrollment, error) {
+	for _, enrollment := range m.enrollments {
+		if enrollment.UserID == userID && enrollment.Status == models.EnrollmentStatusActive {
+			copied := *enrollment
+			return &copied, nil
+		}
+	}
+	return nil, repositories.ErrNotFound
+}
+
+func (m *mockEnrollmentRepository) Advance(
+	ctx context.Context,
+	id uuid.UUID,
+	completedDays int,
+	status models.EnrollmentStatus,
+) error {
+	enrollment, exists := m.enrollments[id]
+	if !exists || enrollment.CompletedDays != completedDays || enrollment.Status != models.EnrollmentStatusActive {
+		return repositories.ErrNotFound
+	}
+	enrollment.CompletedDays++
+	enrollment.Status = status
+	return nil
+}
+
+func (m *mockEnrollmentRepository) UpdateStatus(
+	ctx context.Context,
+	id, userID uuid.UUID,
+	status models.EnrollmentStatus,
+) error {
+	enrollment, exists := m.enrollments[id]
+	if !exists || enrollment.UserID != userID {
+		return repositories.ErrNotFound
+	}
+	enrollment.Status = status
+	return nil
+}
+
+type programFixture struct {
+	service   ProgramService
+	templates TemplateService
+	workouts  *mockWorkoutRepository
+	coachID   uuid.UUID
+	squat     *models.Exercise
+}
+
+func newProgramFixture() *programFixture {
+	squat := &models.Exercise{ID: uuid.New(), Name: "Back Squat"}
+	templateRepo := newMockTemplateRepository()
+	workoutRepo := newMockWorkoutRepository()
+	catalogRepo := newMockExerciseRepository(squat)
+	return &programFixture{
+		service: NewProgramService(
+			newMockProgramRepository(), newMockEnrollmentRepository(), templateRepo, workoutRepo, catalogRepo,
+		),
+		templates: NewTemplateService(templateRepo, workoutRepo, catalogRepo),
+		workouts:  workoutRepo,
+		coachID:   uuid.New(),
+		squat:     squat,
+	}
+}
+
+func (f *programFixture) createTemplate(t *testing.T, weight float64) *models.WorkoutTemplate {
+	t.Helper()
+	template, err := f.templates.Create(context.Background(), f.coachID, &models.CreateTemplateRequest{
+		Name: "Squat day",
+		Exercises: []models.TemplateExerciseInput{
+			{ExerciseID: &f.squat.ID, TargetSets: 5, TargetReps: 5, TargetWeight: &weight},
+		},
+	})
+	require.NoError(t, err)
+	return template
+}
+
+func TestProgramService_ScheduleValidation(t *testing.T) {
+	f := newProgramFixture()
+	ctx := context.Background()
+	template := f.createTemplate(t, 100)
+
+	_, err := f.service.Create(ctx, f.coachID, &models.CreateProgramRequest{
+		Name: "Too short", Weeks: 1, DaysPerWeek: 2,
+		Days: []models.ProgramDayInput{{Week: 2, Day: 1, TemplateID: template.ID}},
+	})
+	assert.ErrorIs(t, err, ErrInvalidProgramSchedule)
+
+	_, err = f.service.Create(ctx, f.coachID, &models.CreateProgramRequest{
+		Name: "Duplicate", Weeks: 1, DaysPerWeek: 2,
+		Days: []models.ProgramDayInput{
+			{Week: 1, Day: 1, TemplateID: template.ID},
+			{Week: 1, Day: 1, TemplateID: template.ID},
+		},
+	})
+	assert.ErrorIs(t, err, ErrInvalidProgramSchedule)
+
+	_, err = f.service.Create(ctx, uuid.New(), &models.CreateProgramRequest{
+		Name: "Someone else's template", Weeks: 1, DaysPerWeek: 1,
+		Days: []models.ProgramDayInput{{Week: 1, Day: 1, TemplateID: template.ID}},
+	})
+	assert.ErrorIs(t, err, ErrTemplateNotFound)
+
+	program, err := f.service.Create(ctx, f.coachID, &models.CreateProgramRequest{
+		Name: "Ordered", Weeks: 2, DaysPerWeek: 2,
+		Days: []models.ProgramDayInput{
+			{Week: 2, Day: 1, TemplateID: template.ID},
+			{Week: 1, Day: 2, TemplateID: template.ID},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.ProgressionNone, program.Progression)
+	assert.Equal(t, 1, program.Days[0].Week)
+
+	weeks := 1
+	_, err = f.service.Update(ctx, f.coachID, program.ID, &models.UpdateProgramRequest{Weeks: &weeks})
+	assert.ErrorIs(t, err, ErrInvalidProgramSchedule)
+
+	_, err = f.service.Update(ctx, uuid.New(), program.ID, &models.UpdateProgramRequest{Weeks: &weeks})
+	assert.ErrorIs(t, err, ErrProgramNotFound)
+}
+
+func TestProgramService_FixedIncrementWithDeload(t *testing.T) {
+	f := newProgramFixture()
+	ctx := context.Background()
+	template := f.createTemplate(t, 100)
+
+	program, err := f.service.Create(ctx, f.coachID, &models.CreateProgramRequest{
+		Name: "Linear", Weeks: 3, DaysPerWeek: 1, IsPublic: true,
+		Progression: models.ProgressionFixedIncrement, Increment: 5, DeloadWeeks: []int{3},
+		Days: []models.ProgramDayInput{
+			{Week: 1, Day: 1, TemplateID: template.ID},
+			{Week: 2, Day: 1, TemplateID: template.ID},
+			{Week: 3, Day: 1, TemplateID: template.ID},
+		},
+	})
+	require.NoError(t, err)
+
+	athleteID := uuid.New()
+	_, err = f.service.Today(ctx, athleteID)
+	assert.ErrorIs(t, err, ErrEnrollmentNotFound)
+
+	_, err = f.service.Enroll(ctx, athleteID, program.ID, &models.EnrollRequest{})
+	require.NoError(t, err)
+	_, err = f.service.Enroll(ctx, athleteID, program.ID, &models.EnrollRequest{})
+	assert.ErrorIs(t, err, ErrAlreadyEnrolled)
+
+	// Week 1 = 100, week 2 = 105, week 3 = (100 + 10) * 60% = 66 -> 65 after rounding to 2.5.
+	for _, expected := range []float64{100, 105, 65} {
+		today, err := f.service.Today(ctx, athleteID)
+		require.NoError(t, err)
+		require.NotNil(t, today.Exercises[0].TargetWeight)
+		assert.InDelta(t, expected, *today.Exercises[0].TargetWeight, 0.001)
+
+		workout, err := f.service.StartToday(ctx, athleteID)
+		require.NoError(t, err)
+		assert.Equal(t, athleteID, workout.UserID)
+		assert.InDelta(t, expected, workout.Exercises[0].Sets[0].Weight, 0.001)
+	}
+
+	_, err = f.service.Today(ctx, athleteID)
+	assert.ErrorIs(t, err, ErrEnrollmentNotFound, "enrollment completes after the last day")
+	assert.Len(t, f.workouts.workouts, 3)
+
+	// The template itself is left untouched.
+	stored, err := f.templates.Get(ctx, f.coachID, template.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, *stored.Exercises[0].TargetWeight)
+}
+
+func TestProgramService_FixedIncrementUnit(t *testing.T) {
+	f := newProgramFixture()
+	ctx := context.Background()
+
+	weight := 225.0
+	template, err := f.templates.Create(ctx, f.coachID, &models.CreateTemplateRequest{
+		Name: "Squat day",
+		Exercises: []models.TemplateExerciseInput{
+			{ExerciseID: &f.squat.ID, TargetSets: 5, TargetReps: 5, TargetWeight: &weight, WeightUnit: models.WeightUnitLb},
+		},
+	})
+	require.NoError(t, err)
+
+	noRounding := 0.0
+	program, err := f.service.Create(ctx, f.coachID, &models.CreateProgramRequest{
+		Name: "Linear", Weeks: 2, DaysPerWeek: 1,
+		Progression: models.ProgressionFixedIncrement, Increment: 5, LoadRounding: &noRounding,
+		Days: []models.ProgramDayInput{
+			{Week: 1, Day: 1, TemplateID: template.ID},
+			{Week: 2, Day: 1, TemplateID: template.ID},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.WeightUnitKg, program.IncrementUnit, "increments default to kg")
+
+	_, err = f.service.Enroll(ctx, f.coachID, program.ID, &models.EnrollRequest{})
+	require.NoError(t, err)
+	_, err = f.service.StartToday(ctx, f.coachID)
+	require.NoError(t, err)
+
+	today, err := f.service.Today(ctx, f.coachID)
+	require.NoError(t, err)
+	assert.InDelta(t, 225+models.ConvertWeight(5, models.WeightUnitKg, models.WeightUnitLb), *today.Exercises[0].TargetWeight, 0.001,
+		"a 5 kg increment adds about 11 lb to a template in pounds")
+}
+
+func TestProgramService_PercentOfTrainingMax(t *testing.T) {
+	f := newProgramFixture()
+	ctx := context.Background()
+	template := f.createTemplate(t, 0)
+
+	intensity := 75.0
+	program, err := f.service.Create(ctx, f.coachID, &models.CreateProgramRequest{
+		Name: "Percentages", Weeks: 1, DaysPerWeek: 1,
+		Progression: models.ProgressionPercentOfMax,
+		Days:        []models.ProgramDayInput{{Week: 1, Day: 1, TemplateID: template.ID, IntensityPercent: &intensity}},
+	})
+	require.NoError(t, err)
+
+	_, err = f.service.Enroll(ctx, f.coachID, program.ID, &models.EnrollRequest{
+		TrainingMaxes: []models.TrainingMax{{ExerciseID: uuid.New(), Weight: 100}},
+	})
+	assert.ErrorIs(t, err, ErrExerciseNotFound)
+
+	enrollment, err := f.service.Enroll(ctx, f.coachID, program.ID, &models.EnrollRequest{
+		TrainingMaxes: []models.TrainingMax{{ExerciseID: f.squat.ID, Weight: 405, WeightUnit: models.WeightUnitLb}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.WeightUnitLb, enrollment.TrainingMaxes[0].WeightUnit)
+
+	// 405 lb is about 183.7 kg; 75% is about 137.8 kg, rounded to 137.5.
+	today, err := f.service.Today(ctx, f.coachID)
+	require.NoError(t, err)
+	assert.InDelta(t, 137.5, *today.Exercises[0].TargetWeight, 0.001)
+	assert.Equal(t, 1, today.TotalDays)
+
+	require.NoError(t, f.service.Unenroll(ctx, f.coachID))
+	assert.ErrorIs(t, f.service.Unenroll(ctx, f.coachID), ErrEnrollmentNotFound)
+}
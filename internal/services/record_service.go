@@ -0,0 +1,341 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+)
+
+// maxEstimateReps is the highest rep count used for 1RM estimates; the
+// formulas lose accuracy quickly past it.
+const maxEstimateReps = 15
+
+// recordEpsilon ignores differences below the stored precision of two decimals.
+const recordEpsilon = 0.005
+
+type RecordService interface {
+	// DetectForSet compares a freshly saved set against the user's records and
+	// stores and returns any it beats, along with the workout's session volume
+	// when that is one. Sets not linked to the catalog are ignored.
+	DetectForSet(
+		ctx context.Context,
+		userID uuid.UUID,
+		workout *models.Workout,
+		exercise *models.WorkoutExercise,
+		set *models.ExerciseSet,
+	) ([]*models.PersonalRecord, error)
+	// RedetectForExercise recomputes a workout's records for a catalog exercise
+	// after a set or entry was removed along with the records it held.
+	RedetectForExercise(
+		ctx context.Context,
+		userID uuid.UUID,
+		workout *models.Workout,
+		exercise *models.WorkoutExercise,
+	) error
+	List(ctx context.Context, userID uuid.UUID, filter models.RecordFilter) ([]*models.PersonalRecord, error)
+	ListForExercise(
+		ctx context.Context,
+		userID, exerciseID uuid.UUID,
+		filter models.RecordFilter,
+	) ([]*models.PersonalRecord, error)
+}
+
+type recordService struct {
+	recordRepo   repositories.RecordRepository
+	exerciseRepo repositories.WorkoutExerciseRepository
+	setRepo      repositories.ExerciseSetRepository
+	catalogRepo  repositories.ExerciseRepository
+}
+
+func NewRecordService(
+	recordRepo repositories.RecordRepository,
+	exerciseRepo repositories.WorkoutExerciseRepository,
+	setRepo repositories.ExerciseSetRepository,
+	catalogRepo repositories.ExerciseRepository,
+) RecordService {
+	return &recordService{
+		recordRepo:   recordRepo,
+		exerciseRepo: exerciseRepo,
+		setRepo:      setRepo,
+		catalogRepo:  catalogRepo,
+	}
+}
+
+// EstimateOneRepMax estimates a one-rep max from a set using the given formula.
+// It returns 0 when the set is outside the range the formulas are meant for.
+func EstimateOneRepMax(weight float64, reps int, formula models.E1RMFormula) float64 {
+	if weight <= 0 || reps < 1 || reps > maxEstimateReps {
+		return 0
+	}
+	if reps == 1 {
+		return weight
+	}
+
+	switch formula {
+	case models.E1RMFormulaBrzycki:
+		return weight * 36 / float64(37-reps)
+	case models.E1RMFormulaEpley:
+		return weight * (1 + float64(reps)/30)
+	default:
+		return 0
+	}
+}
+
+func roundHundredths(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func (s *recordService) DetectForSet(
+	ctx context.Context,
+	userID uuid.UUID,
+	workout *models.Workout,
+	exercise *models.WorkoutExercise,
+	set *models.ExerciseSet,
+) ([]*models.PersonalRecord, error) {
+	if exercise.ExerciseID == nil {
+		return []*models.PersonalRecord{}, nil
+	}
+
+	records, err := s.detectSetRecords(ctx, userID, workout, *exercise.ExerciseID, set)
+	if err != nil {
+		return nil, err
+	}
+
+	volume, err := s.detectSessionVolume(ctx, userID, workout, *exercise.ExerciseID)
+	if err != nil {
+		return nil, err
+	}
+	if volume != nil && !set.IsWarmup {
+		records = append(records, volume)
+	}
+
+	return records, nil
+}
+
+func (s *recordService) RedetectForExercise(
+	ctx context.Context,
+	userID uuid.UUID,
+	workout *models.Workout,
+	exercise *models.WorkoutExercise,
+) error {
+	if exercise.ExerciseID == nil {
+		return nil
+	}
+
+	matching, err := s.matchingEntries(ctx, workout.ID, *exercise.ExerciseID)
+	if err != nil {
+		return err
+	}
+
+	sets, err := s.setRepo.ListByWorkoutID(ctx, workout.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list sets: %w", err)
+	}
+
+	// Sets that still hold a record are not above their own, so only the ones
+	// the removed set was hiding are stored.
+	for _, set := range sets {
+		if !matching[set.WorkoutExerciseID] {
+			continue
+		}
+		if _, err := s.detectSetRecords(ctx, userID, workout, *exercise.ExerciseID, set); err != nil {
+			return err
+		}
+	}
+
+	_, err = s.detectSessionVolume(ctx, userID, workout, *exercise.ExerciseID)
+	return err
+}
+
+// detectSetRecords stores and returns the weight, rep and 1RM records a working set beats.
+func (s *recordService) detectSetRecords(
+	ctx context.Context,
+	userID uuid.UUID,
+	workout *models.Workout,
+	exerciseID uuid.UUID,
+	set *models.ExerciseSet,
+) ([]*models.PersonalRecord, error) {
+	if set.IsWarmup || set.Reps < 1 {
+		return []*models.PersonalRecord{}, nil
+	}
+
+	now := time.Now()
+	setID := set.ID
+	weightKg := roundHundredths(models.ConvertWeight(set.Weight, set.WeightUnit, models.WeightUnitKg))
+	candidate := func(recordType models.RecordType, formula models.E1RMFormula, value float64) *models.PersonalRecord {
+		return &models.PersonalRecord{
+			ID:         uuid.New(),
+			UserID:     userID,
+			ExerciseID: exerciseID,
+			WorkoutID:  workout.ID,
+			SetID:      &setID,
+			RecordType: recordType,
+			Formula:    formula,
+			Value:      roundHundredths(value),
+			WeightKg:   weightKg,
+			Weight:     set.Weight,
+			WeightUnit: set.WeightUnit,
+			Reps:       set.Reps,
+			AchievedAt: workout.StartedAt,
+			CreatedAt:  now,
+		}
+	}
+
+	candidates := []*models.PersonalRecord{
+		candidate(models.RecordTypeMaxReps, "", float64(set.Reps)),
+	}
+	if weightKg > 0 {
+		candidates = append(candidates, candidate(models.RecordTypeMaxWeight, "", weightKg))
+		for _, formula := range []models.E1RMFormula{models.E1RMFormulaEpley, models.E1RMFormulaBrzycki} {
+			if estimate := EstimateOneRepMax(weightKg, set.Reps, formula); estimate > 0 {
+				candidates = append(candidates, candidate(models.RecordTypeEstimated1RM, formula, estimate))
+			}
+		}
+	}
+
+	records := make([]*models.PersonalRecord, 0, len(candidates))
+	for _, record := range candidates {
+		beats, err := s.beatsBest(ctx, record)
+		if err != nil {
+			return nil, err
+		}
+		if beats {
+			records = append(records, record)
+		}
+	}
+
+	if err := s.recordRepo.Create(ctx, records); err != nil {
+		return nil, fmt.Errorf("failed to save personal records: %w", err)
+	}
+
+	return records, nil
+}
+
+// detectSessionVolume replaces the workout's session volume record for a
+// catalog exercise, which belongs to the workout rather than to any one set,
+// and returns it when the current volume is still a record.
+func (s *recordService) detectSessionVolume(
+	ctx context.Context,
+	userID uuid.UUID,
+	workout *models.Workout,
+	exerciseID uuid.UUID,
+) (*models.PersonalRecord, error) {
+	if err := s.recordRepo.DeleteSessionVolume(ctx, workout.ID, exerciseID); err != nil {
+		return nil, fmt.Errorf("failed to delete session volume record: %w", err)
+	}
+
+	volume, err := s.sessionVolume(ctx, workout.ID, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+	if volume <= 0 {
+		return nil, nil
+	}
+
+	record := &models.PersonalRecord{
+		ID:         uuid.New(),
+		UserID:     userID,
+		ExerciseID: exerciseID,
+		WorkoutID:  workout.ID,
+		RecordType: models.RecordTypeSessionVolume,
+		Value:      roundHundredths(volume),
+		WeightUnit: models.WeightUnitKg,
+		AchievedAt: workout.StartedAt,
+		CreatedAt:  time.Now(),
+	}
+
+	beats, err := s.beatsBest(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+	if !beats {
+		return nil, nil
+	}
+
+	if err := s.recordRepo.Create(ctx, []*models.PersonalRecord{record}); err != nil {
+		return nil, fmt.Errorf("failed to save personal records: %w", err)
+	}
+
+	return record, nil
+}
+
+func (s *recordService) beatsBest(ctx context.Context, record *models.PersonalRecord) (bool, error) {
+	best, err := s.recordRepo.GetBest(ctx, record.UserID, record.ExerciseID, record.RecordType, record.Formula, record.WeightKg)
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		return false, fmt.Errorf("failed to get best record: %w", err)
+	}
+	return best == nil || record.Value > best.Value+recordEpsilon, nil
+}
+
+// matchingEntries returns the IDs of the workout's entries for a catalog exercise.
+func (s *recordService) matchingEntries(ctx context.Context, workoutID, exerciseID uuid.UUID) (map[uuid.UUID]bool, error) {
+	entries, err := s.exerciseRepo.ListByWorkoutID(ctx, workoutID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exercises: %w", err)
+	}
+
+	matching := make(map[uuid.UUID]bool)
+	for _, entry := range entries {
+		if entry.ExerciseID != nil && *entry.ExerciseID == exerciseID {
+			matching[entry.ID] = true
+		}
+	}
+
+	return matching, nil
+}
+
+// sessionVolume totals weight x reps in kg over the workout's working sets for a catalog exercise.
+func (s *recordService) sessionVolume(ctx context.Context, workoutID, exerciseID uuid.UUID) (float64, error) {
+	matching, err := s.matchingEntries(ctx, workoutID, exerciseID)
+	if err != nil {
+		return 0, err
+	}
+
+	sets, err := s.setRepo.ListByWorkoutID(ctx, workoutID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list sets: %w", err)
+	}
+
+	volume := 0.0
+	for _, set := range sets {
+		if matching[set.WorkoutExerciseID] && !set.IsWarmup {
+			volume += models.ConvertWeight(set.Weight, set.WeightUnit, models.WeightUnitKg) * float64(set.Reps)
+		}
+	}
+
+	return volume, nil
+}
+
+func (s *recordService) List(ctx context.Context, userID uuid.UUID, filter models.RecordFilter) ([]*models.PersonalRecord, error) {
+	filter.Pagination = filter.Pagination.Normalize()
+
+	records, err := s.recordRepo.List(ctx, userID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list records: %w", err)
+	}
+
+	return records, nil
+}
+
+func (s *recordService) ListForExercise(
+	ctx context.Context,
+	userID, exerciseID uuid.UUID,
+	filter models.RecordFilter,
+) ([]*models.PersonalRecord, error) {
+	_, err := s.catalogRepo.GetByID(ctx, exerciseID, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrExerciseNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exercise: %w", err)
+	}
+
+	filter.ExerciseID = &exerciseID
+	return s.List(ctx, userID, filter)
+}
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockRecordRepository struct {
+	records []*models.PersonalRecord
+}
+
+func newMockRecordRepository() *mockRecordRepository {
+	return &mockRecordRepository{}
+}
+
+func (m *mockRecordRepository) Create(ctx context.Context, records []*models.PersonalRecord) error {
+	m.records = append(m.records, records...)
+	return nil
+}
+
+func (m *mockRecordRepository) GetBest(
+	ctx context.Context,
+	userID, exerciseID uuid.UUID,
+	recordType models.RecordType,
+	formula models.E1RMFormula,
+	weightKg float64,
+) (*models.PersonalRecord, error) {
+	var best *models.PersonalRecord
+	for _, record := range m.records {
+		if record.UserID != userID || record.ExerciseID != exerciseID ||
+			record.RecordType != recordType || record.Formula != formula {
+			continue
+		}
+		if recordType == models.RecordTypeMaxReps && record.WeightKg != weightKg {
+			continue
+		}
+		if best == nil || record.Value > best.Value {
+			best = record
+		}
+	}
+	if best == nil {
+		return nil, repositories.ErrNotFound
+	}
+	return best, nil
+}
+
+func (m *mockRecordRepository) List(
+	ctx context.Context,
+	userID uuid.UUID,
+	filter models.RecordFilter,
+) ([]*models.PersonalRecord, error) {
+	var records []*models.PersonalRecord
+	for _, record := range m.records {
+		if record.UserID != userID {
+			continue
+		}
+		if filter.ExerciseID != nil && record.ExerciseID != *filter.ExerciseID {
+			continue
+		}
+		if filter.RecordType != "" && record.RecordType != filter.RecordType {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (m *mockRecordRepository) DeleteSessionVolume(ctx context.Context, workoutID, exerciseID uuid.UUID) error {
+	m.deleteWhere(func(record *models.PersonalRecord) bool {
+		return record.WorkoutID == workoutID && record.ExerciseID == exerciseID &&
+			record.RecordType == models.RecordTypeSessionVolume
+	})
+	return nil
+}
+
+func (m *mockRecordRepository) deleteWhere(match func(record *models.PersonalRecord) bool) {
+	kept := m.records[:0]
+	for _, record := range m.records {
+		if !match(record) {
+			kept = append(kept, record)
+		}
+	}
+	m.records = kept
+}
+
+func TestEstimateOneRepMax(t *testing.T) {
+	tests := []struct {
+		name     string
+		weight   float64
+		reps     int
+		formula  models.E1RMFormula
+		expected float64
+	}{
+		{"single is the max", 140, 1, models.E1RMFormulaEpley, 140},
+		{"epley", 100, 5, models.E1RMFormulaEpley, 116.6667},
+		{"brzycki", 100, 5, models.E1RMFormulaBrzycki, 112.5},
+		{"too many reps", 60, 20, models.E1RMFormulaEpley, 0},
+		{"no weight", 0, 5, models.E1RMFormulaBrzycki, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, EstimateOneRepMax(tt.weight, tt.reps, tt.formula), 0.001)
+		})
+	}
+}
+
+func recordTypes(records []*models.PersonalRecord) []string {
+	types := make([]string, 0, len(records))
+	for _, record := range records {
+		types = append(types, string(record.RecordType)+":"+string(record.Formula))
+	}
+	return types
+}
+
+func TestWorkoutExerciseService_DetectsPersonalRecords(t *testing.T) {
+	workoutRepo := newMockWorkoutRepository()
+	exerciseRepo := &mockWorkoutExerciseRepository{}
+	setRepo := &mockExerciseSetRepository{exercises: exerciseRepo}
+	bench := &models.Exercise{ID: uuid.New(), Name: "Bench Press"}
+	catalogRepo := newMockExerciseRepository(bench)
+	recordRepo := newMockRecordRepository()
+	records := NewRecordService(recordRepo, exerciseRepo, setRepo, catalogRepo)
+	service := NewWorkoutExerciseService(workoutRepo, exerciseRepo, setRepo, catalogRepo, records)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	workout, err := NewWorkoutService(workoutRepo).Create(ctx, userID, &models.CreateWorkoutRequest{Title: "Push day"})
+	require.NoError(t, err)
+	entry, err := service.AddExercise(ctx, userID, workout.ID, &models.CreateWorkoutExerciseRequest{ExerciseID: &bench.ID})
+	require.NoError(t, err)
+
+	addSet := func(reps int, weight float64, unit models.WeightUnit, warmup bool) *models.ExerciseSet {
+		t.Helper()
+		set, err := service.AddSet(ctx, userID, workout.ID, entry.ID, &models.CreateExerciseSetRequest{
+			Reps: reps, Weight: weight, WeightUnit: unit, IsWarmup: warmup,
+		})
+		require.NoError(t, err)
+		return set
+	}
+
+	warmup := addSet(10, 40, models.WeightUnitKg, true)
+	assert.Empty(t, warmup.NewRecords, "warm-up sets never count")
+
+	first := addSet(5, 100, models.WeightUnitKg, false)
+	assert.ElementsMatch(t, []string{
+		"max_reps:", "max_weight:", "estimated_1rm:epley", "estimated_1rm:brzycki", "session_volume:",
+	}, recordTypes(first.NewRecords))
+	for _, record := range first.NewRecords {
+		assert.Equal(t, workout.ID, record.WorkoutID)
+		assert.Equal(t, workout.StartedAt, record.AchievedAt)
+	}
+
+	// Same weight for fewer reps only adds volume.
+	second := addSet(3, 100, models.WeightUnitKg, false)
+	assert.Equal(t, []string{"session_volume:"}, recordTypes(second.NewRecords))
+	assert.InDelta(t, 800, second.NewRecords[0].Value, 0.001)
+
+	// 225 lb is about 102.06 kg: heavier, a first rep record at that weight, and more volume.
+	third := addSet(2, 225, models.WeightUnitLb, false)
+	assert.ElementsMatch(t, []string{"max_reps:", "max_weight:", "session_volume:"}, recordTypes(third.NewRecords))
+
+	unlinked, err := service.AddExercise(ctx, userID, workout.ID, &models.CreateWorkoutExerciseRequest{Name: "Machine press"})
+	require.NoError(t, err)
+	set, err := service.AddSet(ctx, userID, workout.ID, unlinked.ID, &models.CreateExerciseSetRequest{
+		Reps: 10, Weight: 200, WeightUnit: models.WeightUnitKg,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, set.NewRecords, "entries without a catalog exercise have no records")
+
+	listed, err := records.ListForExercise(ctx, userID, bench.ID, models.RecordFilter{History: true})
+	require.NoError(t, err)
+	assert.Len(t, listed, 7)
+	volumes, err := records.ListForExercise(ctx, userID, bench.ID, models.RecordFilter{RecordType: models.RecordTypeSessionVolume})
+	require.NoError(t, err)
+	require.Len(t, volumes, 1, "the workout keeps a single session volume record")
+	assert.InDelta(t, 1004.12, volumes[0].Value, 0.001)
+	assert.Nil(t, volumes[0].SetID)
+
+	_, err = records.ListForExercise(ctx, userID, uuid.New(), models.RecordFilter{})
+	assert.ErrorIs(t, err, ErrExerciseNotFound)
+}
+
+func TestWorkoutExerciseService_UpdateSetRedetectsRecords(t *testing.T) {
+	workoutRepo := newMockWorkoutRepository()
+	exerciseRepo := &mockWorkoutExerciseRepository{}
+	recordRepo := newMockRecordRepository()
+	setRepo := &mockExerciseSetRepository{exercises: exerciseRepo, records: recordRepo}
+	bench := &models.Exercise{ID: uuid.New(), Name: "Bench Press"}
+	catalogRepo := newMockExerciseRepository(bench)
+	records := NewRecordService(recordRepo, exerciseRepo, setRepo, catalogRepo)
+	service := NewWorkoutExerciseService(workoutRepo, exerciseRepo, setRepo, catalogRepo, records)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	workout, err := NewWorkoutService(workoutRepo).Create(ctx, userID, &models.CreateWorkoutRequest{Title: "Push day"})
+	require.NoError(t, err)
+	entry, err := service.AddExercise(ctx, userID, workout.ID, &models.CreateWorkoutExerciseRequest{ExerciseID: &bench.ID})
+	require.NoError(t, err)
+	set, err := service.AddSet(ctx, userID, workout.ID, entry.ID, &models.CreateExerciseSetRequest{
+		Reps: 5, Weight: 100, WeightUnit: models.WeightUnitKg,
+	})
+	require.NoError(t, err)
+
+	// A typo fixed: the set was really 60 kg.
+	weight := 60.0
+	updated, err := service.UpdateSet(ctx, userID, workout.ID, entry.ID, set.ID, &models.UpdateExerciseSetRequest{Weight: &weight})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"max_reps:", "max_weight:", "estimated_1rm:epley", "estimated_1rm:brzycki", "session_volume:",
+	}, recordTypes(updated.NewRecords), "the set's old records no longer stand in its way")
+
+	listed, err := records.ListForExercise(ctx, userID, bench.ID, models.RecordFilter{History: true})
+	require.NoError(t, err)
+	assert.Len(t, listed, 5, "records from the old values are gone")
+	for _, record := range listed {
+		if record.RecordType == models.RecordTypeSessionVolume {
+			assert.InDelta(t, 300, record.Value, 0.001)
+		} else {
+			assert.InDelta(t, 60, record.WeightKg, 0.001)
+		}
+	}
+}
+
+func TestWorkoutExerciseService_RemoveSetRedetectsRecords(t *testing.T) {
+	workoutRepo := newMockWorkoutRepository()
+	exerciseRepo := &mockWorkoutExerciseRepository{}
+	recordRepo := newMockRecordRepository()
+	setRepo := &mockExerciseSetRepository{exercises: exerciseRepo, records: recordRepo}
+	bench := &models.Exercise{ID: uuid.New(), Name: "Bench Press"}
+	catalogRepo := newMockExerciseRepository(bench)
+	records := NewRecordService(recordRepo, exerciseRepo, setRepo, catalogRepo)
+	service := NewWorkoutExerciseService(workoutRepo, exerciseRepo, setRepo, catalogRepo, records)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	workout, err := NewWorkoutService(workoutRepo).Create(ctx, userID, &models.CreateWorkoutRequest{Title: "Push day"})
+	require.NoError(t, err)
+	entry, err := service.AddExercise(ctx, userID, workout.ID, &models.CreateWorkoutExerciseRequest{ExerciseID: &bench.ID})
+	require.NoError(t, err)
+	addSet := func(weight float64) *models.ExerciseSet {
+		t.Helper()
+		set, err := service.AddSet(ctx, userID, workout.ID, entry.ID, &models.CreateExerciseSetRequest{
+			Reps: 5, Weight: weight, WeightUnit: models.WeightUnitKg,
+		})
+		require.NoError(t, err)
+		return set
+	}
+	typo := addSet(180)
+	addSet(90)
+
+	require.NoError(t, service.RemoveSet(ctx, userID, workout.ID, entry.ID, typo.ID))
+
+	best, err := records.ListForExercise(ctx, userID, bench.ID, models.RecordFilter{})
+	require.NoError(t, err)
+	values := make(map[string]float64)
+	for _, record := range best {
+		values[string(record.RecordType)+":"+string(record.Formula)] = record.Value
+	}
+	assert.InDelta(t, 90, values["max_weight:"], 0.001, "the remaining set becomes the record")
+	assert.InDelta(t, 105, values["estimated_1rm:epley"], 0.001)
+	assert.InDelta(t, 450, values["session_volume:"], 0.001, "volume is recomputed without the removed set")
+
+	require.NoError(t, service.RemoveExercise(ctx, userID, workout.ID, entry.ID))
+	volumes, err := records.ListForExercise(ctx, userID, bench.ID, models.RecordFilter{RecordType: models.RecordTypeSessionVolume})
+	require.NoError(t, err)
+	assert.Empty(t, volumes, "removing the entry drops its session volume too")
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+type SessionService interface {
+	// List returns the user's signed-in devices, most recently used first. The
+	// session holding currentRefreshToken is flagged as current.
+	List(ctx context.Context, userID uuid.UUID, currentRefreshToken string) ([]*models.Session, error)
+	Revoke(ctx context.Context, userID, sessionID uuid.UUID) error
+	// RevokeOthers signs out every session except the one holding currentRefreshToken.
+	RevokeOthers(ctx context.Context, userID uuid.UUID, currentRefreshToken string) error
+}
+
+type sessionService struct {
+	refreshTokenRepo repositories.RefreshTokenRepository
+}
+
+func NewSessionService(refreshTokenRepo repositories.RefreshTokenRepository) SessionService {
+	return &sessionService{
+		refreshTokenRepo: refreshTokenRepo,
+	}
+}
+
+func (s *sessionService) List(ctx context.Context, userID uuid.UUID, currentRefreshToken string) ([]*models.Session, error) {
+	tokens, err := s.refreshTokenRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	currentHash := hashOpaqueToken(currentRefreshToken)
+	sessions := make([]*models.Session, 0, len(tokens))
+	for _, token := range tokens {
+		sessions = append(sessions, &models.Session{
+			ID:         token.FamilyID,
+			DeviceName: token.DeviceName,
+			UserAgent:  token.UserAgent,
+			IPAddress:  token.IPAddress,
+			Current:    currentRefreshToken != "" && token.TokenHash == currentHash,
+			CreatedAt:  token.CreatedAt,
+			LastUsedAt: token.LastUsedAt,
+			ExpiresAt:  token.ExpiresAt,
+		})
+	}
+
+	return sessions, nil
+}
+
+func (s *sessionService) Revoke(ctx context.Context, userID, sessionID uuid.UUID) error {
+	err := s.refreshTokenRepo.RevokeFamily(ctx, userID, sessionID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return nil
+}
+
+func (s *sessionService) RevokeOthers(ctx context.Context, userID uuid.UUID, currentRefreshToken string) error {
+	if err := s.refreshTokenRepo.DeleteByUserIDExcept(ctx, userID, hashOpaqueToken(currentRefreshToken)); err != nil {
+		return fmt.Errorf("failed to revoke other sessions: %w", err)
+	}
+	return nil
+}
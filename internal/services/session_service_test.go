@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/config"
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSessionFixture() (SessionService, *mockRefreshTokenRepository, uuid.UUID) {
+	userID := uuid.New()
+	expiresAt := time.Now().Add(time.Hour)
+	repo := &mockRefreshTokenRepository{tokens: map[string]*models.RefreshToken{
+		hashOpaqueToken("phone"):  {ID: uuid.New(), UserID: userID, FamilyID: uuid.New(), TokenHash: hashOpaqueToken("phone"), DeviceName: "iPhone", ExpiresAt: expiresAt},
+		hashOpaqueToken("laptop"): {ID: uuid.New(), UserID: userID, FamilyID: uuid.New(), TokenHash: hashOpaqueToken("laptop"), DeviceName: "Chrome on macOS", ExpiresAt: expiresAt},
+		hashOpaqueToken("other"):  {ID: uuid.New(), UserID: uuid.New(), FamilyID: uuid.New(), TokenHash: hashOpaqueToken("other"), ExpiresAt: expiresAt},
+	}}
+	return NewSessionService(repo), repo, userID
+}
+
+func TestSessionService_List(t *testing.T) {
+	service, repo, userID := newSessionFixture()
+
+	sessions, err := service.List(context.Background(), userID, "laptop")
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+
+	for _, session := range sessions {
+		assert.Equal(t, session.DeviceName == "Chrome on macOS", session.Current)
+	}
+	assert.Contains(t, []uuid.UUID{sessions[0].ID, sessions[1].ID}, repo.tokens[hashOpaqueToken("phone")].FamilyID, "sessions are identified by family")
+}
+
+func TestSessionService_Revoke(t *testing.T) {
+	t.Run("RevokesOwnSession", func(t *testing.T) {
+		service, repo, userID := newSessionFixture()
+
+		require.NoError(t, service.Revoke(context.Background(), userID, repo.tokens[hashOpaqueToken("phone")].FamilyID))
+		assert.NotNil(t, repo.tokens[hashOpaqueToken("phone")].RevokedAt)
+		assert.Nil(t, repo.tokens[hashOpaqueToken("laptop")].RevokedAt)
+	})
+
+	t.Run("OtherUsersSessionIsNotFound", func(t *testing.T) {
+		service, repo, userID := newSessionFixture()
+
+		err := service.Revoke(context.Background(), userID, repo.tokens[hashOpaqueToken("other")].FamilyID)
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+		assert.Nil(t, repo.tokens[hashOpaqueToken("other")].RevokedAt)
+	})
+}
+
+func TestSessionService_RevokeOthers(t *testing.T) {
+	service, repo, userID := newSessionFixture()
+
+	require.NoError(t, service.RevokeOthers(context.Background(), userID, "laptop"))
+	assert.Contains(t, repo.tokens, hashOpaqueToken("laptop"))
+	assert.NotContains(t, repo.tokens, hashOpaqueToken("phone"))
+	assert.Contains(t, repo.tokens, hashOpaqueToken("other"), "other users are unaffected")
+}
+
+func TestAuthService_SessionDeviceInfo(t *testing.T) {
+	userRepo := &mockUserRepository{users: make(map[string]*models.User)}
+	refreshRepo := &mockRefreshTokenRepository{tokens: make(map[string]*models.RefreshToken)}
+	jwtConfig := &config.JWTConfig{Secret: "test-secret", Issuer: "test-issuer", Audience: "test-audience"}
+	authService := NewAuthService(
+		userRepo, refreshRepo, newMockMFARepository(), noLoginThrottle{},
+		noAudit{}, newTestTokenRevocations(), jwtConfig, &config.AuthConfig{},
+	)
+
+	_, err := authService.Register(context.Background(), &models.CreateUserRequest{Email: "test@example.com", Password: "password123"})
+	require.NoError(t, err)
+
+	_, refreshToken, err := authService.Login(context.Background(), "test@example.com", "password123", models.ClientInfo{
+		UserAgent: "Mozilla/5.0 (iPhone)", IPAddress: "203.0.113.7", DeviceName: "Alex's iPhone",
+	})
+	require.NoError(t, err)
+	loggedIn := refreshRepo.tokens[hashOpaqueToken(refreshToken)]
+	require.NotNil(t, loggedIn)
+	assert.Equal(t, "203.0.113.7", loggedIn.IPAddress)
+	assert.Equal(t, "Alex's iPhone", loggedIn.DeviceName)
+
+	_, rotated, err := authService.RefreshToken(context.Background(), refreshToken, models.ClientInfo{
+		UserAgent: "Mozilla/5.0 (iPhone)", IPAddress: "198.51.100.2", DeviceName: "iPhone",
+	})
+	require.NoError(t, err)
+	session := refreshRepo.tokens[hashOpaqueToken(rotated)]
+	require.NotNil(t, session)
+	assert.Equal(t, "198.51.100.2", session.IPAddress, "records where it was last used")
+	assert.Equal(t, "Alex's iPhone", session.DeviceName, "keeps the session's device name")
+	assert.Equal(t, loggedIn.CreatedAt, session.CreatedAt, "keeps the sign-in time")
+	assert.Equal(t, loggedIn.FamilyID, session.FamilyID)
+	assert.False(t, session.LastUsedAt.Before(loggedIn.LastUsedAt))
+}
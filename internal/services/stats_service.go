@@ -0,0 +1,306 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+)
+
+// maxStatsPeriods bounds how many buckets one stats request may produce.
+const maxStatsPeriods = 400
+
+var (
+	ErrInvalidStatsRange = errors.New("stats range must start before it ends and cover at most 400 periods")
+	ErrInvalidTimezone   = errors.New("unknown timezone")
+)
+
+type StatsService interface {
+	Volume(ctx context.Context, userID uuid.UUID, q models.StatsQuery) (*models.TimeSeriesResponse, error)
+	Sets(ctx context.Context, userID uuid.UUID, q models.StatsQuery) (*models.TimeSeriesResponse, error)
+	Frequency(ctx context.Context, userID uuid.UUID, q models.StatsQuery) (*models.TimeSeriesResponse, error)
+	Duration(ctx context.Context, userID uuid.UUID, q models.StatsQuery) (*models.TimeSeriesResponse, error)
+	Streaks(
+		ctx context.Context,
+		userID uuid.UUID,
+		interval models.StatsInterval,
+		timezone string,
+		weekStart models.WeekStart,
+	) (*models.Streaks, error)
+}
+
+type statsService struct {
+	statsRepo repositories.StatsRepository
+	now       func() time.Time
+}
+
+func NewStatsService(statsRepo repositories.StatsRepository) StatsService {
+	return &statsService{
+		statsRepo: statsRepo,
+		now:       time.Now,
+	}
+}
+
+func loadLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+	return loc, nil
+}
+
+// periodStart truncates t to the start of its period on the wall clock of loc.
+// Weeks start on weekStart, matching the repository's buckets.
+func periodStart(t time.Time, interval models.StatsInterval, weekStart models.WeekStart, loc *time.Location) time.Time {
+	t = t.In(loc)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	switch interval {
+	case models.StatsIntervalWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) - int(weekStart.Weekday()) + 7) % 7))
+	case models.StatsIntervalMonth:
+		return day.AddDate(0, 0, 1-day.Day())
+	default:
+		return day
+	}
+}
+
+func nextPeriod(t time.Time, interval models.StatsInterval) time.Time {
+	switch interval {
+	case models.StatsIntervalWeek:
+		return t.AddDate(0, 0, 7)
+	case models.StatsIntervalMonth:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+// normalizeQuery fills defaults and re-reads From and To as calendar dates in the
+// query timezone. To is inclusive on input and exclusive on output.
+func (s *statsService) normalizeQuery(q models.StatsQuery) (models.StatsQuery, *time.Location, error) {
+	if q.Interval == "" {
+		q.Interval = models.StatsIntervalWeek
+	}
+	if q.Timezone == "" {
+		q.Timezone = "UTC"
+	}
+	if q.WeekStart == "" {
+		q.WeekStart = models.WeekStartMonday
+	}
+	loc, err := loadLocation(q.Timezone)
+	if err != nil {
+		return q, nil, err
+	}
+
+	onDate := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	}
+
+	if q.To.IsZero() {
+		q.To = periodStart(s.now(), models.StatsIntervalDay, q.WeekStart, loc)
+	} else {
+		q.To = onDate(q.To)
+	}
+	q.To = q.To.AddDate(0, 0, 1)
+
+	if q.From.IsZero() {
+		switch q.Interval {
+		case models.StatsIntervalDay:
+			q.From = q.To.AddDate(0, 0, -30)
+		case models.StatsIntervalMonth:
+			q.From = periodStart(q.To.AddDate(0, -11, -1), models.StatsIntervalMonth, q.WeekStart, loc)
+		default:
+			q.From = periodStart(q.To.AddDate(0, 0, -7*12), models.StatsIntervalWeek, q.WeekStart, loc)
+		}
+	} else {
+		q.From = onDate(q.From)
+	}
+
+	if !q.From.Before(q.To) {
+		return q, nil, ErrInvalidStatsRange
+	}
+	return q, loc, nil
+}
+
+func periodKeys(q models.StatsQuery, loc *time.Location) ([]string, error) {
+	keys := make([]string, 0)
+	for period := periodStart(q.From, q.Interval, q.WeekStart, loc); period.Before(q.To); period = nextPeriod(period, q.Interval) {
+		if len(keys) == maxStatsPeriods {
+			return nil, ErrInvalidStatsRange
+		}
+		keys = append(keys, period.Format(models.PeriodLayout))
+	}
+	return keys, nil
+}
+
+// buildTimeSeries turns sparse repository rows into one zero-filled series per key.
+// fixedKeys are always present, even with no data.
+func buildTimeSeries(
+	q models.StatsQuery,
+	loc *time.Location,
+	unit string,
+	rows []models.StatsRow,
+	fixedKeys ...models.StatsRow,
+) (*models.TimeSeriesResponse, error) {
+	periods, err := periodKeys(q, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int, len(periods))
+	for i, period := range periods {
+		index[period] = i
+	}
+
+	seriesByKey := make(map[string]*models.TimeSeries)
+	ensure := func(key, label string) *models.TimeSeries {
+		series, ok := seriesByKey[key]
+		if !ok {
+			series = &models.TimeSeries{Key: key, Label: label, Points: make([]models.TimeSeriesPoint, len(periods))}
+			for i, period := range periods {
+				series.Points[i].Period = period
+			}
+			seriesByKey[key] = series
+		}
+		return series
+	}
+
+	for _, fixed := range fixedKeys {
+		ensure(fixed.Key, fixed.Label)
+	}
+	for _, row := range rows {
+		i, ok := index[row.Period]
+		if !ok {
+			continue
+		}
+		ensure(row.Key, row.Label).Points[i].Value += row.Value
+	}
+
+	series := make([]models.TimeSeries, 0, len(seriesByKey))
+	for _, s := range seriesByKey {
+		series = append(series, *s)
+	}
+	sort.Slice(series, func(i, j int) bool { return series[i].Label < series[j].Label })
+
+	return &models.TimeSeriesResponse{
+		From:     q.From.Format(models.PeriodLayout),
+		To:       q.To.AddDate(0, 0, -1).Format(models.PeriodLayout),
+		Interval: q.Interval,
+		Unit:     unit,
+		Periods:  periods,
+		Series:   series,
+	}, nil
+}
+
+type statsQueryFunc func(ctx context.Context, userID uuid.UUID, q models.StatsQuery) ([]models.StatsRow, error)
+
+func (s *statsService) timeSeries(
+	ctx context.Context,
+	userID uuid.UUID,
+	q models.StatsQuery,
+	query statsQueryFunc,
+	unit string,
+	fixedKeys ...models.StatsRow,
+) (*models.TimeSeriesResponse, error) {
+	q, loc, err := s.normalizeQuery(q)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the range before hitting the database.
+	if _, err := periodKeys(q, loc); err != nil {
+		return nil, err
+	}
+
+	rows, err := query(ctx, userID, q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stats: %w", err)
+	}
+
+	return buildTimeSeries(q, loc, unit, rows, fixedKeys...)
+}
+
+func (s *statsService) Volume(ctx context.Context, userID uuid.UUID, q models.StatsQuery) (*models.TimeSeriesResponse, error) {
+	return s.timeSeries(ctx, userID, q, s.statsRepo.VolumeByMuscle, "kg")
+}
+
+func (s *statsService) Sets(ctx context.Context, userID uuid.UUID, q models.StatsQuery) (*models.TimeSeriesResponse, error) {
+	return s.timeSeries(ctx, userID, q, s.statsRepo.SetsByExercise, "sets")
+}
+
+func (s *statsService) Frequency(ctx context.Context, userID uuid.UUID, q models.StatsQuery) (*models.TimeSeriesResponse, error) {
+	return s.timeSeries(ctx, userID, q, s.statsRepo.WorkoutCounts, "workouts",
+		models.StatsRow{Key: "workouts", Label: "Workouts"})
+}
+
+func (s *statsService) Duration(ctx context.Context, userID uuid.UUID, q models.StatsQuery) (*models.TimeSeriesResponse, error) {
+	return s.timeSeries(ctx, userID, q, s.statsRepo.AverageDurations, "minutes",
+		models.StatsRow{Key: "duration", Label: "Average duration"})
+}
+
+// Streaks counts consecutive periods with at least one workout. The current
+// streak stays alive until a whole period passes without training.
+func (s *statsService) Streaks(
+	ctx context.Context,
+	userID uuid.UUID,
+	interval models.StatsInterval,
+	timezone string,
+	weekStart models.WeekStart,
+) (*models.Streaks, error) {
+	if interval == "" {
+		interval = models.StatsIntervalWeek
+	}
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := loadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	periods, err := s.statsRepo.ActivePeriods(ctx, userID, interval, timezone, weekStart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active periods: %w", err)
+	}
+
+	streaks := &models.Streaks{Interval: interval}
+	if len(periods) == 0 {
+		return streaks, nil
+	}
+
+	run := 0
+	var previous time.Time
+	for _, key := range periods {
+		period, err := time.ParseInLocation(models.PeriodLayout, key, loc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse period %q: %w", key, err)
+		}
+		if run > 0 && nextPeriod(previous, interval).Equal(period) {
+			run++
+		} else {
+			run = 1
+		}
+		if run > streaks.Longest {
+			streaks.Longest = run
+		}
+		previous = period
+	}
+
+	last := periods[len(periods)-1]
+	streaks.LastActive = &last
+
+	current := periodStart(s.now(), interval, weekStart, loc)
+	if previous.Equal(current) || nextPeriod(previous, interval).Equal(current) {
+		streaks.Current = run
+	}
+
+	return streaks, nil
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockStatsRepository struct {
+	rows      []models.StatsRow
+	periods   []string
+	lastQuery models.StatsQuery
+}
+
+func (m *mockStatsRepository) query(q models.StatsQuery) ([]models.StatsRow, error) {
+	m.lastQuery = q
+	return m.rows, nil
+}
+
+func (m *mockStatsRepository) VolumeByMuscle(ctx context.Context, userID uuid.UUID, q models.StatsQuery) ([]models.StatsRow, error) {
+	return m.query(q)
+}
+
+func (m *mockStatsRepository) SetsByExercise(ctx context.Context, userID uuid.UUID, q models.StatsQuery) ([]models.StatsRow, error) {
+	return m.query(q)
+}
+
+func (m *mockStatsRepository) WorkoutCounts(ctx context.Context, userID uuid.UUID, q models.StatsQuery) ([]models.StatsRow, error) {
+	return m.query(q)
+}
+
+func (m *mockStatsRepository) AverageDurations(ctx context.Context, userID uuid.UUID, q models.StatsQuery) ([]models.StatsRow, error) {
+	return m.query(q)
+}
+
+func (m *mockStatsRepository) ActivePeriods(
+	ctx context.Context,
+	userID uuid.UUID,
+	interval models.StatsInterval,
+	timezone string,
+	weekStart models.WeekStart,
+) ([]string, error) {
+	return m.periods, nil
+}
+
+func newTestStatsService(repo *mockStatsRepository, now time.Time) StatsService {
+	service := NewStatsService(repo).(*statsService)
+	service.now = func() time.Time { return now }
+	return service
+}
+
+func date(value string) time.Time {
+	t, _ := time.Parse(models.PeriodLayout, value)
+	return t
+}
+
+func TestStatsService_TimeSeriesIsZeroFilled(t *testing.T) {
+	repo := &mockStatsRepository{rows: []models.StatsRow{
+		{Key: "chest", Label: "chest", Period: "2025-01-06", Value: 5000},
+		{Key: "back", Label: "back", Period: "2025-01-20", Value: 7000},
+		{Key: "chest", Label: "chest", Period: "2025-01-20", Value: 5500},
+	}}
+	service := newTestStatsService(repo, date("2025-02-01"))
+
+	result, err := service.Volume(context.Background(), uuid.New(), models.StatsQuery{
+		From: date("2025-01-08"), To: date("2025-01-26"), Interval: models.StatsIntervalWeek,
+	})
+	require.NoError(t, err)
+
+	// The range starts mid-week, so the first bucket is that week's Monday.
+	assert.Equal(t, []string{"2025-01-06", "2025-01-13", "2025-01-20"}, result.Periods)
+	assert.Equal(t, "kg", result.Unit)
+	assert.Equal(t, date("2025-01-27"), repo.lastQuery.To.UTC(), "to is inclusive")
+
+	require.Len(t, result.Series, 2)
+	assert.Equal(t, "back", result.Series[0].Key)
+	assert.Equal(t, []float64{0, 0, 7000}, seriesValues(result.Series[0]))
+	assert.Equal(t, []float64{5000, 0, 5500}, seriesValues(result.Series[1]))
+}
+
+func TestStatsService_WeekStart(t *testing.T) {
+	repo := &mockStatsRepository{rows: []models.StatsRow{
+		{Key: "workouts", Label: "Workouts", Period: "2025-01-05", Value: 2},
+		{Key: "workouts", Label: "Workouts", Period: "2025-01-12", Value: 3},
+	}}
+	service := newTestStatsService(repo, date("2025-02-01"))
+
+	// 2025-01-08 is a Wednesday.
+	result, err := service.Frequency(context.Background(), uuid.New(), models.StatsQuery{
+		From: date("2025-01-08"), To: date("2025-01-18"), Interval: models.StatsIntervalWeek,
+		WeekStart: models.WeekStartSunday,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2025-01-05", "2025-01-12"}, result.Periods)
+	assert.Equal(t, []float64{2, 3}, seriesValues(result.Series[0]))
+
+	result, err = service.Frequency(context.Background(), uuid.New(), models.StatsQuery{
+		From: date("2025-01-08"), To: date("2025-01-18"), Interval: models.StatsIntervalWeek,
+		WeekStart: models.WeekStartSaturday,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2025-01-04", "2025-01-11", "2025-01-18"}, result.Periods)
+
+	repo.periods = []string{"2025-01-19", "2025-01-26"}
+	streaks, err := service.Streaks(context.Background(), uuid.New(), models.StatsIntervalWeek, "", models.WeekStartSunday)
+	require.NoError(t, err)
+	assert.Equal(t, 2, streaks.Current, "Saturday 2025-02-01 is still in the week starting Sunday 2025-01-26")
+}
+
+func seriesValues(series models.TimeSeries) []float64 {
+	values := make([]float64, 0, len(series.Points))
+	for _, point := range series.Points {
+		values = append(values, point.Value)
+	}
+	return values
+}
+
+func TestStatsService_DefaultsAndValidation(t *testing.T) {
+	repo := &mockStatsRepository{}
+	service := newTestStatsService(repo, time.Date(2025, 3, 15, 10, 0, 0, 0, time.UTC))
+	ctx := context.Background()
+
+	result, err := service.Frequency(ctx, uuid.New(), models.StatsQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, models.StatsIntervalWeek, result.Interval)
+	assert.Len(t, result.Periods, 13)
+	assert.Equal(t, "2025-03-15", result.To)
+	require.Len(t, result.Series, 1, "frequency always has its series")
+	assert.Equal(t, "workouts", result.Series[0].Key)
+
+	monthly, err := service.Duration(ctx, uuid.New(), models.StatsQuery{Interval: models.StatsIntervalMonth})
+	require.NoError(t, err)
+	assert.Equal(t, "2024-04-01", monthly.Periods[0])
+	assert.Len(t, monthly.Periods, 12)
+
+	_, err = service.Sets(ctx, uuid.New(), models.StatsQuery{From: date("2025-02-01"), To: date("2025-01-01")})
+	assert.ErrorIs(t, err, ErrInvalidStatsRange)
+
+	_, err = service.Sets(ctx, uuid.New(), models.StatsQuery{
+		From: date("2020-01-01"), To: date("2025-01-01"), Interval: models.StatsIntervalDay,
+	})
+	assert.ErrorIs(t, err, ErrInvalidStatsRange)
+
+	_, err = service.Sets(ctx, uuid.New(), models.StatsQuery{Timezone: "Mars/Olympus"})
+	assert.ErrorIs(t, err, ErrInvalidTimezone)
+}
+
+func TestStatsService_Streaks(t *testing.T) {
+	repo := &mockStatsRepository{periods: []string{
+		"2025-01-06", "2025-01-13", "2025-01-20", "2025-01-27",
+		"2025-02-17", "2025-02-24",
+	}}
+	ctx := context.Background()
+
+	// Wednesday of the week after the last active one: the streak is still alive.
+	service := newTestStatsService(repo, date("2025-03-05"))
+	streaks, err := service.Streaks(ctx, uuid.New(), models.StatsIntervalWeek, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, 4, streaks.Longest)
+	assert.Equal(t, 2, streaks.Current)
+	assert.Equal(t, "2025-02-24", *streaks.LastActive)
+
+	service = newTestStatsService(repo, date("2025-03-12"))
+	streaks, err = service.Streaks(ctx, uuid.New(), models.StatsIntervalWeek, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, 0, streaks.Current, "a whole week without training ends the streak")
+
+	empty, err := newTestStatsService(&mockStatsRepository{}, date("2025-03-12")).
+		Streaks(ctx, uuid.New(), models.StatsIntervalDay, "", "")
+	require.NoError(t, err)
+	assert.Zero(t, empty.Longest)
+	assert.Nil(t, empty.LastActive)
+}
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTemplateNotFound = errors.New("template not found")
+	ErrTemplateInUse    = errors.New("template is used by a program")
+)
+
+type TemplateService interface {
+	Create(ctx context.Context, userID uuid.UUID, req *models.CreateTemplateRequest) (*models.WorkoutTemplate, error)
+	Get(ctx context.Context, userID, templateID uuid.UUID) (*models.WorkoutTemplate, error)
+	List(ctx context.Context, userID uuid.UUID, page models.Pagination) ([]*models.WorkoutTemplate, error)
+	Update(
+		ctx context.Context,
+		userID, templateID uuid.UUID,
+		req *models.UpdateTemplateRequest,
+	) (*models.WorkoutTemplate, error)
+	Delete(ctx context.Context, userID, templateID uuid.UUID) error
+	Start(ctx context.Context, userID, templateID uuid.UUID) (*models.Workout, error)
+}
+
+type templateService struct {
+	templateRepo repositories.TemplateRepository
+	workoutRepo  repositories.WorkoutRepository
+	catalogRepo  repositories.ExerciseRepository
+}
+
+func NewTemplateService(
+	templateRepo repositories.TemplateRepository,
+	workoutRepo repositories.WorkoutRepository,
+	catalogRepo repositories.ExerciseRepository,
+) TemplateService {
+	return &templateService{
+		templateRepo: templateRepo,
+		workoutRepo:  workoutRepo,
+		catalogRepo:  catalogRepo,
+	}
+}
+
+// buildExercises turns request inputs into positioned template exercises,
+// resolving catalog references and defaulting names from the catalog.
+func (s *templateService) buildExercises(
+	ctx context.Context,
+	userID, templateID uuid.UUID,
+	inputs []models.TemplateExerciseInput,
+) ([]*models.TemplateExercise, error) {
+	exercises := make([]*models.TemplateExercise, 0, len(inputs))
+	for i, input := range inputs {
+		name := input.Name
+		if input.ExerciseID != nil {
+			catalogExercise, err := s.catalogRepo.GetByID(ctx, *input.ExerciseID, userID)
+			if errors.Is(err, repositories.ErrNotFound) {
+				return nil, ErrExerciseNotFound
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get catalog exercise: %w", err)
+			}
+			if name == "" {
+				name = catalogExercise.Name
+			}
+		}
+
+		weightUnit := input.WeightUnit
+		if weightUnit == "" {
+			weightUnit = models.WeightUnitKg
+		}
+
+		exercises = append(exercises, &models.TemplateExercise{
+			ID:           uuid.New(),
+			TemplateID:   templateID,
+			ExerciseID:   input.ExerciseID,
+			Name:         name,
+			Position:     i + 1,
+			TargetSets:   input.TargetSets,
+			TargetReps:   input.TargetReps,
+			TargetWeight: input.TargetWeight,
+			WeightUnit:   weightUnit,
+			RestSeconds:  input.RestSeconds,
+			Notes:        input.Notes,
+		})
+	}
+	return exercises, nil
+}
+
+func (s *templateService) Create(
+	ctx context.Context,
+	userID uuid.UUID,
+	req *models.CreateTemplateRequest,
+) (*models.WorkoutTemplate, error) {
+	now := time.Now()
+	template := &models.WorkoutTemplate{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      req.Name,
+		Notes:     req.Notes,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	exercises, err := s.buildExercises(ctx, userID, template.ID, req.Exercises)
+	if err != nil {
+		return nil, err
+	}
+	template.Exercises = exercises
+
+	if err := s.templateRepo.Create(ctx, template); err != nil {
+		return nil, fmt.Errorf("failed to create template: %w", err)
+	}
+
+	return template, nil
+}
+
+func (s *templateService) Get(ctx context.Context, userID, templateID uuid.UUID) (*models.WorkoutTemplate, error) {
+	template, err := s.templateRepo.GetByID(ctx, templateID, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrTemplateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+
+	return template, nil
+}
+
+func (s *templateService) List(ctx context.Context, userID uuid.UUID, page models.Pagination) ([]*models.WorkoutTemplate, error) {
+	page = page.Normalize()
+
+	templates, err := s.templateRepo.ListByUserID(ctx, userID, page.Limit, page.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+func (s *templateService) Update(
+	ctx context.Context,
+	userID, templateID uuid.UUID,
+	req *models.UpdateTemplateRequest,
+) (*models.WorkoutTemplate, error) {
+	template, err := s.Get(ctx, userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		template.Name = *req.Name
+	}
+	if req.Notes != nil {
+		template.Notes = *req.Notes
+	}
+	if req.Exercises != nil {
+		exercises, err := s.buildExercises(ctx, userID, template.ID, *req.Exercises)
+		if err != nil {
+			return nil, err
+		}
+		template.Exercises = exercises
+	}
+	template.UpdatedAt = time.Now()
+
+	err = s.templateRepo.Update(ctx, template)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrTemplateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update template: %w", err)
+	}
+
+	return template, nil
+}
+
+func (s *templateService) Delete(ctx context.Context, userID, templateID uuid.UUID) error {
+	err := s.templateRepo.Delete(ctx, templateID, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrTemplateNotFound
+	}
+	if errors.Is(err, repositories.ErrReferenced) {
+		return ErrTemplateInUse
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+
+	return nil
+}
+
+// Start creates a workout starting now with one entry per template exercise,
+// each pre-filled with its target sets.
+func (s *templateService) Start(ctx context.Context, userID, templateID uuid.UUID) (*models.Workout, error) {
+	template, err := s.Get(ctx, userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	workout := workoutFromTemplate(userID, template, time.Now())
+	if err := s.workoutRepo.CreateWithExercises(ctx, workout); err != nil {
+		return nil, fmt.Errorf("failed to start workout: %w", err)
+	}
+
+	return workout, nil
+}
+
+func workoutFromTemplate(userID uuid.UUID, template *models.WorkoutTemplate, now time.Time) *models.Workout {
+	workout := &models.Workout{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Title:     template.Name,
+		StartedAt: now,
+		Notes:     template.Notes,
+		Exercises: make([]*models.WorkoutExercise, 0, len(template.Exercises)),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	for _, planned := range template.Exercises {
+		exercise := &models.WorkoutExercise{
+			ID:         uuid.New(),
+			WorkoutID:  workout.ID,
+			ExerciseID: planned.ExerciseID,
+			Name:       planned.Name,
+			Position:   planned.Position,
+			Notes:      planned.Notes,
+			Sets:       make([]*models.ExerciseSet, 0, planned.TargetSets),
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+
+		weight := 0.0
+		if planned.TargetWeight != nil {
+			weight = *planned.TargetWeight
+		}
+		for i := 0; i < planned.TargetSets; i++ {
+			exercise.Sets = append(exercise.Sets, &models.ExerciseSet{
+				ID:                uuid.New(),
+				WorkoutExerciseID: exercise.ID,
+				Position:          i + 1,
+				Reps:              planned.TargetReps,
+				Weight:            weight,
+				WeightUnit:        planned.WeightUnit,
+				RestSeconds:       planned.RestSeconds,
+				CreatedAt:         now,
+				UpdatedAt:         now,
+			})
+		}
+
+		workout.Exercises = append(workout.Exercises, exercise)
+	}
+
+	return workout
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTemplateRepository struct {
+	templates map[uuid.UUID]*models.WorkoutTemplate
+}
+
+func newMockTemplateRepository() *mockTemplateRepository {
+	return &mockTemplateRepository{templates: make(map[uuid.UUID]*models.WorkoutTemplate)}
+}
+
+func (m *mockTemplateRepository) Create(ctx context.Context, template *models.WorkoutTemplate) error {
+	m.templates[template.ID] = template
+	return nil
+}
+
+func (m *mockTemplateRepository) GetByID(ctx context.Context, id, userID uuid.UUID) (*models.WorkoutTemplate, error) {
+	template, exists := m.templates[id]
+	if !exists || template.UserID != userID {
+		return nil, repositories.ErrNotFound
+	}
+	copied := *template
+	return &copied, nil
+}
+
+func (m *mockTemplateRepository) ListByUserID(
+	ctx context.Context,
+	userID uuid.UUID,
+	limit, offset int,
+) ([]*models.WorkoutTemplate, error) {
+	var templates []*models.WorkoutTemplate
+	for _, template := range m.templates {
+		if template.UserID == userID {
+			templates = append(templates, template)
+		}
+	}
+	return templates, nil
+}
+
+func (m *mockTemplateRepository) Update(ctx context.Context, template *models.WorkoutTemplate) error {
+	existing, exists := m.templates[template.ID]
+	if !exists || existing.UserID != template.UserID {
+		return repositories.ErrNotFound
+	}
+	m.templates[template.ID] = template
+	return nil
+}
+
+func (m *mockTemplateRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	template, exists := m.templates[id]
+	if !exists || template.UserID != userID {
+		return repositories.ErrNotFound
+	}
+	delete(m.templates, id)
+	return nil
+}
+
+func newTestTemplateService(seed ...*models.Exercise) (TemplateService, *mockWorkoutRepository) {
+	workoutRepo := newMockWorkoutRepository()
+	return NewTemplateService(newMockTemplateRepository(), workoutRepo, newMockExerciseRepository(seed...)), workoutRepo
+}
+
+func TestTemplateService_CreateAndUpdate(t *testing.T) {
+	bench := &models.Exercise{ID: uuid.New(), Name: "Bench Press"}
+	service, _ := newTestTemplateService(bench)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	weight := 80.0
+	template, err := service.Create(ctx, userID, &models.CreateTemplateRequest{
+		Name: "Upper A",
+		Exercises: []models.TemplateExerciseInput{
+			{ExerciseID: &bench.ID, TargetSets: 3, TargetReps: 5, TargetWeight: &weight},
+			{Name: "Face pull", TargetSets: 3, TargetReps: 15},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, template.Exercises, 2)
+	assert.Equal(t, "Bench Press", template.Exercises[0].Name)
+	assert.Equal(t, 1, template.Exercises[0].Position)
+	assert.Equal(t, 2, template.Exercises[1].Position)
+	assert.Equal(t, models.WeightUnitKg, template.Exercises[1].WeightUnit)
+
+	missing := uuid.New()
+	_, err = service.Create(ctx, userID, &models.CreateTemplateRequest{
+		Name:      "Broken",
+		Exercises: []models.TemplateExerciseInput{{ExerciseID: &missing, TargetSets: 1, TargetReps: 1}},
+	})
+	assert.ErrorIs(t, err, ErrExerciseNotFound)
+
+	replacement := []models.TemplateExerciseInput{{Name: "Dips", TargetSets: 4, TargetReps: 10}}
+	updated, err := service.Update(ctx, userID, template.ID, &models.UpdateTemplateRequest{Exercises: &replacement})
+	require.NoError(t, err)
+	require.Len(t, updated.Exercises, 1)
+	assert.Equal(t, "Dips", updated.Exercises[0].Name)
+	assert.Equal(t, "Upper A", updated.Name)
+
+	_, err = service.Get(ctx, uuid.New(), template.ID)
+	assert.ErrorIs(t, err, ErrTemplateNotFound)
+	assert.ErrorIs(t, service.Delete(ctx, uuid.New(), template.ID), ErrTemplateNotFound)
+}
+
+func TestTemplateService_Start(t *testing.T) {
+	service, workoutRepo := newTestTemplateService()
+	ctx := context.Background()
+	userID := uuid.New()
+
+	weight := 100.0
+	template, err := service.Create(ctx, userID, &models.CreateTemplateRequest{
+		Name: "Lower A",
+		Exercises: []models.TemplateExerciseInput{
+			{Name: "Squat", TargetSets: 3, TargetReps: 5, TargetWeight: &weight},
+			{Name: "Plank", TargetSets: 2, TargetReps: 1},
+		},
+	})
+	require.NoError(t, err)
+
+	workout, err := service.Start(ctx, userID, template.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Lower A", workout.Title)
+	assert.Contains(t, workoutRepo.workouts, workout.ID)
+
+	require.Len(t, workout.Exercises, 2)
+	squat := workout.Exercises[0]
+	assert.Equal(t, "Squat", squat.Name)
+	assert.Equal(t, workout.ID, squat.WorkoutID)
+	require.Len(t, squat.Sets, 3)
+	for i, set := range squat.Sets {
+		assert.Equal(t, i+1, set.Position)
+		assert.Equal(t, 5, set.Reps)
+		assert.Equal(t, 100.0, set.Weight)
+		assert.Equal(t, squat.ID, set.WorkoutExerciseID)
+	}
+	assert.Equal(t, 0.0, workout.Exercises[1].Sets[0].Weight)
+
+	_, err = service.Start(ctx, uuid.New(), template.ID)
+	assert.ErrorIs(t, err, ErrTemplateNotFound)
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
+)
+
+var ErrTokenRevoked = errors.New("token has been revoked")
+
+// TokenRevocationService tracks access tokens that must stop working before
+// they expire. Checks are answered from memory; the cache is reloaded from
+// the database every sync interval, so revocations made by other instances,
+// and tokens_valid_after cutoffs, take effect within that interval.
+type TokenRevocationService interface {
+	// Revoke blocks the access token with claims until it expires.
+	Revoke(ctx context.Context, claims *Claims) error
+	// RevokeUser rejects every access token the user was issued before at by
+	// moving their tokens_valid_after forward. It never moves it back.
+	RevokeUser(ctx context.Context, userID uuid.UUID, at time.Time) error
+	// Check returns ErrTokenRevoked if the token was revoked or was issued
+	// before its user's tokens_valid_after.
+	Check(ctx context.Context, claims *Claims) error
+	// PruneExpired deletes revocations of tokens that have expired anyway. It
+	// is meant to run periodically in the background.
+	PruneExpired(ctx context.Context) error
+}
+
+type tokenRevocationService struct {
+	repo         repositories.TokenRevocationRepository
+	syncInterval time.Duration
+	now          func() time.Time
+	// syncs makes concurrent checks that find the cache stale share one reload.
+	syncs singleflight.Group
+
+	mu         sync.RWMutex
+	syncedAt   time.Time
+	revoked    map[uuid.UUID]time.Time
+	validAfter map[uuid.UUID]time.Time
+}
+
+func NewTokenRevocationService(repo repositories.TokenRevocationRepository, syncInterval time.Duration) TokenRevocationService {
+	return &tokenRevocationService{
+		repo:         repo,
+		syncInterval: syncInterval,
+		now:          time.Now,
+		revoked:      make(map[uuid.UUID]time.Time),
+		validAfter:   make(map[uuid.UUID]time.Time),
+	}
+}
+
+func (s *tokenRevocationService) Revoke(ctx context.Context, claims *Claims) error {
+	jti, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return fmt.Errorf("token has no valid jti: %w", err)
+	}
+	expiresAt := s.now().Add(accessTokenTTL)
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+
+	err = s.repo.Revoke(ctx, &models.RevokedAccessToken{
+		JTI:       jti,
+		UserID:    claims.UserID,
+		ExpiresAt: expiresAt,
+		CreatedAt: s.now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.revoked[jti] = expiresAt
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *tokenRevocationService) RevokeUser(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	if err := s.repo.SetTokensValidAfter(ctx, userID, at); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	if at.After(s.validAfter[userID]) {
+		s.validAfter[userID] = at
+	}
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *tokenRevocationService) Check(ctx context.Context, claims *Claims) error {
+	s.mu.RLock()
+	stale := s.now().Sub(s.syncedAt) >= s.syncInterval
+	s.mu.RUnlock()
+
+	if stale {
+		// The reload is shared, so one caller going away must not cancel it for the rest.
+		syncCtx := context.WithoutCancel(ctx)
+		if _, err, _ := s.syncs.Do("sync", func() (interface{}, error) { return nil, s.sync(syncCtx) }); err != nil {
+			return err
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Tokens issued before jti claims were introduced can only be cut off per user.
+	if jti, err := uuid.Parse(claims.ID); err == nil {
+		if _, revoked := s.revoked[jti]; revoked {
+			return ErrTokenRevoked
+		}
+	}
+
+	if cutoff, ok := s.validAfter[claims.UserID]; ok {
+		if claims.IssuedAt == nil || claims.IssuedAt.Time.Before(cutoff) {
+			return ErrTokenRevoked
+		}
+	}
+
+	return nil
+}
+
+// sync reloads the cache from the database without holding s.mu, then swaps
+// it in. Revocations only ever accumulate until they expire, so entries this
+// instance added while the reload ran are merged in rather than lost. Both
+// sets only hold entries younger than the token lifetime, so they stay small.
+func (s *tokenRevocationService) sync(ctx context.Context) error {
+	now := s.now()
+	since := now.Add(-accessTokenTTL)
+
+	tokens, err := s.repo.ListActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load revoked tokens: %w", err)
+	}
+	validAfter, err := s.repo.ListTokensValidAfter(ctx, since)
+	if err != nil {
+		return fmt.Errorf("failed to load token cutoffs: %w", err)
+	}
+
+	revoked := make(map[uuid.UUID]time.Time, len(tokens))
+	for _, token := range tokens {
+		revoked[token.JTI] = token.ExpiresAt
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for jti, expiresAt := range s.revoked {
+		if expiresAt.After(now) {
+			revoked[jti] = expiresAt
+		}
+	}
+	for userID, cutoff := range s.validAfter {
+		if cutoff.After(since) && cutoff.After(validAfter[userID]) {
+			validAfter[userID] = cutoff
+		}
+	}
+
+	s.revoked = revoked
+	s.validAfter = validAfter
+	s.syncedAt = now
+
+	return nil
+}
+
+func (s *tokenRevocationService) PruneExpired(ctx context.Context) error {
+	return s.repo.DeleteExpired(ctx)
+}
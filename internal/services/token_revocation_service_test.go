@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTokenRevocationRepository struct {
+	tokens     map[uuid.UUID]*models.RevokedAccessToken
+	validAfter map[uuid.UUID]time.Time
+	syncs      int
+	prunes     int
+}
+
+func newMockTokenRevocationRepository() *mockTokenRevocationRepository {
+	return &mockTokenRevocationRepository{
+		tokens:     make(map[uuid.UUID]*models.RevokedAccessToken),
+		validAfter: make(map[uuid.UUID]time.Time),
+	}
+}
+
+func (m *mockTokenRevocationRepository) Revoke(ctx context.Context, token *models.RevokedAccessToken) error {
+	m.tokens[token.JTI] = token
+	return nil
+}
+
+func (m *mockTokenRevocationRepository) ListActive(ctx context.Context) ([]*models.RevokedAccessToken, error) {
+	m.syncs++
+	tokens := make([]*models.RevokedAccessToken, 0, len(m.tokens))
+	for _, token := range m.tokens {
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+func (m *mockTokenRevocationRepository) ListTokensValidAfter(ctx context.Context, since time.Time) (map[uuid.UUID]time.Time, error) {
+	cutoffs := make(map[uuid.UUID]time.Time, len(m.validAfter))
+	for userID, cutoff := range m.validAfter {
+		if cutoff.After(since) {
+			cutoffs[userID] = cutoff
+		}
+	}
+	return cutoffs, nil
+}
+
+func (m *mockTokenRevocationRepository) SetTokensValidAfter(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	if at.After(m.validAfter[userID]) {
+		m.validAfter[userID] = at
+	}
+	return nil
+}
+
+func (m *mockTokenRevocationRepository) DeleteExpired(ctx context.Context) error {
+	m.prunes++
+	return nil
+}
+
+// newTestTokenRevocations returns a revocation service over an in-memory repository.
+func newTestTokenRevocations() TokenRevocationService {
+	return NewTokenRevocationService(newMockTokenRevocationRepository(), time.Minute)
+}
+
+// assertTokensCutOff checks that the user's access tokens issued before now are rejected.
+func assertTokensCutOff(t *testing.T, revocations TokenRevocationService, userID uuid.UUID) {
+	t.Helper()
+	claims := newAccessClaims(userID, time.Now().Add(-time.Minute))
+	assert.ErrorIs(t, revocations.Check(context.Background(), claims), ErrTokenRevoked, "earlier access tokens are cut off")
+}
+
+func newAccessClaims(userID uuid.UUID, issuedAt time.Time) *Claims {
+	return &Claims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(issuedAt.Add(accessTokenTTL)),
+		},
+	}
+}
+
+func TestTokenRevocationService_Revoke(t *testing.T) {
+	repo := newMockTokenRevocationRepository()
+	service := NewTokenRevocationService(repo, time.Minute)
+	userID := uuid.New()
+	revoked := newAccessClaims(userID, time.Now())
+	other := newAccessClaims(userID, time.Now())
+
+	require.NoError(t, service.Check(context.Background(), revoked))
+	require.NoError(t, service.Revoke(context.Background(), revoked))
+
+	assert.ErrorIs(t, service.Check(context.Background(), revoked), ErrTokenRevoked,
+		"the revoking instance sees the revocation without waiting for a sync")
+	assert.NoError(t, service.Check(context.Background(), other), "other tokens of the user stay valid")
+	assert.Len(t, repo.tokens, 1)
+
+	err := service.Revoke(context.Background(), &Claims{UserID: userID})
+	assert.Error(t, err, "tokens without a jti cannot be revoked individually")
+}
+
+func TestTokenRevocationService_Sync(t *testing.T) {
+	now := time.Now()
+	repo := newMockTokenRevocationRepository()
+	service := NewTokenRevocationService(repo, time.Minute).(*tokenRevocationService)
+	service.now = func() time.Time { return now }
+
+	userID := uuid.New()
+	claims := newAccessClaims(userID, now.Add(-time.Minute))
+	require.NoError(t, service.Check(context.Background(), claims))
+
+	// Another instance revokes the token and cuts off the user's older tokens.
+	jti, err := uuid.Parse(claims.ID)
+	require.NoError(t, err)
+	repo.tokens[jti] = &models.RevokedAccessToken{JTI: jti, UserID: userID, ExpiresAt: claims.ExpiresAt.Time}
+	repo.validAfter[userID] = now.Truncate(time.Second)
+
+	assert.NoError(t, service.Check(context.Background(), claims), "the cache is reused within the sync interval")
+	assert.Equal(t, 1, repo.syncs)
+
+	now = now.Add(time.Minute)
+	assert.ErrorIs(t, service.Check(context.Background(), claims), ErrTokenRevoked)
+	assert.Equal(t, 2, repo.syncs)
+
+	older := newAccessClaims(userID, now.Add(-2*time.Minute))
+	assert.ErrorIs(t, service.Check(context.Background(), older), ErrTokenRevoked, "tokens issued before the cutoff are rejected")
+
+	newer := newAccessClaims(userID, now)
+	assert.NoError(t, service.Check(context.Background(), newer), "tokens issued after the cutoff are accepted")
+
+	legacy := &Claims{UserID: uuid.New()}
+	assert.NoError(t, service.Check(context.Background(), legacy), "tokens without a jti are only subject to cutoffs")
+}
+
+func TestTokenRevocationService_RevokeUser(t *testing.T) {
+	now := time.Now()
+	repo := newMockTokenRevocationRepository()
+	service := NewTokenRevocationService(repo, time.Minute).(*tokenRevocationService)
+	service.now = func() time.Time { return now }
+
+	userID := uuid.New()
+	claims := newAccessClaims(userID, now.Add(-time.Minute))
+	require.NoError(t, service.Check(context.Background(), claims))
+
+	require.NoError(t, service.RevokeUser(context.Background(), userID, now))
+	assert.Equal(t, now, repo.validAfter[userID])
+	assert.ErrorIs(t, service.Check(context.Background(), claims), ErrTokenRevoked,
+		"the revoking instance applies the cutoff without waiting for a sync")
+	assert.Equal(t, 1, repo.syncs)
+
+	require.NoError(t, service.RevokeUser(context.Background(), userID, now.Add(-time.Hour)))
+	assert.Equal(t, now, repo.validAfter[userID], "the cutoff never moves back")
+
+	// A reload that started before the cutoff was stored does not drop it.
+	delete(repo.validAfter, userID)
+	now = now.Add(time.Minute)
+	assert.ErrorIs(t, service.Check(context.Background(), claims), ErrTokenRevoked)
+	assert.Equal(t, 2, repo.syncs)
+
+	assert.Zero(t, repo.prunes, "checks never prune")
+	require.NoError(t, service.PruneExpired(context.Background()))
+	assert.Equal(t, 1, repo.prunes)
+}
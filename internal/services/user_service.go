@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var ErrUserNotFound = errors.New("user not found")
+
+type UserService interface {
+	GetProfile(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	UpdateProfile(ctx context.Context, userID uuid.UUID, req *models.UpdateProfileRequest) (*models.User, error)
+}
+
+type userService struct {
+	userRepo repositories.UserRepository
+}
+
+func NewUserService(userRepo repositories.UserRepository) UserService {
+	return &userService{
+		userRepo: userRepo,
+	}
+}
+
+func (s *userService) GetProfile(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (s *userService) UpdateProfile(
+	ctx context.Context,
+	userID uuid.UUID,
+	req *models.UpdateProfileRequest,
+) (*models.User, error) {
+	user, err := s.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DisplayName != nil {
+		user.DisplayName = *req.DisplayName
+	}
+	if req.BirthDate != nil {
+		user.BirthDate = req.BirthDate
+		if *req.BirthDate == "" {
+			user.BirthDate = nil
+		}
+	}
+	if req.Sex != nil {
+		user.Sex = req.Sex
+		if *req.Sex == "" {
+			user.Sex = nil
+		}
+	}
+	if req.HeightCm != nil {
+		user.HeightCm = req.HeightCm
+		if *req.HeightCm == 0 {
+			user.HeightCm = nil
+		}
+	}
+	if req.WeightUnit != nil {
+		user.WeightUnit = *req.WeightUnit
+	}
+	if req.Timezone != nil {
+		if _, err := loadLocation(*req.Timezone); err != nil {
+			return nil, err
+		}
+		user.Timezone = *req.Timezone
+		if user.Timezone == "" {
+			user.Timezone = models.DefaultTimezone
+		}
+	}
+	if req.Locale != nil {
+		user.Locale = *req.Locale
+	}
+	if req.WeekStart != nil {
+		user.WeekStart = *req.WeekStart
+	}
+	user.UpdatedAt = time.Now()
+
+	err = s.userRepo.Update(ctx, user)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return user, nil
+}
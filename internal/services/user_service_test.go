@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUserService(t *testing.T) (UserService, *models.User) {
+	t.Helper()
+
+	heightCm := 180.0
+	sex := models.SexMale
+	user := &models.User{
+		ID:         uuid.New(),
+		Email:      "test@example.com",
+		HeightCm:   &heightCm,
+		Sex:        &sex,
+		WeightUnit: models.WeightUnitKg,
+		Timezone:   models.DefaultTimezone,
+		Locale:     models.DefaultLocale,
+		WeekStart:  models.WeekStartMonday,
+	}
+	repo := &mockUserRepository{users: map[string]*models.User{user.Email: user}}
+	return NewUserService(repo), user
+}
+
+func TestUserService_GetProfile(t *testing.T) {
+	service, user := newTestUserService(t)
+
+	found, err := service.GetProfile(context.Background(), user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, user.Email, found.Email)
+
+	_, err = service.GetProfile(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+func TestUserService_UpdateProfile(t *testing.T) {
+	t.Run("PartialUpdate", func(t *testing.T) {
+		service, user := newTestUserService(t)
+		name := "Alex"
+		unit := models.WeightUnitLb
+		timezone := "Europe/Berlin"
+		birthDate := "1990-05-17"
+
+		updated, err := service.UpdateProfile(context.Background(), user.ID, &models.UpdateProfileRequest{
+			DisplayName: &name,
+			WeightUnit:  &unit,
+			Timezone:    &timezone,
+			BirthDate:   &birthDate,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Alex", updated.DisplayName)
+		assert.Equal(t, models.WeightUnitLb, updated.WeightUnit)
+		assert.Equal(t, "Europe/Berlin", updated.Timezone)
+		require.NotNil(t, updated.BirthDate)
+		assert.Equal(t, "1990-05-17", *updated.BirthDate)
+		assert.Equal(t, models.DefaultLocale, updated.Locale)
+		require.NotNil(t, updated.HeightCm)
+	})
+
+	t.Run("ClearsOptionalFields", func(t *testing.T) {
+		service, user := newTestUserService(t)
+		zero := 0.0
+		noSex := models.Sex("")
+
+		updated, err := service.UpdateProfile(context.Background(), user.ID, &models.UpdateProfileRequest{
+			HeightCm: &zero,
+			Sex:      &noSex,
+		})
+		require.NoError(t, err)
+		assert.Nil(t, updated.HeightCm)
+		assert.Nil(t, updated.Sex)
+	})
+
+	t.Run("RejectsUnknownTimezone", func(t *testing.T) {
+		service, user := newTestUserService(t)
+		timezone := "Mars/Olympus"
+
+		_, err := service.UpdateProfile(context.Background(), user.ID, &models.UpdateProfileRequest{Timezone: &timezone})
+		assert.ErrorIs(t, err, ErrInvalidTimezone)
+	})
+}
@@ -0,0 +1,352 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWorkoutExerciseNotFound = errors.New("workout exercise not found")
+	ErrExerciseSetNotFound     = errors.New("exercise set not found")
+)
+
+type WorkoutExerciseService interface {
+	AddExercise(
+		ctx context.Context,
+		userID, workoutID uuid.UUID,
+		req *models.CreateWorkoutExerciseRequest,
+	) (*models.WorkoutExercise, error)
+	ListExercises(ctx context.Context, userID, workoutID uuid.UUID) ([]*models.WorkoutExercise, error)
+	UpdateExercise(
+		ctx context.Context,
+		userID, workoutID, exerciseID uuid.UUID,
+		req *models.UpdateWorkoutExerciseRequest,
+	) (*models.WorkoutExercise, error)
+	RemoveExercise(ctx context.Context, userID, workoutID, exerciseID uuid.UUID) error
+
+	AddSet(ctx context.Context, userID, workoutID, exerciseID uuid.UUID, req *models.CreateExerciseSetRequest) (*models.ExerciseSet, error)
+	ListSets(ctx context.Context, userID, workoutID, exerciseID uuid.UUID) ([]*models.ExerciseSet, error)
+	UpdateSet(
+		ctx context.Context,
+		userID, workoutID, exerciseID, setID uuid.UUID,
+		req *models.UpdateExerciseSetRequest,
+	) (*models.ExerciseSet, error)
+	RemoveSet(ctx context.Context, userID, workoutID, exerciseID, setID uuid.UUID) error
+}
+
+type workoutExerciseService struct {
+	workoutRepo  repositories.WorkoutRepository
+	exerciseRepo repositories.WorkoutExerciseRepository
+	setRepo      repositories.ExerciseSetRepository
+	catalogRepo  repositories.ExerciseRepository
+	records      RecordService
+}
+
+func NewWorkoutExerciseService(
+	workoutRepo repositories.WorkoutRepository,
+	exerciseRepo repositories.WorkoutExerciseRepository,
+	setRepo repositories.ExerciseSetRepository,
+	catalogRepo repositories.ExerciseRepository,
+	records RecordService,
+) WorkoutExerciseService {
+	return &workoutExerciseService{
+		workoutRepo:  workoutRepo,
+		exerciseRepo: exerciseRepo,
+		setRepo:      setRepo,
+		catalogRepo:  catalogRepo,
+		records:      records,
+	}
+}
+
+func (s *workoutExerciseService) getWorkout(ctx context.Context, userID, workoutID uuid.UUID) (*models.Workout, error) {
+	workout, err := s.workoutRepo.GetByID(ctx, workoutID, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrWorkoutNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workout: %w", err)
+	}
+	return workout, nil
+}
+
+func (s *workoutExerciseService) ensureWorkout(ctx context.Context, userID, workoutID uuid.UUID) error {
+	_, err := s.getWorkout(ctx, userID, workoutID)
+	return err
+}
+
+func (s *workoutExerciseService) getExercise(
+	ctx context.Context,
+	userID, workoutID, exerciseID uuid.UUID,
+) (*models.Workout, *models.WorkoutExercise, error) {
+	workout, err := s.getWorkout(ctx, userID, workoutID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	exercise, err := s.exerciseRepo.GetByID(ctx, exerciseID, workoutID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, nil, ErrWorkoutExerciseNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get workout exercise: %w", err)
+	}
+
+	return workout, exercise, nil
+}
+
+func (s *workoutExerciseService) AddExercise(
+	ctx context.Context,
+	userID, workoutID uuid.UUID,
+	req *models.CreateWorkoutExerciseRequest,
+) (*models.WorkoutExercise, error) {
+	if err := s.ensureWorkout(ctx, userID, workoutID); err != nil {
+		return nil, err
+	}
+
+	name := req.Name
+	if req.ExerciseID != nil {
+		catalogExercise, err := s.catalogRepo.GetByID(ctx, *req.ExerciseID, userID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrExerciseNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get catalog exercise: %w", err)
+		}
+		if name == "" {
+			name = catalogExercise.Name
+		}
+	}
+
+	now := time.Now()
+	exercise := &models.WorkoutExercise{
+		ID:         uuid.New(),
+		WorkoutID:  workoutID,
+		ExerciseID: req.ExerciseID,
+		Name:       name,
+		Notes:      req.Notes,
+		Sets:       []*models.ExerciseSet{},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := s.exerciseRepo.Create(ctx, exercise); err != nil {
+		return nil, fmt.Errorf("failed to add exercise: %w", err)
+	}
+
+	return exercise, nil
+}
+
+func (s *workoutExerciseService) ListExercises(ctx context.Context, userID, workoutID uuid.UUID) ([]*models.WorkoutExercise, error) {
+	if err := s.ensureWorkout(ctx, userID, workoutID); err != nil {
+		return nil, err
+	}
+
+	exercises, err := s.exerciseRepo.ListByWorkoutID(ctx, workoutID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exercises: %w", err)
+	}
+
+	sets, err := s.setRepo.ListByWorkoutID(ctx, workoutID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sets: %w", err)
+	}
+
+	byExercise := make(map[uuid.UUID]*models.WorkoutExercise, len(exercises))
+	for _, exercise := range exercises {
+		exercise.Sets = []*models.ExerciseSet{}
+		byExercise[exercise.ID] = exercise
+	}
+	for _, set := range sets {
+		if exercise, ok := byExercise[set.WorkoutExerciseID]; ok {
+			exercise.Sets = append(exercise.Sets, set)
+		}
+	}
+
+	return exercises, nil
+}
+
+func (s *workoutExerciseService) UpdateExercise(
+	ctx context.Context,
+	userID, workoutID, exerciseID uuid.UUID,
+	req *models.UpdateWorkoutExerciseRequest,
+) (*models.WorkoutExercise, error) {
+	_, exercise, err := s.getExercise(ctx, userID, workoutID, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		exercise.Name = *req.Name
+	}
+	if req.Notes != nil {
+		exercise.Notes = *req.Notes
+	}
+	if req.Position != nil {
+		exercise.Position = *req.Position
+	}
+	exercise.UpdatedAt = time.Now()
+
+	err = s.exerciseRepo.Update(ctx, exercise)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrWorkoutExerciseNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update exercise: %w", err)
+	}
+
+	return exercise, nil
+}
+
+func (s *workoutExerciseService) RemoveExercise(ctx context.Context, userID, workoutID, exerciseID uuid.UUID) error {
+	workout, exercise, err := s.getExercise(ctx, userID, workoutID, exerciseID)
+	if err != nil {
+		return err
+	}
+
+	err = s.exerciseRepo.Delete(ctx, exerciseID, workoutID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrWorkoutExerciseNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove exercise: %w", err)
+	}
+
+	if err := s.records.RedetectForExercise(ctx, userID, workout, exercise); err != nil {
+		return fmt.Errorf("failed to detect personal records: %w", err)
+	}
+
+	return nil
+}
+
+func (s *workoutExerciseService) AddSet(
+	ctx context.Context,
+	userID, workoutID, exerciseID uuid.UUID,
+	req *models.CreateExerciseSetRequest,
+) (*models.ExerciseSet, error) {
+	workout, exercise, err := s.getExercise(ctx, userID, workoutID, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	set := &models.ExerciseSet{
+		ID:                uuid.New(),
+		WorkoutExerciseID: exerciseID,
+		Reps:              req.Reps,
+		Weight:            req.Weight,
+		WeightUnit:        req.WeightUnit,
+		RPE:               req.RPE,
+		RestSeconds:       req.RestSeconds,
+		IsWarmup:          req.IsWarmup,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	if err := s.setRepo.Create(ctx, set); err != nil {
+		return nil, fmt.Errorf("failed to add set: %w", err)
+	}
+
+	set.NewRecords, err = s.records.DetectForSet(ctx, userID, workout, exercise, set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect personal records: %w", err)
+	}
+
+	return set, nil
+}
+
+func (s *workoutExerciseService) ListSets(ctx context.Context, userID, workoutID, exerciseID uuid.UUID) ([]*models.ExerciseSet, error) {
+	if _, _, err := s.getExercise(ctx, userID, workoutID, exerciseID); err != nil {
+		return nil, err
+	}
+
+	sets, err := s.setRepo.ListByWorkoutExerciseID(ctx, exerciseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sets: %w", err)
+	}
+
+	return sets, nil
+}
+
+func (s *workoutExerciseService) UpdateSet(
+	ctx context.Context,
+	userID, workoutID, exerciseID, setID uuid.UUID,
+	req *models.UpdateExerciseSetRequest,
+) (*models.ExerciseSet, error) {
+	workout, exercise, err := s.getExercise(ctx, userID, workoutID, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	set, err := s.setRepo.GetByID(ctx, setID, exerciseID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrExerciseSetNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get set: %w", err)
+	}
+
+	if req.Reps != nil {
+		set.Reps = *req.Reps
+	}
+	if req.Weight != nil {
+		set.Weight = *req.Weight
+	}
+	if req.WeightUnit != nil {
+		set.WeightUnit = *req.WeightUnit
+	}
+	if req.RPE != nil {
+		set.RPE = req.RPE
+	}
+	if req.RestSeconds != nil {
+		set.RestSeconds = req.RestSeconds
+	}
+	if req.IsWarmup != nil {
+		set.IsWarmup = *req.IsWarmup
+	}
+	if req.Position != nil {
+		set.Position = *req.Position
+	}
+	set.UpdatedAt = time.Now()
+
+	err = s.setRepo.Update(ctx, set)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrExerciseSetNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update set: %w", err)
+	}
+
+	set.NewRecords, err = s.records.DetectForSet(ctx, userID, workout, exercise, set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect personal records: %w", err)
+	}
+
+	return set, nil
+}
+
+func (s *workoutExerciseService) RemoveSet(ctx context.Context, userID, workoutID, exerciseID, setID uuid.UUID) error {
+	workout, exercise, err := s.getExercise(ctx, userID, workoutID, exerciseID)
+	if err != nil {
+		return err
+	}
+
+	err = s.setRepo.Delete(ctx, setID, exerciseID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrExerciseSetNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove set: %w", err)
+	}
+
+	if err := s.records.RedetectForExercise(ctx, userID, workout, exercise); err != nil {
+		return fmt.Errorf("failed to detect personal records: %w", err)
+	}
+
+	return nil
+}
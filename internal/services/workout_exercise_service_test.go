@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockWorkoutExerciseRepository struct {
+	exercises []*models.WorkoutExercise
+}
+
+func (m *mockWorkoutExerciseRepository) Create(ctx context.Context, exercise *models.WorkoutExercise) error {
+	position := 0
+	for _, existing := range m.exercises {
+		if existing.WorkoutID == exercise.WorkoutID && existing.Position > position {
+			position = existing.Position
+		}
+	}
+	exercise.Position = position + 1
+	m.exercises = append(m.exercises, exercise)
+	return nil
+}
+
+func (m *mockWorkoutExerciseRepository) GetByID(ctx context.Context, id, workoutID uuid.UUID) (*models.WorkoutExercise, error) {
+	for _, exercise := range m.exercises {
+		if exercise.ID == id && exercise.WorkoutID == workoutID {
+			return exercise, nil
+		}
+	}
+	return nil, repositories.ErrNotFound
+}
+
+func (m *mockWorkoutExerciseRepository) ListByWorkoutID(ctx context.Context, workoutID uuid.UUID) ([]*models.WorkoutExercise, error) {
+	var exercises []*models.WorkoutExercise
+	for _, exercise := range m.exercises {
+		if exercise.WorkoutID == workoutID {
+			exercises = append(exercises, exercise)
+		}
+	}
+	return exercises, nil
+}
+
+func (m *mockWorkoutExerciseRepository) Update(ctx context.Context, exercise *models.WorkoutExercise) error {
+	return nil
+}
+
+func (m *mockWorkoutExerciseRepository) Delete(ctx context.Context, id, workoutID uuid.UUID) error {
+	for i, exercise := range m.exercises {
+		if exercise.ID == id && exercise.WorkoutID == workoutID {
+			m.exercises = append(m.exercises[:i], m.exercises[i+1:]...)
+			return nil
+		}
+	}
+	return repositories.ErrNotFound
+}
+
+type mockExerciseSetRepository struct {
+	sets      []*models.ExerciseSet
+	exercises *mockWorkoutExerciseRepository
+	// records, when set, has the set's records dropped on update and delete like the database does.
+	records *mockRecordRepository
+}
+
+func (m *mockExerciseSetRepository) Create(ctx context.Context, set *models.ExerciseSet) error {
+	position := 0
+	for _, existing := range m.sets {
+		if existing.WorkoutExerciseID == set.WorkoutExerciseID && existing.Position > position {
+			position = existing.Position
+		}
+	}
+	set.Position = position + 1
+	m.sets = append(m.sets, set)
+	return nil
+}
+
+func (m *mockExerciseSetRepository) GetByID(ctx context.Context, id, workoutExerciseID uuid.UUID) (*models.ExerciseSet, error) {
+	for _, set := range m.sets {
+		if set.ID == id && set.WorkoutExerciseID == workoutExerciseID {
+			return set, nil
+		}
+	}
+	return nil, repositories.ErrNotFound
+}
+
+func (m *mockExerciseSetRepository) ListByWorkoutExerciseID(
+	ctx context.Context,
+	workoutExerciseID uuid.UUID,
+) ([]*models.ExerciseSet, error) {
+	var sets []*models.ExerciseSet
+	for _, set := range m.sets {
+		if set.WorkoutExerciseID == workoutExerciseID {
+			sets = append(sets, set)
+		}
+	}
+	return sets, nil
+}
+
+func (m *mockExerciseSetRepository) ListByWorkoutID(ctx context.Context, workoutID uuid.UUID) ([]*models.ExerciseSet, error) {
+	var sets []*models.ExerciseSet
+	for _, set := range m.sets {
+		exercise, err := m.exercises.GetByID(ctx, set.WorkoutExerciseID, workoutID)
+		if err == nil && exercise != nil {
+			sets = append(sets, set)
+		}
+	}
+	return sets, nil
+}
+
+func (m *mockExerciseSetRepository) Update(ctx context.Context, set *models.ExerciseSet) error {
+	m.dropRecords(set.ID)
+	return nil
+}
+
+func (m *mockExerciseSetRepository) Delete(ctx context.Context, id, workoutExerciseID uuid.UUID) error {
+	for i, set := range m.sets {
+		if set.ID == id && set.WorkoutExerciseID == workoutExerciseID {
+			m.sets = append(m.sets[:i], m.sets[i+1:]...)
+			m.dropRecords(id)
+			return nil
+		}
+	}
+	return repositories.ErrNotFound
+}
+
+func (m *mockExerciseSetRepository) dropRecords(setID uuid.UUID) {
+	if m.records != nil {
+		m.records.deleteWhere(func(record *models.PersonalRecord) bool {
+			return record.SetID != nil && *record.SetID == setID
+		})
+	}
+}
+
+func newTestWorkoutExerciseService() (WorkoutExerciseService, WorkoutService) {
+	workoutRepo := newMockWorkoutRepository()
+	exerciseRepo := &mockWorkoutExerciseRepository{}
+	setRepo := &mockExerciseSetRepository{exercises: exerciseRepo}
+	catalogRepo := newMockExerciseRepository()
+	records := NewRecordService(newMockRecordRepository(), exerciseRepo, setRepo, catalogRepo)
+	return NewWorkoutExerciseService(workoutRepo, exerciseRepo, setRepo, catalogRepo, records), NewWorkoutService(workoutRepo)
+}
+
+func TestWorkoutExerciseService_LogSets(t *testing.T) {
+	exerciseService, workoutService := newTestWorkoutExerciseService()
+	ctx := context.Background()
+	userID := uuid.New()
+
+	workout, err := workoutService.Create(ctx, userID, &models.CreateWorkoutRequest{Title: "Push day"})
+	require.NoError(t, err)
+
+	bench, err := exerciseService.AddExercise(ctx, userID, workout.ID, &models.CreateWorkoutExerciseRequest{Name: "Bench press"})
+	require.NoError(t, err)
+	dips, err := exerciseService.AddExercise(ctx, userID, workout.ID, &models.CreateWorkoutExerciseRequest{Name: "Dips"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, bench.Position)
+	assert.Equal(t, 2, dips.Position)
+
+	for _, reps := range []int{5, 5, 3} {
+		_, err := exerciseService.AddSet(ctx, userID, workout.ID, bench.ID, &models.CreateExerciseSetRequest{
+			Reps:       reps,
+			Weight:     100,
+			WeightUnit: models.WeightUnitKg,
+		})
+		require.NoError(t, err)
+	}
+
+	sets, err := exerciseService.ListSets(ctx, userID, workout.ID, bench.ID)
+	require.NoError(t, err)
+	require.Len(t, sets, 3)
+	assert.Equal(t, 3, sets[2].Position)
+	assert.Equal(t, 3, sets[2].Reps)
+
+	exercises, err := exerciseService.ListExercises(ctx, userID, workout.ID)
+	require.NoError(t, err)
+	require.Len(t, exercises, 2)
+	assert.Len(t, exercises[0].Sets, 3)
+	assert.Empty(t, exercises[1].Sets)
+}
+
+func TestWorkoutExerciseService_OwnershipEnforced(t *testing.T) {
+	exerciseService, workoutService := newTestWorkoutExerciseService()
+	ctx := context.Background()
+	ownerID := uuid.New()
+	otherID := uuid.New()
+
+	workout, err := workoutService.Create(ctx, ownerID, &models.CreateWorkoutRequest{Title: "Pull day"})
+	require.NoError(t, err)
+	exercise, err := exerciseService.AddExercise(ctx, ownerID, workout.ID, &models.CreateWorkoutExerciseRequest{Name: "Row"})
+	require.NoError(t, err)
+
+	_, err = exerciseService.AddExercise(ctx, otherID, workout.ID, &models.CreateWorkoutExerciseRequest{Name: "Row"})
+	assert.ErrorIs(t, err, ErrWorkoutNotFound)
+
+	_, err = exerciseService.AddSet(ctx, otherID, workout.ID, exercise.ID, &models.CreateExerciseSetRequest{Reps: 8})
+	assert.ErrorIs(t, err, ErrWorkoutNotFound)
+
+	_, err = exerciseService.AddSet(ctx, ownerID, workout.ID, uuid.New(), &models.CreateExerciseSetRequest{Reps: 8})
+	assert.ErrorIs(t, err, ErrWorkoutExerciseNotFound)
+
+	err = exerciseService.RemoveSet(ctx, ownerID, workout.ID, exercise.ID, uuid.New())
+	assert.ErrorIs(t, err, ErrExerciseSetNotFound)
+}
+
+func TestWorkoutExerciseService_AddFromCatalog(t *testing.T) {
+	workoutRepo := newMockWorkoutRepository()
+	exerciseRepo := &mockWorkoutExerciseRepository{}
+	setRepo := &mockExerciseSetRepository{exercises: exerciseRepo}
+	catalogExercise := &models.Exercise{ID: uuid.New(), Name: "Deadlift"}
+	catalogRepo := newMockExerciseRepository(catalogExercise)
+	service := NewWorkoutExerciseService(
+		workoutRepo, exerciseRepo, setRepo, catalogRepo,
+		NewRecordService(newMockRecordRepository(), exerciseRepo, setRepo, catalogRepo),
+	)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	workout, err := NewWorkoutService(workoutRepo).Create(ctx, userID, &models.CreateWorkoutRequest{Title: "Pull day"})
+	require.NoError(t, err)
+
+	entry, err := service.AddExercise(ctx, userID, workout.ID, &models.CreateWorkoutExerciseRequest{ExerciseID: &catalogExercise.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "Deadlift", entry.Name)
+	assert.Equal(t, catalogExercise.ID, *entry.ExerciseID)
+
+	missingID := uuid.New()
+	_, err = service.AddExercise(ctx, userID, workout.ID, &models.CreateWorkoutExerciseRequest{ExerciseID: &missingID})
+	assert.ErrorIs(t, err, ErrExerciseNotFound)
+}
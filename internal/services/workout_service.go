@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWorkoutNotFound     = errors.New("workout not found")
+	ErrInvalidWorkoutTimes = errors.New("workout cannot finish before it starts")
+)
+
+type WorkoutService interface {
+	Create(ctx context.Context, userID uuid.UUID, req *models.CreateWorkoutRequest) (*models.Workout, error)
+	Get(ctx context.Context, userID, workoutID uuid.UUID) (*models.Workout, error)
+	List(ctx context.Context, userID uuid.UUID, page models.Pagination) ([]*models.Workout, error)
+	Update(ctx context.Context, userID, workoutID uuid.UUID, req *models.UpdateWorkoutRequest) (*models.Workout, error)
+	Delete(ctx context.Context, userID, workoutID uuid.UUID) error
+}
+
+type workoutService struct {
+	workoutRepo repositories.WorkoutRepository
+}
+
+func NewWorkoutService(workoutRepo repositories.WorkoutRepository) WorkoutService {
+	return &workoutService{
+		workoutRepo: workoutRepo,
+	}
+}
+
+func (s *workoutService) Create(ctx context.Context, userID uuid.UUID, req *models.CreateWorkoutRequest) (*models.Workout, error) {
+	now := time.Now()
+	startedAt := now
+	if req.StartedAt != nil {
+		startedAt = *req.StartedAt
+	}
+
+	if req.FinishedAt != nil && req.FinishedAt.Before(startedAt) {
+		return nil, ErrInvalidWorkoutTimes
+	}
+
+	workout := &models.Workout{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Title:      req.Title,
+		StartedAt:  startedAt,
+		FinishedAt: req.FinishedAt,
+		Notes:      req.Notes,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := s.workoutRepo.Create(ctx, workout); err != nil {
+		return nil, fmt.Errorf("failed to create workout: %w", err)
+	}
+
+	return workout, nil
+}
+
+func (s *workoutService) Get(ctx context.Context, userID, workoutID uuid.UUID) (*models.Workout, error) {
+	workout, err := s.workoutRepo.GetByID(ctx, workoutID, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrWorkoutNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workout: %w", err)
+	}
+
+	return workout, nil
+}
+
+func (s *workoutService) List(ctx context.Context, userID uuid.UUID, page models.Pagination) ([]*models.Workout, error) {
+	page = page.Normalize()
+
+	workouts, err := s.workoutRepo.ListByUserID(ctx, userID, page.Limit, page.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workouts: %w", err)
+	}
+
+	return workouts, nil
+}
+
+func (s *workoutService) Update(
+	ctx context.Context,
+	userID, workoutID uuid.UUID,
+	req *models.UpdateWorkoutRequest,
+) (*models.Workout, error) {
+	workout, err := s.Get(ctx, userID, workoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		workout.Title = *req.Title
+	}
+	if req.StartedAt != nil {
+		workout.StartedAt = *req.StartedAt
+	}
+	if req.FinishedAt != nil {
+		workout.FinishedAt = req.FinishedAt
+	}
+	if req.ClearFinishedAt {
+		workout.FinishedAt = nil
+	}
+	if req.Notes != nil {
+		workout.Notes = *req.Notes
+	}
+
+	if workout.FinishedAt != nil && workout.FinishedAt.Before(workout.StartedAt) {
+		return nil, ErrInvalidWorkoutTimes
+	}
+
+	workout.UpdatedAt = time.Now()
+
+	err = s.workoutRepo.Update(ctx, workout)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrWorkoutNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update workout: %w", err)
+	}
+
+	return workout, nil
+}
+
+func (s *workoutService) Delete(ctx context.Context, userID, workoutID uuid.UUID) error {
+	err := s.workoutRepo.Delete(ctx, workoutID, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrWorkoutNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete workout: %w", err)
+	}
+
+	return nil
+}
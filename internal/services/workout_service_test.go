@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aleksandr/strive-api/internal/models"
+	"github.com/aleksandr/strive-api/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockWorkoutRepository struct {
+	workouts map[uuid.UUID]*models.Workout
+}
+
+func newMockWorkoutRepository() *mockWorkoutRepository {
+	return &mockWorkoutRepository{
+		workouts: make(map[uuid.UUID]*models.Workout),
+	}
+}
+
+func (m *mockWorkoutRepository) Create(ctx context.Context, workout *models.Workout) error {
+	m.workouts[workout.ID] = workout
+	return nil
+}
+
+func (m *mockWorkoutRepository) CreateWithExercises(ctx context.Context, workout *models.Workout) error {
+	m.workouts[workout.ID] = workout
+	return nil
+}
+
+func (m *mockWorkoutRepository) GetByID(ctx context.Context, id, userID uuid.UUID) (*models.Workout, error) {
+	workout, exists := m.workouts[id]
+	if !exists || workout.UserID != userID {
+		return nil, repositories.ErrNotFound
+	}
+	copied := *workout
+	return &copied, nil
+}
+
+func (m *mockWorkoutRepository) ListByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Workout, error) {
+	var workouts []*models.Workout
+	for _, workout := range m.workouts {
+		if workout.UserID == userID {
+			workouts = append(workouts, workout)
+		}
+	}
+	return workouts, nil
+}
+
+func (m *mockWorkoutRepository) Update(ctx context.Context, workout *models.Workout) error {
+	existing, exists := m.workouts[workout.ID]
+	if !exists || existing.UserID != workout.UserID {
+		return repositories.ErrNotFound
+	}
+	m.workouts[workout.ID] = workout
+	return nil
+}
+
+func (m *mockWorkoutRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	workout, exists := m.workouts[id]
+	if !exists || workout.UserID != userID {
+		return repositories.ErrNotFound
+	}
+	delete(m.workouts, id)
+	return nil
+}
+
+func TestWorkoutService_Create(t *testing.T) {
+	service := NewWorkoutService(newMockWorkoutRepository())
+	userID := uuid.New()
+
+	t.Run("DefaultsStartedAtToNow", func(t *testing.T) {
+		workout, err := service.Create(context.Background(), userID, &models.CreateWorkoutRequest{Title: "Push day"})
+		require.NoError(t, err)
+		assert.Equal(t, userID, workout.UserID)
+		assert.Equal(t, "Push day", workout.Title)
+		assert.WithinDuration(t, time.Now(), workout.StartedAt, time.Second)
+		assert.Nil(t, workout.FinishedAt)
+	})
+
+	t.Run("RejectsFinishBeforeStart", func(t *testing.T) {
+		startedAt := time.Now()
+		finishedAt := startedAt.Add(-time.Hour)
+
+		_, err := service.Create(context.Background(), userID, &models.CreateWorkoutRequest{
+			Title:      "Leg day",
+			StartedAt:  &startedAt,
+			FinishedAt: &finishedAt,
+		})
+		assert.ErrorIs(t, err, ErrInvalidWorkoutTimes)
+	})
+}
+
+func TestWorkoutService_OwnerScoping(t *testing.T) {
+	service := NewWorkoutService(newMockWorkoutRepository())
+	ownerID := uuid.New()
+	otherID := uuid.New()
+
+	workout, err := service.Create(context.Background(), ownerID, &models.CreateWorkoutRequest{Title: "Pull day"})
+	require.NoError(t, err)
+
+	_, err = service.Get(context.Background(), otherID, workout.ID)
+	assert.ErrorIs(t, err, ErrWorkoutNotFound)
+
+	title := "Hijacked"
+	_, err = service.Update(context.Background(), otherID, workout.ID, &models.UpdateWorkoutRequest{Title: &title})
+	assert.ErrorIs(t, err, ErrWorkoutNotFound)
+
+	err = service.Delete(context.Background(), otherID, workout.ID)
+	assert.ErrorIs(t, err, ErrWorkoutNotFound)
+
+	found, err := service.Get(context.Background(), ownerID, workout.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Pull day", found.Title)
+}
+
+func TestWorkoutService_Update(t *testing.T) {
+	service := NewWorkoutService(newMockWorkoutRepository())
+	userID := uuid.New()
+
+	workout, err := service.Create(context.Background(), userID, &models.CreateWorkoutRequest{Title: "Upper", Notes: "old"})
+	require.NoError(t, err)
+
+	t.Run("PartialUpdate", func(t *testing.T) {
+		notes := "new notes"
+		finishedAt := workout.StartedAt.Add(time.Hour)
+
+		updated, err := service.Update(context.Background(), userID, workout.ID, &models.UpdateWorkoutRequest{
+			Notes:      &notes,
+			FinishedAt: &finishedAt,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Upper", updated.Title)
+		assert.Equal(t, "new notes", updated.Notes)
+		require.NotNil(t, updated.FinishedAt)
+		assert.True(t, finishedAt.Equal(*updated.FinishedAt))
+	})
+
+	t.Run("ClearFinishedAt", func(t *testing.T) {
+		updated, err := service.Update(context.Background(), userID, workout.ID, &models.UpdateWorkoutRequest{ClearFinishedAt: true})
+		require.NoError(t, err)
+		assert.Nil(t, updated.FinishedAt, "a workout marked finished by mistake can be reopened")
+		assert.Equal(t, "new notes", updated.Notes)
+	})
+
+	t.Run("RejectsStartAfterFinish", func(t *testing.T) {
+		finishedAt := workout.StartedAt.Add(time.Hour)
+		_, err := service.Update(context.Background(), userID, workout.ID, &models.UpdateWorkoutRequest{FinishedAt: &finishedAt})
+		require.NoError(t, err)
+
+		startedAt := workout.StartedAt.Add(2 * time.Hour)
+		_, err = service.Update(context.Background(), userID, workout.ID, &models.UpdateWorkoutRequest{StartedAt: &startedAt})
+		assert.ErrorIs(t, err, ErrInvalidWorkoutTimes)
+	})
+}
@@ -106,3 +106,32 @@ func ValidateString(value, fieldName string, minLen, maxLen int) error {
 	}
 	return nil
 }
+
+func (ve *ValidationErrors) Add(field string, err error) {
+	if err != nil {
+		*ve = append(*ve, ValidationError{Field: field, Message: err.Error()})
+	}
+}
+
+func ValidateIntRange(value int, fieldName string, minValue, maxValue int) error {
+	if value < minValue || value > maxValue {
+		return fmt.Errorf("%s must be between %d and %d", fieldName, minValue, maxValue)
+	}
+	return nil
+}
+
+func ValidateFloatRange(value float64, fieldName string, minValue, maxValue float64) error {
+	if value < minValue || value > maxValue {
+		return fmt.Errorf("%s must be between %g and %g", fieldName, minValue, maxValue)
+	}
+	return nil
+}
+
+func ValidateOneOf(value, fieldName string, allowed ...string) error {
+	for _, candidate := range allowed {
+		if value == candidate {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s must be one of: %s", fieldName, strings.Join(allowed, ", "))
+}
@@ -0,0 +1,2 @@
+-- Drop workouts table
+DROP TABLE IF EXISTS workouts CASCADE;
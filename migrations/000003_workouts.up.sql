@@ -0,0 +1,19 @@
+-- Create workouts table
+CREATE TABLE IF NOT EXISTS workouts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT workouts_finished_after_started CHECK (finished_at IS NULL OR finished_at >= started_at)
+);
+
+-- Create index for listing a user's workouts
+CREATE INDEX idx_workouts_user_id_started_at ON workouts(user_id, started_at DESC);
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_workouts_updated_at BEFORE UPDATE ON workouts
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
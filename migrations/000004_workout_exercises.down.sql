@@ -0,0 +1,3 @@
+-- Drop exercise_sets and workout_exercises tables
+DROP TABLE IF EXISTS exercise_sets CASCADE;
+DROP TABLE IF EXISTS workout_exercises CASCADE;
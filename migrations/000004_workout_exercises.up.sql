@@ -0,0 +1,36 @@
+-- Create workout_exercises table
+CREATE TABLE IF NOT EXISTS workout_exercises (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    workout_id UUID NOT NULL REFERENCES workouts(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    position INTEGER NOT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create exercise_sets table
+CREATE TABLE IF NOT EXISTS exercise_sets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    workout_exercise_id UUID NOT NULL REFERENCES workout_exercises(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    reps INTEGER NOT NULL CHECK (reps >= 0),
+    weight NUMERIC(7, 2) NOT NULL DEFAULT 0 CHECK (weight >= 0),
+    weight_unit VARCHAR(2) NOT NULL DEFAULT 'kg' CHECK (weight_unit IN ('kg', 'lb')),
+    rpe NUMERIC(3, 1) CHECK (rpe BETWEEN 1 AND 10),
+    rest_seconds INTEGER CHECK (rest_seconds >= 0),
+    is_warmup BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create indexes for ordered lookups
+CREATE INDEX idx_workout_exercises_workout_id ON workout_exercises(workout_id, position);
+CREATE INDEX idx_exercise_sets_workout_exercise_id ON exercise_sets(workout_exercise_id, position);
+
+-- Create triggers for updated_at
+CREATE TRIGGER update_workout_exercises_updated_at BEFORE UPDATE ON workout_exercises
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_exercise_sets_updated_at BEFORE UPDATE ON exercise_sets
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
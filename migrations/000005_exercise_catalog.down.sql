@@ -0,0 +1,5 @@
+-- Unlink workout entries from the catalog
+ALTER TABLE workout_exercises DROP COLUMN IF EXISTS exercise_id;
+
+-- Drop exercises table
+DROP TABLE IF EXISTS exercises CASCADE;
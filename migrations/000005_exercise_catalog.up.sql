@@ -0,0 +1,77 @@
+-- Create exercises table (owner_id NULL means a global catalog exercise)
+CREATE TABLE IF NOT EXISTS exercises (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    primary_muscles TEXT[] NOT NULL DEFAULT '{}',
+    secondary_muscles TEXT[] NOT NULL DEFAULT '{}',
+    equipment VARCHAR(50) NOT NULL,
+    movement_pattern VARCHAR(50) NOT NULL,
+    is_unilateral BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Exercise names are unique per owner, and globally for the shared catalog
+CREATE UNIQUE INDEX idx_exercises_global_name ON exercises(LOWER(name)) WHERE owner_id IS NULL;
+CREATE UNIQUE INDEX idx_exercises_owner_name ON exercises(owner_id, LOWER(name)) WHERE owner_id IS NOT NULL;
+CREATE INDEX idx_exercises_primary_muscles ON exercises USING GIN (primary_muscles);
+CREATE INDEX idx_exercises_secondary_muscles ON exercises USING GIN (secondary_muscles);
+CREATE INDEX idx_exercises_equipment ON exercises(equipment);
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_exercises_updated_at BEFORE UPDATE ON exercises
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Link workout entries to the catalog
+ALTER TABLE workout_exercises
+    ADD COLUMN exercise_id UUID REFERENCES exercises(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_workout_exercises_exercise_id ON workout_exercises(exercise_id);
+
+-- Seed the global catalog
+INSERT INTO exercises (name, primary_muscles, secondary_muscles, equipment, movement_pattern, is_unilateral) VALUES
+    ('Barbell Bench Press', '{chest}', '{triceps,shoulders}', 'barbell', 'horizontal_push', FALSE),
+    ('Incline Barbell Bench Press', '{chest}', '{shoulders,triceps}', 'barbell', 'horizontal_push', FALSE),
+    ('Dumbbell Bench Press', '{chest}', '{triceps,shoulders}', 'dumbbell', 'horizontal_push', FALSE),
+    ('Incline Dumbbell Press', '{chest}', '{shoulders,triceps}', 'dumbbell', 'horizontal_push', FALSE),
+    ('Push-Up', '{chest}', '{triceps,shoulders,abs}', 'bodyweight', 'horizontal_push', FALSE),
+    ('Dip', '{chest,triceps}', '{shoulders}', 'bodyweight', 'vertical_push', FALSE),
+    ('Cable Fly', '{chest}', '{shoulders}', 'cable', 'isolation', FALSE),
+    ('Overhead Press', '{shoulders}', '{triceps,upper_back}', 'barbell', 'vertical_push', FALSE),
+    ('Seated Dumbbell Shoulder Press', '{shoulders}', '{triceps}', 'dumbbell', 'vertical_push', FALSE),
+    ('Lateral Raise', '{shoulders}', '{traps}', 'dumbbell', 'isolation', FALSE),
+    ('Face Pull', '{rear_delts}', '{upper_back,traps}', 'cable', 'horizontal_pull', FALSE),
+    ('Barbell Row', '{upper_back,lats}', '{biceps,rear_delts,lower_back}', 'barbell', 'horizontal_pull', FALSE),
+    ('One-Arm Dumbbell Row', '{lats,upper_back}', '{biceps,rear_delts}', 'dumbbell', 'horizontal_pull', TRUE),
+    ('Seated Cable Row', '{upper_back,lats}', '{biceps,rear_delts}', 'cable', 'horizontal_pull', FALSE),
+    ('Pull-Up', '{lats}', '{biceps,upper_back}', 'bodyweight', 'vertical_pull', FALSE),
+    ('Chin-Up', '{lats,biceps}', '{upper_back}', 'bodyweight', 'vertical_pull', FALSE),
+    ('Lat Pulldown', '{lats}', '{biceps,upper_back}', 'cable', 'vertical_pull', FALSE),
+    ('Barbell Shrug', '{traps}', '{forearms}', 'barbell', 'isolation', FALSE),
+    ('Barbell Curl', '{biceps}', '{forearms}', 'barbell', 'isolation', FALSE),
+    ('Dumbbell Curl', '{biceps}', '{forearms}', 'dumbbell', 'isolation', FALSE),
+    ('Hammer Curl', '{biceps,forearms}', '{}', 'dumbbell', 'isolation', FALSE),
+    ('Triceps Pushdown', '{triceps}', '{}', 'cable', 'isolation', FALSE),
+    ('Skull Crusher', '{triceps}', '{}', 'barbell', 'isolation', FALSE),
+    ('Overhead Triceps Extension', '{triceps}', '{}', 'dumbbell', 'isolation', FALSE),
+    ('Back Squat', '{quadriceps,glutes}', '{hamstrings,adductors,lower_back}', 'barbell', 'squat', FALSE),
+    ('Front Squat', '{quadriceps}', '{glutes,upper_back,abs}', 'barbell', 'squat', FALSE),
+    ('Goblet Squat', '{quadriceps,glutes}', '{adductors,abs}', 'dumbbell', 'squat', FALSE),
+    ('Leg Press', '{quadriceps,glutes}', '{hamstrings,adductors}', 'machine', 'squat', FALSE),
+    ('Bulgarian Split Squat', '{quadriceps,glutes}', '{hamstrings,adductors}', 'dumbbell', 'lunge', TRUE),
+    ('Walking Lunge', '{quadriceps,glutes}', '{hamstrings,adductors}', 'dumbbell', 'lunge', TRUE),
+    ('Leg Extension', '{quadriceps}', '{}', 'machine', 'isolation', FALSE),
+    ('Deadlift', '{hamstrings,glutes,lower_back}', '{quadriceps,upper_back,traps,forearms}', 'barbell', 'hinge', FALSE),
+    ('Romanian Deadlift', '{hamstrings,glutes}', '{lower_back,forearms}', 'barbell', 'hinge', FALSE),
+    ('Hip Thrust', '{glutes}', '{hamstrings}', 'barbell', 'hinge', FALSE),
+    ('Kettlebell Swing', '{glutes,hamstrings}', '{lower_back,shoulders,abs}', 'kettlebell', 'hinge', FALSE),
+    ('Lying Leg Curl', '{hamstrings}', '{calves}', 'machine', 'isolation', FALSE),
+    ('Standing Calf Raise', '{calves}', '{}', 'machine', 'isolation', FALSE),
+    ('Seated Calf Raise', '{calves}', '{}', 'machine', 'isolation', FALSE),
+    ('Plank', '{abs}', '{obliques,shoulders}', 'bodyweight', 'core', FALSE),
+    ('Hanging Leg Raise', '{abs}', '{obliques,forearms}', 'bodyweight', 'core', FALSE),
+    ('Cable Crunch', '{abs}', '{obliques}', 'cable', 'core', FALSE),
+    ('Pallof Press', '{obliques,abs}', '{}', 'cable', 'core', TRUE),
+    ('Farmer''s Carry', '{forearms,traps}', '{abs,glutes}', 'dumbbell', 'carry', FALSE),
+    ('Suitcase Carry', '{obliques,forearms}', '{traps,abs}', 'kettlebell', 'carry', TRUE);
@@ -0,0 +1,3 @@
+-- Drop template_exercises and workout_templates tables
+DROP TABLE IF EXISTS template_exercises CASCADE;
+DROP TABLE IF EXISTS workout_templates CASCADE;
@@ -0,0 +1,32 @@
+-- Create workout_templates table
+CREATE TABLE IF NOT EXISTS workout_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create template_exercises table
+CREATE TABLE IF NOT EXISTS template_exercises (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    template_id UUID NOT NULL REFERENCES workout_templates(id) ON DELETE CASCADE,
+    exercise_id UUID REFERENCES exercises(id) ON DELETE SET NULL,
+    name VARCHAR(255) NOT NULL,
+    position INTEGER NOT NULL,
+    target_sets INTEGER NOT NULL CHECK (target_sets > 0),
+    target_reps INTEGER NOT NULL CHECK (target_reps > 0),
+    target_weight NUMERIC(7, 2) CHECK (target_weight >= 0),
+    weight_unit VARCHAR(2) NOT NULL DEFAULT 'kg' CHECK (weight_unit IN ('kg', 'lb')),
+    rest_seconds INTEGER CHECK (rest_seconds >= 0),
+    notes TEXT NOT NULL DEFAULT ''
+);
+
+-- Create indexes for faster lookups
+CREATE INDEX idx_workout_templates_user_id ON workout_templates(user_id, name);
+CREATE INDEX idx_template_exercises_template_id ON template_exercises(template_id, position);
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_workout_templates_updated_at BEFORE UPDATE ON workout_templates
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
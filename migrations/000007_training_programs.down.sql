@@ -0,0 +1,5 @@
+-- Drop program tables
+DROP TABLE IF EXISTS enrollment_training_maxes CASCADE;
+DROP TABLE IF EXISTS program_enrollments CASCADE;
+DROP TABLE IF EXISTS program_days CASCADE;
+DROP TABLE IF EXISTS programs CASCADE;
@@ -0,0 +1,68 @@
+-- Create programs table
+CREATE TABLE IF NOT EXISTS programs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    weeks INTEGER NOT NULL CHECK (weeks > 0),
+    days_per_week INTEGER NOT NULL CHECK (days_per_week BETWEEN 1 AND 7),
+    is_public BOOLEAN NOT NULL DEFAULT FALSE,
+    progression VARCHAR(20) NOT NULL DEFAULT 'none'
+        CHECK (progression IN ('none', 'fixed_increment', 'percent_of_max')),
+    increment NUMERIC(6, 2) NOT NULL DEFAULT 0 CHECK (increment >= 0),
+    -- The fixed weekly increment is converted into each template exercise's unit
+    increment_unit VARCHAR(2) NOT NULL DEFAULT 'kg' CHECK (increment_unit IN ('kg', 'lb')),
+    deload_weeks INTEGER[] NOT NULL DEFAULT '{}',
+    deload_percent NUMERIC(5, 2) NOT NULL DEFAULT 60 CHECK (deload_percent > 0 AND deload_percent <= 100),
+    load_rounding NUMERIC(5, 2) NOT NULL DEFAULT 2.5 CHECK (load_rounding >= 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create program_days table (templates cannot be deleted while a program uses them)
+CREATE TABLE IF NOT EXISTS program_days (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    program_id UUID NOT NULL REFERENCES programs(id) ON DELETE CASCADE,
+    week INTEGER NOT NULL CHECK (week > 0),
+    day INTEGER NOT NULL CHECK (day BETWEEN 1 AND 7),
+    template_id UUID NOT NULL REFERENCES workout_templates(id) ON DELETE RESTRICT,
+    intensity_percent NUMERIC(5, 2) CHECK (intensity_percent > 0),
+    UNIQUE (program_id, week, day)
+);
+
+-- Create program_enrollments table
+CREATE TABLE IF NOT EXISTS program_enrollments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    program_id UUID NOT NULL REFERENCES programs(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'completed', 'cancelled')),
+    completed_days INTEGER NOT NULL DEFAULT 0 CHECK (completed_days >= 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create enrollment_training_maxes table
+CREATE TABLE IF NOT EXISTS enrollment_training_maxes (
+    enrollment_id UUID NOT NULL REFERENCES program_enrollments(id) ON DELETE CASCADE,
+    exercise_id UUID NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    weight NUMERIC(7, 2) NOT NULL CHECK (weight >= 0),
+    weight_unit VARCHAR(2) NOT NULL DEFAULT 'kg' CHECK (weight_unit IN ('kg', 'lb')),
+    PRIMARY KEY (enrollment_id, exercise_id)
+);
+
+-- A user follows at most one program at a time
+CREATE UNIQUE INDEX idx_program_enrollments_active ON program_enrollments(user_id) WHERE status = 'active';
+
+-- Create indexes for faster lookups
+CREATE INDEX idx_programs_user_id ON programs(user_id);
+CREATE INDEX idx_programs_public ON programs(is_public) WHERE is_public;
+CREATE INDEX idx_program_days_program_id ON program_days(program_id, week, day);
+CREATE INDEX idx_program_days_template_id ON program_days(template_id);
+CREATE INDEX idx_program_enrollments_program_id ON program_enrollments(program_id);
+
+-- Create triggers for updated_at
+CREATE TRIGGER update_programs_updated_at BEFORE UPDATE ON programs
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_program_enrollments_updated_at BEFORE UPDATE ON program_enrollments
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,25 @@
+-- Create personal_records table; each row is a record at the time it was set,
+-- so the current best per kind is the highest value still present
+CREATE TABLE IF NOT EXISTS personal_records (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    exercise_id UUID NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    workout_id UUID NOT NULL REFERENCES workouts(id) ON DELETE CASCADE,
+    set_id UUID REFERENCES exercise_sets(id) ON DELETE CASCADE,
+    record_type VARCHAR(20) NOT NULL
+        CHECK (record_type IN ('max_weight', 'estimated_1rm', 'max_reps', 'session_volume')),
+    formula VARCHAR(10) NOT NULL DEFAULT '' CHECK (formula IN ('', 'epley', 'brzycki')),
+    value NUMERIC(12, 2) NOT NULL,
+    weight_kg NUMERIC(7, 2) NOT NULL DEFAULT 0,
+    weight NUMERIC(7, 2) NOT NULL DEFAULT 0,
+    weight_unit VARCHAR(2) NOT NULL DEFAULT 'kg' CHECK (weight_unit IN ('kg', 'lb')),
+    reps INTEGER NOT NULL DEFAULT 0,
+    achieved_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create indexes for best-record lookups and history
+CREATE INDEX idx_personal_records_lookup
+    ON personal_records(user_id, exercise_id, record_type, formula, weight_kg, value DESC);
+CREATE INDEX idx_personal_records_achieved_at ON personal_records(user_id, achieved_at DESC);
+CREATE INDEX idx_personal_records_workout_id ON personal_records(workout_id);
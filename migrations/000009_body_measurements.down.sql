@@ -0,0 +1,2 @@
+-- Drop measurements table
+DROP TABLE IF EXISTS measurements CASCADE;
@@ -0,0 +1,24 @@
+-- Create measurements table
+CREATE TABLE IF NOT EXISTS measurements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    measured_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    bodyweight NUMERIC(6, 2) CHECK (bodyweight > 0),
+    weight_unit VARCHAR(2) NOT NULL DEFAULT 'kg' CHECK (weight_unit IN ('kg', 'lb')),
+    body_fat_percent NUMERIC(4, 1) CHECK (body_fat_percent > 0 AND body_fat_percent < 100),
+    circumferences JSONB NOT NULL DEFAULT '{}',
+    length_unit VARCHAR(2) NOT NULL DEFAULT 'cm' CHECK (length_unit IN ('cm', 'in')),
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT measurements_has_value CHECK (
+        bodyweight IS NOT NULL OR body_fat_percent IS NOT NULL OR circumferences <> '{}'
+    )
+);
+
+-- Create index for range queries
+CREATE INDEX idx_measurements_user_measured_at ON measurements(user_id, measured_at DESC);
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_measurements_updated_at BEFORE UPDATE ON measurements
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,10 @@
+-- Drop profile and preference columns from users
+ALTER TABLE users
+    DROP COLUMN IF EXISTS display_name,
+    DROP COLUMN IF EXISTS birth_date,
+    DROP COLUMN IF EXISTS sex,
+    DROP COLUMN IF EXISTS height_cm,
+    DROP COLUMN IF EXISTS weight_unit,
+    DROP COLUMN IF EXISTS timezone,
+    DROP COLUMN IF EXISTS locale,
+    DROP COLUMN IF EXISTS week_start;
@@ -0,0 +1,10 @@
+-- Add profile and preference columns to users
+ALTER TABLE users
+    ADD COLUMN display_name VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN birth_date DATE,
+    ADD COLUMN sex VARCHAR(10) CHECK (sex IN ('male', 'female', 'other')),
+    ADD COLUMN height_cm NUMERIC(5, 1) CHECK (height_cm > 0),
+    ADD COLUMN weight_unit VARCHAR(2) NOT NULL DEFAULT 'kg' CHECK (weight_unit IN ('kg', 'lb')),
+    ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    ADD COLUMN locale VARCHAR(35) NOT NULL DEFAULT 'en',
+    ADD COLUMN week_start VARCHAR(8) NOT NULL DEFAULT 'monday' CHECK (week_start IN ('monday', 'sunday', 'saturday'));
@@ -0,0 +1,5 @@
+-- Drop email_verification_tokens table
+DROP TABLE IF EXISTS email_verification_tokens CASCADE;
+
+-- Remove verification timestamp
+ALTER TABLE users DROP COLUMN IF EXISTS email_verified_at;
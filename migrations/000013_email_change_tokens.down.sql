@@ -0,0 +1,2 @@
+-- Drop email_change_tokens table
+DROP TABLE IF EXISTS email_change_tokens CASCADE;
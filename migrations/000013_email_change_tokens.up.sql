@@ -0,0 +1,14 @@
+-- Create email_change_tokens table
+CREATE TABLE IF NOT EXISTS email_change_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    new_email VARCHAR(255) NOT NULL,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create indexes
+CREATE INDEX idx_email_change_tokens_user_id ON email_change_tokens(user_id);
+CREATE INDEX idx_email_change_tokens_expires_at ON email_change_tokens(expires_at);
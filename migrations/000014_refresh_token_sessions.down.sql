@@ -0,0 +1,6 @@
+-- Remove device columns from refresh_tokens
+ALTER TABLE refresh_tokens
+    DROP COLUMN IF EXISTS user_agent,
+    DROP COLUMN IF EXISTS ip_address,
+    DROP COLUMN IF EXISTS device_name,
+    DROP COLUMN IF EXISTS last_used_at;
@@ -0,0 +1,6 @@
+-- Record which device each refresh token belongs to
+ALTER TABLE refresh_tokens
+    ADD COLUMN user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    ADD COLUMN ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN device_name VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN last_used_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
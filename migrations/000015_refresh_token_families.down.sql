@@ -0,0 +1,8 @@
+-- Revoked tokens were previously deleted
+DELETE FROM refresh_tokens WHERE revoked_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_refresh_tokens_family_id;
+
+ALTER TABLE refresh_tokens
+    DROP COLUMN IF EXISTS family_id,
+    DROP COLUMN IF EXISTS revoked_at;
@@ -0,0 +1,11 @@
+-- Group rotated refresh tokens into families and keep them as revoked instead of deleting them
+ALTER TABLE refresh_tokens
+    ADD COLUMN family_id UUID,
+    ADD COLUMN revoked_at TIMESTAMP WITH TIME ZONE;
+
+-- Every existing token starts its own family
+UPDATE refresh_tokens SET family_id = id WHERE family_id IS NULL;
+
+ALTER TABLE refresh_tokens ALTER COLUMN family_id SET NOT NULL;
+
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens(family_id);
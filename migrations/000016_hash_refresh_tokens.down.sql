@@ -0,0 +1,8 @@
+-- Digests cannot be turned back into tokens, so every session is signed out
+DELETE FROM refresh_tokens;
+
+ALTER INDEX IF EXISTS idx_refresh_tokens_token_hash RENAME TO idx_refresh_tokens_token;
+
+ALTER TABLE refresh_tokens ALTER COLUMN token_hash TYPE VARCHAR(255);
+
+ALTER TABLE refresh_tokens RENAME COLUMN token_hash TO token;
@@ -0,0 +1,8 @@
+-- Store only the SHA-256 digest of refresh tokens; existing tokens are converted in place
+ALTER TABLE refresh_tokens RENAME COLUMN token TO token_hash;
+
+UPDATE refresh_tokens SET token_hash = encode(sha256(convert_to(token_hash, 'UTF8')), 'hex');
+
+ALTER TABLE refresh_tokens ALTER COLUMN token_hash TYPE VARCHAR(64);
+
+ALTER INDEX idx_refresh_tokens_token RENAME TO idx_refresh_tokens_token_hash;
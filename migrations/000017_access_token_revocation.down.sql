@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS revoked_access_tokens;
+
+DROP INDEX IF EXISTS idx_users_tokens_valid_after;
+
+ALTER TABLE users DROP COLUMN IF EXISTS tokens_valid_after;
@@ -0,0 +1,14 @@
+-- Access tokens issued before this instant are rejected; set when a user's credentials change
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tokens_valid_after TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_users_tokens_valid_after ON users(tokens_valid_after) WHERE tokens_valid_after IS NOT NULL;
+
+-- Create revoked_access_tokens table; rows are only needed until the token expires
+CREATE TABLE IF NOT EXISTS revoked_access_tokens (
+    jti UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_revoked_access_tokens_expires_at ON revoked_access_tokens(expires_at);
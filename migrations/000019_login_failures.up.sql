@@ -0,0 +1,12 @@
+-- Create login_failures table; failed sign-ins counted per account (email, known or not) and per IP address
+CREATE TABLE IF NOT EXISTS login_failures (
+    scope VARCHAR(16) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    failures INTEGER NOT NULL,
+    last_failure_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    -- Where last_failure_at was before the latest attempt, so releasing that attempt does not move the window
+    previous_failure_at TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (scope, key)
+);
+
+CREATE INDEX idx_login_failures_last_failure_at ON login_failures(last_failure_at);
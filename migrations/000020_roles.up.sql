@@ -0,0 +1,30 @@
+-- Create roles table; every user has exactly one role
+CREATE TABLE IF NOT EXISTS roles (
+    name VARCHAR(16) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create role_permissions table; a role grants exactly the permissions listed here
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role VARCHAR(16) NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+    permission VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (role, permission)
+);
+
+INSERT INTO roles (name, description) VALUES
+    ('user', 'Keeps their own training diary'),
+    ('coach', 'Guides other users'' training'),
+    ('admin', 'Supports users and operates the service')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'users:read'),
+    ('admin', 'users:manage'),
+    ('admin', 'audit:read')
+ON CONFLICT (role, permission) DO NOTHING;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(16) NOT NULL DEFAULT 'user' REFERENCES roles(name);
+
+CREATE INDEX idx_users_role ON users(role) WHERE role <> 'user';